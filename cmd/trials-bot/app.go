@@ -13,6 +13,8 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/bot"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webapi"
 )
 
 func start(c config) error {
@@ -55,6 +57,10 @@ func start(c config) error {
 	if deps.promHandler != nil {
 		mux.Handle("/metrics", deps.promHandler)
 	}
+	if deps.calendarHandler != nil {
+		mux.Handle("/guilds/", deps.calendarHandler)
+	}
+	mux.Handle(webapi.PathPrefix, deps.webapiHandler)
 
 	prom := &http.Server{
 		Addr:         c.PrometheusHostPort,
@@ -78,6 +84,9 @@ func runAll(deps *dependencies, b *bot.DiscordBot, srv *http.Server) func(contex
 		g.Go(serverStartFunc(deps, srv))
 		g.Go(serverShutdownFunc(ctx, deps, srv))
 		g.Go(func() error { return deps.statsHub.Start(ctx) })
+		g.Go(func() error { return deps.scheduler.Start(ctx) })
+		g.Go(func() error { return deps.webhooks.Start(ctx) })
+		g.Go(func() error { return deps.liveMessages.Start(ctx) })
 
 		return g.Wait()
 	}
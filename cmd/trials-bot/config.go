@@ -34,18 +34,21 @@ type config struct {
 	PostgresStatementCacehMode     string  `mapstructure:"postgres_statement_cache_mode"`
 	PostgresMinPoolSize            int32   `mapstructure:"postgres_min_pool_size"`
 	PostgresMaxPoolSize            int32   `mapstructure:"postgres_max_pool_size"`
+	CalendarBaseURL                string  `mapstructure:"calendar_base_url"`
 
 	ClientSecretVar    string `mapstructure:"client_secret_var"`
 	ClientTokenVar     string `mapstructure:"client_token_var"`
 	PostgresCredsVar   string `mapstructure:"postgres_creds_var"`
 	BugsnagAPIKeyVar   string `mapstructure:"bugsnag_apikey_var"`
 	HoneycombAPIKeyVar string `mapstructure:"honeycomb_apikey_var"`
+	CalendarSecretVar  string `mapstructure:"calendar_secret_var"`
 
 	ClientID        string `mapstructure:"-"`
 	ClientSecret    string `mapstructure:"-"`
 	ClientToken     string `mapstructure:"-"`
 	BugsnagAPIKey   string `mapstructure:"-"`
 	HoneycombAPIKey string `mapstructure:"-"`
+	CalendarSecret  string `mapstructure:"-"`
 	PgDetails       string `mapstructure:"-"`
 }
 
@@ -79,6 +82,14 @@ func (c *config) FillSecrets() error {
 	}
 	c.HoneycombAPIKey = strings.TrimSpace(data)
 
+	// calendar feeds are optional
+	if c.CalendarSecretVar != "" {
+		if data, ok = os.LookupEnv(c.CalendarSecretVar); !ok {
+			return errors.Wrap(ErrMissingSecret, "could not read calendar secret", "var", c.CalendarSecretVar)
+		}
+		c.CalendarSecret = strings.TrimSpace(data)
+	}
+
 	if data, ok = os.LookupEnv(c.PostgresCredsVar); !ok {
 		return errors.Wrap(ErrMissingSecret, "could not read postgres creds", "var", c.PostgresCredsVar)
 	}
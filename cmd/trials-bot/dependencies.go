@@ -26,13 +26,24 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/bugsnag"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/calendar"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/commands"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/directmsg"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/fileupload"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/guildemojis"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/livemessages"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/membersearch"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/permissions"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/pgxutil"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/reactions"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/scheduler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/stats"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webapi"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 )
 
 const DiscordAPI = "https://discord.com/api/v8"
@@ -47,12 +58,22 @@ type Logger = interface {
 type dependencies struct {
 	logger Logger
 
-	db       *pgxpool.Pool
-	trialAPI storage.TrialAPI
-	guildAPI storage.GuildAPI
+	db            *pgxpool.Pool
+	trialAPI      storage.TrialAPI
+	guildAPI      storage.GuildAPI
+	jobAPI        storage.JobAPI
+	memberAPI     storage.MemberAPI
+	attendanceAPI storage.AttendanceAPI
+	activityAPI   storage.ActivityAPI
+	apiTokenAPI   storage.APITokenAPI
+	webhookAPI    storage.WebhookAPI
+	liveMsgAPI    storage.LiveMessageAPI
+	eventMsgAPI   storage.EventMessageAPI
+	templateAPI   storage.TemplateAPI
 
 	httpDoer   httpclient.Doer
 	httpClient *httpclient.HTTPClient
+	restClient *discordrest.Client
 	wsDialer   wsclient.Dialer
 	wsClient   *wsclient.WSClient
 	jsClient   *jsonapi.DiscordJSONClient
@@ -60,6 +81,7 @@ type dependencies struct {
 	messageRateLimiter   *rate.Limiter
 	connectRateLimiter   *rate.Limiter
 	reactionsRateLimiter *rate.Limiter
+	restRateLimiter      *rate.Limiter
 	botSession           *session.Session
 
 	cmdHandler            *cmdhandler.CommandHandler
@@ -67,6 +89,8 @@ type dependencies struct {
 	adminHandler          *cmdhandler.CommandHandler
 	debugHandler          *cmdhandler.CommandHandler
 	reactionHandler       reactions.Handler
+	componentHandler      components.Handler
+	modalHandler          components.ModalHandler
 	interactionDispatcher *cmdhandler.InteractionDispatcher
 	discordMsgHandler     *dispatcher.Dispatcher
 	msgHandlers           msghandler.Handlers
@@ -81,6 +105,21 @@ type dependencies struct {
 
 	statsHub *stats.Hub
 
+	scheduler      *scheduler.Scheduler
+	directMessages *directmsg.Opener
+	uploader       *fileupload.Uploader
+	memberSearch   *membersearch.Searcher
+	guildEmojis    *guildemojis.Lister
+	components     *components.Attacher
+	modalDrafts    *components.Drafts
+
+	calendarHandler *calendar.Handler
+	calendarLinks   *calendar.Links
+
+	webapiHandler *webapi.Handler
+	webhooks      *webhooks.Queue
+	liveMessages  *livemessages.Updater
+
 	sendAllowed            bool
 	interactionSendAllowed bool
 }
@@ -98,6 +137,7 @@ func createDependencies(conf config, botPermissions, botIntents int) (*dependenc
 		connectRateLimiter:     rate.NewLimiter(rate.Every(5*time.Second), 1),
 		messageRateLimiter:     rate.NewLimiter(rate.Every(60*time.Second), 120),
 		reactionsRateLimiter:   rate.NewLimiter(rate.Every(500*time.Millisecond), 1),
+		restRateLimiter:        rate.NewLimiter(rate.Every(25*time.Millisecond), 10),
 		botSession:             session.NewSession(),
 		statsHub:               stats.NewHub(),
 	}
@@ -180,6 +220,62 @@ func createDependencies(conf config, botPermissions, botIntents int) (*dependenc
 		return d, err
 	}
 
+	d.jobAPI, err = storage.NewPgJobAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.memberAPI, err = storage.NewPgMemberAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.attendanceAPI, err = storage.NewPgAttendanceAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.activityAPI, err = storage.NewPgActivityAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.apiTokenAPI, err = storage.NewPgAPITokenAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.webhookAPI, err = storage.NewPgWebhookAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.liveMsgAPI, err = storage.NewPgLiveMessageAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.eventMsgAPI, err = storage.NewPgEventMessageAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.templateAPI, err = storage.NewPgTemplateAPI(d.db, d.census)
+	if err != nil {
+		return d, err
+	}
+
+	d.scheduler = scheduler.NewScheduler(d, scheduler.Options{})
+	d.webhooks = webhooks.NewQueue(d, webhooks.Options{})
+
+	if conf.CalendarSecret != "" {
+		signer := calendar.NewSigner(conf.CalendarSecret)
+		d.calendarHandler = calendar.NewHandler(d, signer)
+		d.calendarLinks = calendar.NewLinks(conf.CalendarBaseURL, signer)
+	}
+
+	d.webapiHandler = webapi.NewHandler(d)
+
 	d.httpClient = httpclient.NewHTTPClient(d)
 
 	// d.httpClient.SetDebug(true)
@@ -188,6 +284,14 @@ func createDependencies(conf config, botPermissions, botIntents int) (*dependenc
 	h.Add("User-Agent", fmt.Sprintf("DiscordBot (%s, %s)", conf.ClientURL, BuildVersion))
 	h.Add("Authorization", fmt.Sprintf("Bot %s", conf.ClientToken))
 	d.httpClient.SetHeaders(h)
+	d.restClient = discordrest.NewClient(d.httpDoer, DiscordAPI, h, d.restRateLimiter, discordrest.Options{})
+	d.directMessages = directmsg.NewOpener(d.restClient)
+	d.uploader = fileupload.NewUploader(d.restClient, d.httpDoer)
+	d.memberSearch = membersearch.NewSearcher(d.restClient)
+	d.guildEmojis = guildemojis.NewLister(d.restClient)
+	d.components = components.NewAttacher(d.restClient)
+	d.modalDrafts = components.NewDrafts(15 * time.Minute)
+	d.liveMessages = livemessages.NewUpdater(d, commands.NewLiveRenderer(d), d.restClient, livemessages.Options{})
 
 	d.wsClient = wsclient.NewWSClient(d, wsclient.Options{MaxConcurrentHandlers: conf.NumWorkers})
 	d.jsClient = jsonapi.NewDiscordJSONClient(d, DiscordAPI)
@@ -229,6 +333,8 @@ func createDependencies(conf config, botPermissions, botIntents int) (*dependenc
 	}
 
 	d.reactionHandler = commands.NewReactionHandler(d)
+	d.componentHandler = commands.NewComponentHandler(d)
+	d.modalHandler = ac
 
 	d.discordMsgHandler = dispatcher.NewDispatcher(d)
 
@@ -238,6 +344,10 @@ func createDependencies(conf config, botPermissions, botIntents int) (*dependenc
 		SuccessColor:            0xaa63ff,
 	})
 
+	if err := commands.NewJobHandlers(d).RegisterJobs(d.scheduler); err != nil {
+		return d, err
+	}
+
 	if err := d.interactionDispatcher.LearnGlobalCommands(uc.GlobalCommands()); err != nil {
 		return d, err
 	}
@@ -312,6 +422,15 @@ func (d *dependencies) InteractionSendAllowed() bool                  { return d
 func (d *dependencies) Logger() Logger                                { return d.logger }
 func (d *dependencies) GuildAPI() storage.GuildAPI                    { return d.guildAPI }
 func (d *dependencies) TrialAPI() storage.TrialAPI                    { return d.trialAPI }
+func (d *dependencies) JobAPI() storage.JobAPI                        { return d.jobAPI }
+func (d *dependencies) MemberAPI() storage.MemberAPI                  { return d.memberAPI }
+func (d *dependencies) AttendanceAPI() storage.AttendanceAPI          { return d.attendanceAPI }
+func (d *dependencies) ActivityAPI() storage.ActivityAPI              { return d.activityAPI }
+func (d *dependencies) APITokenAPI() storage.APITokenAPI              { return d.apiTokenAPI }
+func (d *dependencies) WebhookAPI() storage.WebhookAPI                { return d.webhookAPI }
+func (d *dependencies) LiveMessageAPI() storage.LiveMessageAPI        { return d.liveMsgAPI }
+func (d *dependencies) EventMessageAPI() storage.EventMessageAPI      { return d.eventMsgAPI }
+func (d *dependencies) TemplateAPI() storage.TemplateAPI              { return d.templateAPI }
 func (d *dependencies) HTTPDoer() httpclient.Doer                     { return d.httpDoer }
 func (d *dependencies) HTTPClient() jsonapi.HTTPClient                { return d.httpClient }
 func (d *dependencies) WSDialer() wsclient.Dialer                     { return d.wsDialer }
@@ -326,11 +445,23 @@ func (d *dependencies) ConfigHandler() *cmdhandler.CommandHandler     { return d
 func (d *dependencies) AdminHandler() *cmdhandler.CommandHandler      { return d.adminHandler }
 func (d *dependencies) DebugHandler() *cmdhandler.CommandHandler      { return d.debugHandler }
 func (d *dependencies) ReactionHandler() reactions.Handler            { return d.reactionHandler }
+func (d *dependencies) ComponentHandler() components.Handler          { return d.componentHandler }
+func (d *dependencies) ComponentAttacher() *components.Attacher       { return d.components }
+func (d *dependencies) ModalHandler() components.ModalHandler         { return d.modalHandler }
+func (d *dependencies) ModalDrafts() *components.Drafts               { return d.modalDrafts }
 func (d *dependencies) MessageHandler() msghandler.Handlers           { return d.msgHandlers }
 func (d *dependencies) ErrReporter() errreport.Reporter               { return d.rep }
 func (d *dependencies) Census() *telemetry.Census                     { return d.census }
 func (d *dependencies) Bot() *bot.DiscordBot                          { return d.bot }
 func (d *dependencies) StatsHub() *stats.Hub                          { return d.statsHub }
+func (d *dependencies) Scheduler() *scheduler.Scheduler               { return d.scheduler }
+func (d *dependencies) DirectMessages() *directmsg.Opener             { return d.directMessages }
+func (d *dependencies) Uploader() *fileupload.Uploader                { return d.uploader }
+func (d *dependencies) MemberSearch() *membersearch.Searcher          { return d.memberSearch }
+func (d *dependencies) GuildEmojis() *guildemojis.Lister              { return d.guildEmojis }
+func (d *dependencies) CalendarLinks() *calendar.Links                { return d.calendarLinks }
+func (d *dependencies) Webhooks() *webhooks.Queue                     { return d.webhooks }
+func (d *dependencies) LiveMessages() *livemessages.Updater           { return d.liveMessages }
 func (d *dependencies) Dispatcher() bot.Dispatcher                    { return d.discordMsgHandler }
 func (d *dependencies) PermissionsManager() *permissions.Manager {
 	return d.permissionsManager
@@ -0,0 +1,20 @@
+-- Write your migrate up statements here
+
+ALTER TABLE guild_settings
+    ADD COLUMN signup_limit INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN signup_limit_per_category BOOLEAN NOT NULL DEFAULT 'f';
+
+ALTER TABLE events
+    ADD COLUMN event_category TEXT NOT NULL DEFAULT '';
+
+---- create above / drop below ----
+
+ALTER TABLE guild_settings
+    DROP COLUMN signup_limit,
+    DROP COLUMN signup_limit_per_category;
+
+ALTER TABLE events
+    DROP COLUMN event_category;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,30 @@
+-- Write your migrate up statements here
+
+CREATE TABLE scheduled_jobs (
+    job_id BIGSERIAL PRIMARY KEY,
+    guild_id CHAR(20) NOT NULL,
+    event_name VARCHAR(255) NOT NULL DEFAULT '',
+    job_kind VARCHAR(255) NOT NULL,
+    run_at TIMESTAMPTZ NOT NULL,
+    job_status VARCHAR(255) NOT NULL DEFAULT 'pending',
+    payload TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX scheduled_jobs_pending_idx ON scheduled_jobs (run_at) WHERE job_status = 'pending';
+
+CREATE INDEX scheduled_jobs_event_idx ON scheduled_jobs (guild_id, event_name);
+
+CREATE TRIGGER update_scheduled_jobs_updated_at
+    BEFORE UPDATE ON scheduled_jobs
+    FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+---- create above / drop below ----
+
+DROP TRIGGER update_scheduled_jobs_updated_at ON scheduled_jobs;
+
+DROP TABLE scheduled_jobs;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
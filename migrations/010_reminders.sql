@@ -0,0 +1,30 @@
+-- Write your migrate up statements here
+
+ALTER TABLE guild_settings
+    ADD COLUMN reminder_offsets TEXT NOT NULL DEFAULT '',
+    ADD COLUMN reminder_delivery VARCHAR(255) NOT NULL DEFAULT 'channel';
+
+CREATE TABLE member_preferences (
+    guild_id CHAR(20),
+    member_id VARCHAR(255),
+    reminders_opt_out BOOLEAN NOT NULL DEFAULT 'f',
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, member_id)
+);
+
+CREATE TRIGGER update_member_preferences_updated_at
+    BEFORE UPDATE ON member_preferences
+    FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+---- create above / drop below ----
+
+DROP TRIGGER update_member_preferences_updated_at ON member_preferences;
+
+DROP TABLE member_preferences;
+
+ALTER TABLE guild_settings
+    DROP COLUMN reminder_offsets,
+    DROP COLUMN reminder_delivery;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,31 @@
+-- Write your migrate up statements here
+
+CREATE TABLE attendance (
+    guild_id CHAR(20),
+    event_name VARCHAR(255),
+    occurrence VARCHAR(255),
+    member VARCHAR(255),
+    member_role VARCHAR(255) NOT NULL DEFAULT '',
+    attendance_status VARCHAR(32) NOT NULL,
+    recorded_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, event_name, occurrence, member)
+);
+
+CREATE INDEX attendance_member_idx ON attendance (guild_id, member);
+
+CREATE TRIGGER update_attendance_updated_at
+    BEFORE UPDATE ON attendance
+    FOR EACH ROW EXECUTE PROCEDURE update_updated_at_column();
+
+---- create above / drop below ----
+
+DROP TRIGGER update_attendance_updated_at ON attendance;
+
+DROP INDEX attendance_member_idx;
+
+DROP TABLE attendance;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
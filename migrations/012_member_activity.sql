@@ -0,0 +1,25 @@
+-- Write your migrate up statements here
+
+CREATE TABLE member_activity (
+    activity_id BIGSERIAL PRIMARY KEY,
+    guild_id CHAR(20) NOT NULL,
+    member VARCHAR(255) NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    member_role VARCHAR(255) NOT NULL DEFAULT '',
+    activity_kind VARCHAR(32) NOT NULL,
+    late BOOLEAN NOT NULL DEFAULT 'f',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX member_activity_guild_created_idx ON member_activity (guild_id, created_at);
+CREATE INDEX member_activity_member_idx ON member_activity (guild_id, member);
+
+---- create above / drop below ----
+
+DROP INDEX member_activity_member_idx;
+DROP INDEX member_activity_guild_created_idx;
+
+DROP TABLE member_activity;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
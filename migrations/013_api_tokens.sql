@@ -0,0 +1,22 @@
+-- Write your migrate up statements here
+
+CREATE TABLE api_tokens (
+    token_id BIGSERIAL PRIMARY KEY,
+    guild_id CHAR(20) NOT NULL,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMPTZ
+);
+
+CREATE INDEX api_tokens_guild_idx ON api_tokens (guild_id);
+
+---- create above / drop below ----
+
+DROP INDEX api_tokens_guild_idx;
+
+DROP TABLE api_tokens;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
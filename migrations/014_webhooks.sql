@@ -0,0 +1,27 @@
+-- Write your migrate up statements here
+
+CREATE TABLE webhooks (
+    webhook_id BIGSERIAL PRIMARY KEY,
+    guild_id CHAR(20) NOT NULL,
+    url TEXT NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX webhooks_guild_idx ON webhooks (guild_id);
+
+CREATE TABLE webhook_secrets (
+    guild_id CHAR(20) PRIMARY KEY,
+    secret CHAR(64) NOT NULL
+);
+
+---- create above / drop below ----
+
+DROP TABLE webhook_secrets;
+
+DROP INDEX webhooks_guild_idx;
+
+DROP TABLE webhooks;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,21 @@
+-- Write your migrate up statements here
+
+CREATE TABLE live_messages (
+    message_id CHAR(20) PRIMARY KEY,
+    channel_id CHAR(20) NOT NULL,
+    guild_id CHAR(20) NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX live_messages_event_idx ON live_messages (guild_id, event_name);
+
+---- create above / drop below ----
+
+DROP INDEX live_messages_event_idx;
+
+DROP TABLE live_messages;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
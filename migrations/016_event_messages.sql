@@ -0,0 +1,21 @@
+-- Write your migrate up statements here
+
+CREATE TABLE event_messages (
+    channel_id CHAR(20),
+    message_id CHAR(20),
+    guild_id CHAR(20) NOT NULL,
+    event_name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (channel_id, message_id)
+);
+
+CREATE INDEX event_messages_event_idx ON event_messages (guild_id, LOWER(event_name));
+
+---- create above / drop below ----
+
+DROP INDEX event_messages_event_idx;
+
+DROP TABLE event_messages;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,12 @@
+-- Write your migrate up statements here
+
+ALTER TABLE guild_settings
+    ADD COLUMN language VARCHAR(16) NOT NULL DEFAULT 'en';
+
+---- create above / drop below ----
+
+ALTER TABLE guild_settings
+    DROP COLUMN language;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,16 @@
+-- Write your migrate up statements here
+
+CREATE TABLE message_templates (
+    guild_id CHAR(20) NOT NULL,
+    kind VARCHAR(32) NOT NULL,
+    template TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, kind)
+);
+
+---- create above / drop below ----
+
+DROP TABLE message_templates;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
@@ -0,0 +1,12 @@
+-- Write your migrate up statements here
+
+ALTER TABLE guild_settings
+    ADD COLUMN role_aliases TEXT NOT NULL DEFAULT '';
+
+---- create above / drop below ----
+
+ALTER TABLE guild_settings
+    DROP COLUMN role_aliases;
+
+-- Write your migrate down statements here. If this migration is irreversible
+-- Then delete the separator line above.
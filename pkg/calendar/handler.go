@@ -0,0 +1,216 @@
+package calendar
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type Logger = interface {
+	Log(keyvals ...interface{}) error
+	Message(string, ...interface{})
+	Err(string, error, ...interface{})
+	Printf(string, ...interface{})
+}
+
+type dependencies interface {
+	Logger() Logger
+	TrialAPI() storage.TrialAPI
+	Census() *telemetry.Census
+}
+
+// Links builds the urls of calendar feeds
+type Links struct {
+	baseURL string
+	signer  *Signer
+}
+
+// NewLinks creates a new Links for feeds served under baseURL
+func NewLinks(baseURL string, signer *Signer) *Links {
+	return &Links{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		signer:  signer,
+	}
+}
+
+// GuildFeedURL is the url of the feed of all open events in a guild
+func (l *Links) GuildFeedURL(gid snowflake.Snowflake) string {
+	return fmt.Sprintf("%s/guilds/%s/events.ics?token=%s", l.baseURL, gid.ToString(), l.signer.Token(gid.ToString(), ""))
+}
+
+// MemberFeedURL is the url of the feed of the open events a member is signed up for
+func (l *Links) MemberFeedURL(gid, uid snowflake.Snowflake) string {
+	return fmt.Sprintf("%s/guilds/%s/members/%s/events.ics?token=%s", l.baseURL, gid.ToString(), uid.ToString(), l.signer.Token(gid.ToString(), uid.ToString()))
+}
+
+// Handler serves calendar feeds at /guilds/{gid}/events.ics and /guilds/{gid}/members/{uid}/events.ics
+type Handler struct {
+	deps   dependencies
+	signer *Signer
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a new Handler
+func NewHandler(deps dependencies, signer *Signer) *Handler {
+	return &Handler{
+		deps:   deps,
+		signer: signer,
+	}
+}
+
+func parseFeedPath(p string) (gid, uid snowflake.Snowflake, ok bool) {
+	parts := strings.Split(strings.Trim(p, "/"), "/")
+
+	switch {
+	case len(parts) == 3 && parts[0] == "guilds" && parts[2] == "events.ics":
+	case len(parts) == 5 && parts[0] == "guilds" && parts[2] == "members" && parts[4] == "events.ics":
+		var err error
+		if uid, err = snowflake.FromString(parts[3]); err != nil {
+			return 0, 0, false
+		}
+	default:
+		return 0, 0, false
+	}
+
+	gid, err := snowflake.FromString(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return gid, uid, true
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx, span := h.deps.Census().StartSpan(req.Context(), "calendar.ServeHTTP")
+	defer span.End()
+
+	logger := h.deps.Logger()
+
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	gid, uid, ok := parseFeedPath(req.URL.Path)
+	if !ok {
+		http.NotFound(w, req)
+		return
+	}
+
+	var uidStr string
+	if uid != 0 {
+		uidStr = uid.ToString()
+	}
+
+	if !h.signer.Verify(gid.ToString(), uidStr, req.URL.Query().Get("token")) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	cal, err := h.calendar(ctx, gid, uid)
+	if err != nil {
+		level.Error(logger).Err("could not build calendar", err, "guild_id", gid.ToString())
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+
+	if req.Method == http.MethodHead {
+		return
+	}
+
+	if err := cal.Render(w, time.Now()); err != nil {
+		level.Error(logger).Err("could not write calendar", err, "guild_id", gid.ToString())
+	}
+}
+
+func (h *Handler) calendar(ctx context.Context, gid, uid snowflake.Snowflake) (Calendar, error) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "calendar.calendar", "guild_id", gid.ToString())
+	defer span.End()
+
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		return Calendar{}, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	return buildCalendar(ctx, gid, uid, t.GetTrials(ctx)), nil
+}
+
+// memberRole finds the role a member fills in an event (as assigned by the roster), checking both
+// account and nickname mentions; it is "" for a member in overflow
+func memberRole(ctx context.Context, trial storage.Trial, uid snowflake.Snowflake) (string, bool) {
+	account := fmt.Sprintf("<@%s>", uid.ToString())
+	nickname := fmt.Sprintf("<@!%s>", uid.ToString())
+
+	for _, su := range trial.GetSignups(ctx) {
+		if name := su.GetName(ctx); name == account || name == nickname {
+			return storage.TrialRoster(ctx, trial).AssignedRole(name), true
+		}
+	}
+
+	return "", false
+}
+
+// buildCalendar turns the open events (only those the member is signed up for, if uid is set) into a
+// calendar; events whose time cannot be understood are left out and listed in the calendar description
+func buildCalendar(ctx context.Context, gid, uid snowflake.Snowflake, trials []storage.Trial) Calendar {
+	cal := Calendar{
+		Name: "Event Signups",
+	}
+
+	var skipped []string
+
+	for _, trial := range trials {
+		if trial.GetState(ctx) != storage.TrialStateOpen {
+			continue
+		}
+
+		desc := trial.GetDescription(ctx)
+
+		if uid != 0 {
+			role, ok := memberRole(ctx, trial, uid)
+			if !ok {
+				continue
+			}
+
+			if role == "" {
+				desc = strings.TrimSpace(fmt.Sprintf("Signed up (overflow)\n\n%s", desc))
+			} else {
+				desc = strings.TrimSpace(fmt.Sprintf("Signed up as %s\n\n%s", role, desc))
+			}
+		}
+
+		start, ok := storage.ParseEventTime(trial.GetTime(ctx))
+		if !ok {
+			skipped = append(skipped, trial.GetName(ctx))
+			continue
+		}
+
+		cal.Events = append(cal.Events, Event{
+			UID:         fmt.Sprintf("%s-%s@discord-signup-bot", gid.ToString(), url.PathEscape(strings.ToLower(trial.GetName(ctx)))),
+			Summary:     trial.GetName(ctx),
+			Description: desc,
+			Start:       start,
+		})
+	}
+
+	if len(skipped) > 0 {
+		cal.Description = fmt.Sprintf("Events without a recognizable time are not shown: %s", strings.Join(skipped, ", "))
+	}
+
+	return cal
+}
@@ -0,0 +1,113 @@
+package calendar
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// DefaultEventDuration is used for the end time of events, since events do not record one
+const DefaultEventDuration = time.Hour
+
+const icalTimeLayout = "20060102T150405Z"
+
+// Event is a single calendar entry
+type Event struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	Duration    time.Duration
+	URL         string
+}
+
+// Calendar is a named list of events
+type Calendar struct {
+	Name        string
+	Description string
+	Events      []Event
+}
+
+// Render writes the calendar in RFC 5545 (iCalendar) format
+func (c Calendar) Render(w io.Writer, now time.Time) error {
+	cw := &contentWriter{w: w}
+
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:-//gsmcwhirter//discord-signup-bot//EN")
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	cw.line("X-WR-CALNAME:" + escapeText(c.Name))
+	if c.Description != "" {
+		cw.line("X-WR-CALDESC:" + escapeText(c.Description))
+	}
+
+	stamp := now.UTC().Format(icalTimeLayout)
+
+	for _, e := range c.Events {
+		d := e.Duration
+		if d <= 0 {
+			d = DefaultEventDuration
+		}
+
+		cw.line("BEGIN:VEVENT")
+		cw.line("UID:" + escapeText(e.UID))
+		cw.line("DTSTAMP:" + stamp)
+		cw.line("DTSTART:" + e.Start.UTC().Format(icalTimeLayout))
+		cw.line("DTEND:" + e.Start.Add(d).UTC().Format(icalTimeLayout))
+		cw.line("SUMMARY:" + escapeText(e.Summary))
+		if e.Description != "" {
+			cw.line("DESCRIPTION:" + escapeText(e.Description))
+		}
+		if e.URL != "" {
+			cw.line("URL:" + e.URL)
+		}
+		cw.line("END:VEVENT")
+	}
+
+	cw.line("END:VCALENDAR")
+
+	return cw.err
+}
+
+// escapeText escapes a TEXT property value (RFC 5545 section 3.3.11)
+func escapeText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// contentWriter writes content lines, folding them at 75 octets without splitting utf-8 sequences
+type contentWriter struct {
+	w   io.Writer
+	err error
+}
+
+const maxLineOctets = 75
+
+func (cw *contentWriter) line(s string) {
+	if cw.err != nil {
+		return
+	}
+
+	var b strings.Builder
+	lineLen := 0
+	for _, r := range s {
+		rl := len(string(r))
+		if lineLen+rl > maxLineOctets {
+			b.WriteString("\r\n ")
+			lineLen = 1
+		}
+		b.WriteRune(r)
+		lineLen += rl
+	}
+	b.WriteString("\r\n")
+
+	_, cw.err = fmt.Fprint(cw.w, b.String())
+}
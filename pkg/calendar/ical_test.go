@@ -0,0 +1,59 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_escapeText(t *testing.T) {
+	t.Parallel()
+
+	got := escapeText("Raid; bring food, potions\nand a \\ backslash")
+	want := `Raid\; bring food\, potions\nand a \\ backslash`
+	if got != want {
+		t.Errorf("escapeText() = %q, want %q", got, want)
+	}
+}
+
+func TestCalendar_Render(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	cal := Calendar{
+		Name: "Events",
+		Events: []Event{
+			{
+				UID:         "1-raid@discord-signup-bot",
+				Summary:     "Raid",
+				Description: strings.Repeat("x", 100),
+				Start:       time.Date(2022, 1, 2, 20, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	var b strings.Builder
+	if err := cal.Render(&b, now); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTAMP:20220101T000000Z\r\n",
+		"DTSTART:20220102T200000Z\r\n",
+		"DTEND:20220102T210000Z\r\n",
+		"SUMMARY:Raid\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render() missing %q in %q", want, out)
+		}
+	}
+
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("Render() line too long (%d): %q", len(line), line)
+		}
+	}
+}
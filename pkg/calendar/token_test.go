@@ -0,0 +1,26 @@
+package calendar
+
+import "testing"
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	s := NewSigner("secret")
+
+	tok := s.Token("123", "")
+	if !s.Verify("123", "", tok) {
+		t.Error("Verify() rejected a guild token")
+	}
+
+	if s.Verify("123", "456", tok) {
+		t.Error("Verify() accepted a guild token for a member feed")
+	}
+
+	if s.Verify("124", "", tok) {
+		t.Error("Verify() accepted a token for another guild")
+	}
+
+	if NewSigner("other").Verify("123", "", tok) {
+		t.Error("Verify() accepted a token made with another secret")
+	}
+}
@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	log "github.com/gsmcwhirter/go-util/v8/logging"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+const (
+	defaultStatsDays = 90
+	leaderboardSize  = 20
+)
+
+// lateWithdrawWindow is how close to the start of an event a withdrawal counts as late
+const lateWithdrawWindow = 24 * time.Hour
+
+// withdrawIsLate determines whether a member withdrawing from an event now counts against their
+// reliability; it is measured from the start of the event (when signups close does not matter),
+// and an event without a known start time never has late withdrawals
+func withdrawIsLate(ctx context.Context, trial storage.Trial, now time.Time) bool {
+	if start, ok := storage.ParseEventTime(trial.GetTime(ctx)); ok {
+		return !now.Before(start.Add(-lateWithdrawWindow))
+	}
+
+	return false
+}
+
+// signupRole finds the role a member fills in an event, as assigned by the roster (which need not be
+// their first choice); members in overflow are signed up, but fill no role
+func signupRole(ctx context.Context, trial storage.Trial, name string) (string, bool) {
+	member := attendanceMember(name)
+	for _, su := range trial.GetSignups(ctx) {
+		if attendanceMember(su.GetName(ctx)) == member {
+			return trialRoster(ctx, trial).AssignedRole(su.GetName(ctx)), true
+		}
+	}
+
+	return "", false
+}
+
+// recordActivity saves a signup or withdrawal for the reliability stats; failures are logged
+// rather than returned, since the signup or withdrawal itself has already happened
+func recordActivity(ctx context.Context, logger log.Logger, api storage.ActivityAPI, gid snowflake.Snowflake, trial storage.Trial, member, role string, kind storage.ActivityKind, late bool) {
+	err := api.RecordActivity(ctx, storage.MemberActivity{
+		GuildID:   gid.ToString(),
+		Member:    attendanceMember(member),
+		EventName: trial.GetName(ctx),
+		Role:      role,
+		Kind:      kind,
+		Late:      late,
+	})
+	if err != nil {
+		level.Error(logger).Err("could not record member activity", err, "trial_name", trial.GetName(ctx), "kind", string(kind))
+	}
+}
+
+// parseStatsDays parses the size of the stats window, in days
+func parseStatsDays(val string) (int, error) {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return defaultStatsDays, nil
+	}
+
+	days, err := strconv.Atoi(val)
+	if err != nil || days <= 0 {
+		return 0, i18n.NewError(i18n.ArgsStatsDays, val)
+	}
+
+	return days, nil
+}
+
+func statsSince(days int) time.Time {
+	return time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+}
+
+func formatReliabilityCounts(p i18n.Printer, s storage.ReliabilityStats) string {
+	return p.Sprintf(i18n.StatsCounts, s.Signups, s.Withdrawals, s.LateWithdrawals, s.Attended, s.Late, s.NoShows)
+}
+
+// formatMemberStats describes the per-role stats of one member, with a total line
+func formatMemberStats(p i18n.Printer, member string, days int, stats []storage.ReliabilityStats) string {
+	if len(stats) == 0 {
+		return p.Sprintf(i18n.StatsNone, member, days)
+	}
+
+	total := storage.ReliabilityStats{Member: member}
+	lines := make([]string, 0, len(stats)+2)
+	lines = append(lines, p.Sprintf(i18n.StatsTitle, member, days))
+
+	for _, s := range stats {
+		total.Add(s)
+
+		role := s.Role
+		if role == "" {
+			role = p.Sprintf(i18n.StatsNoRole)
+		}
+		lines = append(lines, fmt.Sprintf("**%s**: %s", role, formatReliabilityCounts(p, s)))
+	}
+
+	lines = append(lines, p.Sprintf(i18n.StatsTotal, formatReliabilityCounts(p, total), int(math.Round(total.Reliability()*100))))
+
+	return strings.Join(lines, "\n")
+}
+
+// leaderboard totals the stats for each member (optionally for a single role) and ranks them by reliability
+func leaderboard(stats []storage.ReliabilityStats, role string) []storage.ReliabilityStats {
+	byMember := map[string]*storage.ReliabilityStats{}
+	order := make([]string, 0, len(stats))
+
+	for _, s := range stats {
+		if role != "" && !strings.EqualFold(s.Role, role) {
+			continue
+		}
+
+		total, ok := byMember[s.Member]
+		if !ok {
+			total = &storage.ReliabilityStats{Member: s.Member, Role: role}
+			byMember[s.Member] = total
+			order = append(order, s.Member)
+		}
+
+		total.Add(s)
+	}
+
+	ranked := make([]storage.ReliabilityStats, 0, len(order))
+	for _, m := range order {
+		if byMember[m].Signups > 0 {
+			ranked = append(ranked, *byMember[m])
+		}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		ri, rj := ranked[i].Reliability(), ranked[j].Reliability()
+		if ri != rj {
+			return ri > rj
+		}
+
+		if ranked[i].Signups != ranked[j].Signups {
+			return ranked[i].Signups > ranked[j].Signups
+		}
+
+		return ranked[i].Member < ranked[j].Member
+	})
+
+	return ranked
+}
+
+func formatLeaderboard(p i18n.Printer, ranked []storage.ReliabilityStats, role string, days int) string {
+	title := p.Sprintf(i18n.LeaderboardTitle, days)
+	if role != "" {
+		title = p.Sprintf(i18n.LeaderboardTitleRole, role, days)
+	}
+
+	if len(ranked) == 0 {
+		return fmt.Sprintf("%s\n\n%s", title, p.Sprintf(i18n.LeaderboardEmpty))
+	}
+
+	if len(ranked) > leaderboardSize {
+		ranked = ranked[:leaderboardSize]
+	}
+
+	lines := make([]string, 0, len(ranked)+1)
+	lines = append(lines, title)
+	for i, s := range ranked {
+		lines = append(lines, fmt.Sprintf("%d. %s %.0f%%: %s", i+1, s.Member, s.Reliability()*100, formatReliabilityCounts(p, s)))
+	}
+
+	return strings.Join(lines, "\n")
+}
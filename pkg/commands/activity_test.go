@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// timedTrial is an event with only a start time (and a signup close time, which must not matter)
+type timedTrial struct {
+	storage.Trial
+	time             string
+	closeHoursBefore uint64
+}
+
+func (t timedTrial) GetTime(context.Context) string             { return t.time }
+func (t timedTrial) GetCloseHoursBefore(context.Context) uint64 { return t.closeHoursBefore }
+
+func TestWithdrawIsLate(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	start := time.Date(2021, 6, 5, 20, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		trial timedTrial
+		now   time.Time
+		want  bool
+	}{
+		{name: "no start time", trial: timedTrial{time: "saturday evening"}, now: start, want: false},
+		{name: "days before", trial: timedTrial{time: "2021-06-05 20:00"}, now: start.Add(-72 * time.Hour), want: false},
+		{name: "within a day", trial: timedTrial{time: "2021-06-05 20:00"}, now: start.Add(-2 * time.Hour), want: true},
+		{name: "after the start", trial: timedTrial{time: "2021-06-05 20:00"}, now: start.Add(time.Hour), want: true},
+		{name: "signups closed early", trial: timedTrial{time: "2021-06-05 20:00", closeHoursBefore: 72}, now: start.Add(-48 * time.Hour), want: false},
+		{name: "signups close late", trial: timedTrial{time: "2021-06-05 20:00", closeHoursBefore: 1}, now: start.Add(-12 * time.Hour), want: true},
+	}
+
+	for _, tt := range tests {
+		if got := withdrawIsLate(ctx, tt.trial, tt.now); got != tt.want {
+			t.Errorf("%s: withdrawIsLate() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestSignupRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	// <@2> would rather tank, but the roster moves them to their alternate; <@3> is overflow
+	trial := &signupTrial{
+		roleCounts: []storage.RoleCount{testRoleCount{role: "Tank"}, testRoleCount{role: "Healer"}},
+		signups: []*testSignup{
+			{name: "<@1>", role: "Tank"},
+			{name: "<@!2>", role: "Tank", alternates: []string{"Healer"}},
+			{name: "<@3>", role: "Tank"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		want         string
+		wantSignedUp bool
+	}{
+		{name: "<@1>", want: "Tank", wantSignedUp: true},
+		{name: "<@2>", want: "Healer", wantSignedUp: true},
+		{name: "<@3>", want: "", wantSignedUp: true},
+		{name: "<@4>", want: "", wantSignedUp: false},
+	}
+
+	for _, tt := range tests {
+		if got, signedUp := signupRole(ctx, trial, tt.name); got != tt.want || signedUp != tt.wantSignedUp {
+			t.Errorf("signupRole(%s) = %q, %v; want %q, %v", tt.name, got, signedUp, tt.want, tt.wantSignedUp)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	"github.com/gsmcwhirter/go-util/v8/parser"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
@@ -65,6 +67,8 @@ func (c *AdminCommands) HandleInteraction(ix *cmdhandler.Interaction) (cmdhandle
 	switch sc {
 	case "announce":
 		return c.announceInteraction(ix, opts)
+	case "attendance":
+		return c.attendanceInteraction(ix, opts)
 	case "clear":
 		return c.clearInteraction(ix, opts)
 	case "close":
@@ -77,8 +81,16 @@ func (c *AdminCommands) HandleInteraction(ix *cmdhandler.Interaction) (cmdhandle
 		return c.deleteInteraction(ix, opts)
 	case "edit":
 		return c.editInteraction(ix, opts)
+	case "export":
+		return c.exportInteraction(ix, opts)
 	case "grouping":
 		return c.groupingInteraction(ix, opts)
+	case "groups":
+		return c.groupsInteraction(ix, opts)
+	case "import":
+		return c.importInteraction(ix, opts)
+	case "leaderboard":
+		return c.leaderboardInteraction(ix, opts)
 	case "list":
 		return c.listInteraction(ix, opts)
 	case "open":
@@ -142,6 +154,8 @@ func (c *AdminCommands) Autocomplete(ix *cmdhandler.Interaction) ([]entity.Appli
 	switch scKind {
 	case "announce:event_name":
 		return c.autocompleteOpenEvents(ix, opts, focused)
+	case "attendance:event_name":
+		return c.autocompleteAllEvents(ix, opts, focused)
 	case "clear:event_name":
 		return c.autocompleteAllEvents(ix, opts, focused)
 	case "close:event_name":
@@ -152,8 +166,14 @@ func (c *AdminCommands) Autocomplete(ix *cmdhandler.Interaction) ([]entity.Appli
 		return c.autocompleteAllEvents(ix, opts, focused)
 	case "edit:event_name":
 		return c.autocompleteAllEvents(ix, opts, focused)
+	case "export:event_name":
+		return c.autocompleteAllEvents(ix, opts, focused)
 	case "grouping:event_name":
 		return c.autocompleteOpenEvents(ix, opts, focused)
+	case "groups:event_name":
+		return c.autocompleteAllEvents(ix, opts, focused)
+	case "import:event_name":
+		return c.autocompleteAllEvents(ix, opts, focused)
 	case "open:event_name":
 		return c.autocompleteClosedEvents(ix, opts, focused)
 	case "show:event_name":
@@ -171,12 +191,16 @@ func (c *AdminCommands) Autocomplete(ix *cmdhandler.Interaction) ([]entity.Appli
 
 func (c *AdminCommands) AttachToCommandHandler(ch *cmdhandler.CommandHandler) {
 	ch.SetHandler("list", cmdhandler.NewMessageHandler(c.listHandler))
+	ch.SetHandler("leaderboard", cmdhandler.NewMessageHandler(c.leaderboardHandler))
 	ch.SetHandler("create", cmdhandler.NewMessageHandler(c.createHandler))
 	ch.SetHandler("edit", cmdhandler.NewMessageHandler(c.editHandler))
 	ch.SetHandler("open", cmdhandler.NewMessageHandler(c.openHandler))
 	ch.SetHandler("close", cmdhandler.NewMessageHandler(c.closeHandler))
 	ch.SetHandler("delete", cmdhandler.NewMessageHandler(c.deleteHandler))
 	ch.SetHandler("announce", cmdhandler.NewMessageHandler(c.announceHandler))
+	ch.SetHandler("attendance", cmdhandler.NewMessageHandler(c.attendanceHandler))
+	ch.SetHandler("export", cmdhandler.NewMessageHandler(c.exportHandler))
+	ch.SetHandler("import", cmdhandler.NewMessageHandler(c.importHandler))
 	ch.SetHandler("grouping", cmdhandler.NewMessageHandler(c.groupingHandler))
 	ch.SetHandler("signup", cmdhandler.NewMessageHandler(c.signupHandler))
 	ch.SetHandler("su", cmdhandler.NewMessageHandler(c.signupHandler))
@@ -213,7 +237,7 @@ func (c *AdminCommands) commandForGuild(ctx context.Context, gid snowflake.Snowf
 					{
 						Type:        entity.OptTypeString,
 						Name:        "announce_message",
-						Description: "A message to include at the top of the announcement",
+						Description: "A message to include at the top of the announcement (omit to use the announce template)",
 						Required:    false,
 					},
 					{
@@ -225,6 +249,57 @@ func (c *AdminCommands) commandForGuild(ctx context.Context, gid snowflake.Snowf
 					},
 				},
 			},
+			{
+				Type:        entity.OptTypeSubCommand,
+				Name:        "attendance",
+				Description: "Record or show attendance for an event",
+				Options: []entity.ApplicationCommandOption{
+					{
+						Type:         entity.OptTypeString,
+						Name:         "event_name",
+						Description:  "Name of the event to take attendance for",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "all",
+						Description: "Status for everyone in the main roster not listed below",
+						Choices: []entity.ApplicationCommandOptionChoice{
+							{
+								Type:        entity.OptTypeString,
+								Name:        "attended",
+								ValueString: string(storage.AttendanceAttended),
+							},
+							{
+								Type:        entity.OptTypeString,
+								Name:        "late",
+								ValueString: string(storage.AttendanceLate),
+							},
+							{
+								Type:        entity.OptTypeString,
+								Name:        "no-show",
+								ValueString: string(storage.AttendanceNoShow),
+							},
+						},
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "attended",
+						Description: "Users who attended (@mentions)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "late",
+						Description: "Users who were late (@mentions)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "noshow",
+						Description: "Users who did not show up (@mentions)",
+					},
+				},
+			},
 			{
 				Type:        entity.OptTypeSubCommand,
 				Name:        "clear",
@@ -258,19 +333,17 @@ func (c *AdminCommands) commandForGuild(ctx context.Context, gid snowflake.Snowf
 			{
 				Type:        entity.OptTypeSubCommand,
 				Name:        "create",
-				Description: "Create a new event",
+				Description: "Create a new event (with no options, opens a form)",
 				Options: []entity.ApplicationCommandOption{
 					{
 						Type:        entity.OptTypeString,
 						Name:        "event_name",
 						Description: "Name of the event to create",
-						Required:    true,
 					},
 					{
 						Type:        entity.OptTypeString,
 						Name:        "roles",
-						Description: "Roles for the event (comma-separated list of NAME:COUNT[:EMOJI])",
-						Required:    true,
+						Description: "Event roles (comma-separated [GROUP/]NAME:COUNT[:EMOJI][{ALIAS|ALIAS}][[@ROLE|@ROLE]])",
 					},
 					{
 						Type:        entity.OptTypeString,
@@ -314,6 +387,56 @@ func (c *AdminCommands) commandForGuild(ctx context.Context, gid snowflake.Snowf
 						Name:        "roleorder",
 						Description: "Order to display the event roles (omit or set to empty for alphabetical)",
 					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "rolegroups",
+						Description: "Role groups sharing a cap (comma-separated GROUP:COUNT; put roles in one with GROUP/NAME)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "category",
+						Description: "Category of the event, for per-category signup limits",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "signupsopenat",
+						Description: "When signups open automatically (discord timestamp or YYYY-MM-DD HH:MM UTC)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "signupscloseat",
+						Description: "When signups close automatically (discord timestamp or YYYY-MM-DD HH:MM UTC)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "closehoursbefore",
+						Description: "Close signups this many hours before the event time (if signupscloseat is not set)",
+					},
+					{
+						Type:        entity.OptTypeBoolean,
+						Name:        "announcestatechanges",
+						Description: "Post in the signup channel when signups open or close automatically",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "reminders",
+						Description: "Reminder times before the event (e.g., 1d,1h), 'off' to disable, or blank for the default",
+					},
+					{
+						Type:        entity.OptTypeUser,
+						Name:        "leader",
+						Description: "Who is leading the event (available to message templates)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "prioritytiers",
+						Description: "Discord roles that get slots first, highest tier first (e.g., @Core|@Officer, @Raider)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "priorityhoursbefore",
+						Description: "Signups stop getting a priority tier this many hours before the event (omit for never)",
+					},
 				},
 			},
 			{
@@ -362,7 +485,7 @@ func (c *AdminCommands) commandForGuild(ctx context.Context, gid snowflake.Snowf
 					{
 						Type:        entity.OptTypeString,
 						Name:        "roles",
-						Description: "Roles for the event (comma-separated list of NAME:COUNT[:EMOJI])",
+						Description: "Event roles (comma-separated [GROUP/]NAME:COUNT[:EMOJI][{ALIAS|ALIAS}][[@ROLE|@ROLE]])",
 					},
 					{
 						Type:        entity.OptTypeString,
@@ -406,6 +529,87 @@ func (c *AdminCommands) commandForGuild(ctx context.Context, gid snowflake.Snowf
 						Name:        "roleorder",
 						Description: "Order to display the event roles (omit or set to empty for alphabetical)",
 					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "rolegroups",
+						Description: "Role groups sharing a cap (comma-separated GROUP:COUNT; put roles in one with GROUP/NAME)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "category",
+						Description: "Category of the event, for per-category signup limits",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "signupsopenat",
+						Description: "When signups open automatically (discord timestamp or YYYY-MM-DD HH:MM UTC)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "signupscloseat",
+						Description: "When signups close automatically (discord timestamp or YYYY-MM-DD HH:MM UTC)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "closehoursbefore",
+						Description: "Close signups this many hours before the event time (if signupscloseat is not set)",
+					},
+					{
+						Type:        entity.OptTypeBoolean,
+						Name:        "announcestatechanges",
+						Description: "Post in the signup channel when signups open or close automatically",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "reminders",
+						Description: "Reminder times before the event (e.g., 1d,1h), 'off' to disable, or blank for the default",
+					},
+					{
+						Type:        entity.OptTypeUser,
+						Name:        "leader",
+						Description: "Who is leading the event (available to message templates)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "prioritytiers",
+						Description: "Discord roles that get slots first, highest tier first (e.g., @Core|@Officer, @Raider)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "priorityhoursbefore",
+						Description: "Signups stop getting a priority tier this many hours before the event (omit for never)",
+					},
+				},
+			},
+			{
+				Type:        entity.OptTypeSubCommand,
+				Name:        "export",
+				Description: "Download the roster of an event as a file",
+				Options: []entity.ApplicationCommandOption{
+					{
+						Type:         entity.OptTypeString,
+						Name:         "event_name",
+						Description:  "Name of the event to export",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "format",
+						Description: "File format (default csv)",
+						Choices: []entity.ApplicationCommandOptionChoice{
+							{
+								Type:        entity.OptTypeString,
+								Name:        "csv",
+								ValueString: exportFormatCSV,
+							},
+							{
+								Type:        entity.OptTypeString,
+								Name:        "json",
+								ValueString: exportFormatJSON,
+							},
+						},
+					},
 				},
 			},
 			{
@@ -424,7 +628,7 @@ func (c *AdminCommands) commandForGuild(ctx context.Context, gid snowflake.Snowf
 					{
 						Type:        entity.OptTypeString,
 						Name:        "grouping_message",
-						Description: "A message to include with the grouping notification",
+						Description: "A message to include with the grouping notification (omit to use the grouping template)",
 						Required:    false,
 					},
 					{
@@ -436,6 +640,74 @@ func (c *AdminCommands) commandForGuild(ctx context.Context, gid snowflake.Snowf
 					},
 				},
 			},
+			{
+				Type:        entity.OptTypeSubCommand,
+				Name:        "groups",
+				Description: "Split the roster of an event into balanced groups",
+				Options: []entity.ApplicationCommandOption{
+					{
+						Type:         entity.OptTypeString,
+						Name:         "event_name",
+						Description:  "Name of the event to split into groups",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        entity.OptTypeInteger,
+						Name:        "size",
+						Description: "The largest number of members in a group",
+						Required:    true,
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "quotas",
+						Description: "Members of each role per group, like Tank:2, Healer:2, DPS:8 (omit to split by role counts)",
+						Required:    false,
+					},
+				},
+			},
+			{
+				Type:        entity.OptTypeSubCommand,
+				Name:        "import",
+				Description: "Sign up many users at once from a CSV of user,role rows",
+				Options: []entity.ApplicationCommandOption{
+					{
+						Type:         entity.OptTypeString,
+						Name:         "event_name",
+						Description:  "Name of the event to import into",
+						Required:     true,
+						Autocomplete: true,
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "file_url",
+						Description: "Link to the CSV file uploaded to discord (users can be ids, mentions or usernames)",
+						Required:    true,
+					},
+					{
+						Type:        entity.OptTypeBoolean,
+						Name:        "dry_run",
+						Description: "Only check the file; do not sign anyone up",
+					},
+				},
+			},
+			{
+				Type:        entity.OptTypeSubCommand,
+				Name:        "leaderboard",
+				Description: "Rank members by signup reliability",
+				Options: []entity.ApplicationCommandOption{
+					{
+						Type:        entity.OptTypeString,
+						Name:        "days",
+						Description: "How many days back to count (default 90)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "role",
+						Description: "Only count signups for this role",
+					},
+				},
+			},
 			{
 				Type:        entity.OptTypeSubCommand,
 				Name:        "list",
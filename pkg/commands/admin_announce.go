@@ -9,9 +9,12 @@ import (
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/templates"
 
+	"github.com/gsmcwhirter/discord-bot-lib/v23/bot/session"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
@@ -82,7 +85,7 @@ func (c *AdminCommands) announceInteraction(ix *cmdhandler.Interaction, opts []e
 
 	level.Info(logger).Message("trial announced", "trial_name", eventName, "announce_channel", r2.ToChannel.ToString(), "announce_to", r2.To)
 
-	return r, []cmdhandler.Response{r2}, nil
+	return r, []cmdhandler.Response{newLiveResponse(r2, storage.LiveMessageAnnounce)}, nil
 }
 
 func (c *AdminCommands) announceHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
@@ -140,10 +143,10 @@ func (c *AdminCommands) announceHandler(msg cmdhandler.Message) (cmdhandler.Resp
 
 	level.Info(logger).Message("trial announced", "trial_name", trialName, "announce_channel", r2.ToChannel.ToString(), "announce_to", r2.To)
 
-	return r2, nil
+	return newLiveResponse(r2, storage.LiveMessageAnnounce), nil
 }
 
-func (c *AdminCommands) announce(ctx context.Context, gid snowflake.Snowflake, gsettings storage.GuildSettings, eventName, phrase string) (*cmdhandler.EmbedResponse, error) {
+func (c *AdminCommands) announce(ctx context.Context, gid snowflake.Snowflake, gsettings storage.GuildSettings, eventName, phrase string) (*eventEmbed, error) {
 	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.announce", "guild_id", gid.ToString())
 	defer span.End()
 
@@ -158,7 +161,20 @@ func (c *AdminCommands) announce(ctx context.Context, gid snowflake.Snowflake, g
 		return nil, err
 	}
 
-	sessionGuild, ok := c.deps.BotSession().Guild(gid)
+	// without a phrase of its own, the announce template is used
+	if phrase == "" {
+		phrase, err = renderMessage(ctx, c.deps.TemplateAPI(), gid, trial, templates.KindAnnounce, eventTemplateData(ctx, c.deps.BotSession(), gid, trial))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not render announce template")
+		}
+	}
+
+	return formatAnnouncement(ctx, c.deps.BotSession(), gid, trial, gsettings, phrase)
+}
+
+// formatAnnouncement builds the announcement embed for an event
+func formatAnnouncement(ctx context.Context, sess *session.Session, gid snowflake.Snowflake, trial storage.Trial, gsettings storage.GuildSettings, phrase string) (*eventEmbed, error) {
+	sessionGuild, ok := sess.Guild(gid)
 	if !ok {
 		return nil, ErrGuildNotFound
 	}
@@ -174,26 +190,34 @@ func (c *AdminCommands) announce(ctx context.Context, gid snowflake.Snowflake, g
 		announceCid = acID
 	}
 
+	p := i18n.NewPrinter(gsettings.Language)
+
 	roles := trial.GetRoleCounts(ctx)
-	signups := trial.GetSignups(ctx)
+	roster := trialRoster(ctx, trial)
 
 	roleStrs := make([]string, 0, len(roles))
 	emojis := make([]string, 0, len(roles))
-	for _, rc := range roles {
-		suNames, ofNames := getTrialRoleSignups(ctx, signups, rc)
-
-		filledStr := fmt.Sprintf("(%d signed up", len(suNames))
-		if len(ofNames) != 0 {
-			filledStr += fmt.Sprintf("; %d overflow)", len(ofNames))
-		} else {
-			filledStr += ")"
+	for _, entry := range roleTree(ctx, roles, trial.GetRoleGroups(ctx)) {
+		indent := ""
+		if entry.group != nil {
+			roleStrs = append(roleStrs, fmt.Sprintf("%s: %d", entry.group.GetName(ctx), entry.group.GetCount(ctx)))
+			indent = "  "
 		}
 
-		emoji := rc.GetEmoji(ctx)
-		roleStrs = append(roleStrs, fmt.Sprintf("%s %s: %d %s", emoji, rc.GetRole(ctx), rc.GetCount(ctx), filledStr))
+		for _, rc := range entry.roles {
+			suNames, ofNames := getTrialRoleSignups(ctx, roster, rc)
+
+			filledStr := p.Sprintf(i18n.AnnounceFilled, len(suNames))
+			if len(ofNames) != 0 {
+				filledStr = p.Sprintf(i18n.AnnounceFilledOverflow, len(suNames), len(ofNames))
+			}
+
+			emoji := rc.GetEmoji(ctx)
+			roleStrs = append(roleStrs, fmt.Sprintf("%s%s %s: %s %s", indent, emoji, rc.GetRole(ctx), storage.RoleCountString(ctx, rc), filledStr))
 
-		if emoji != "" {
-			emojis = append(emojis, emoji)
+			if emoji != "" {
+				emojis = append(emojis, emoji)
+			}
 		}
 	}
 
@@ -210,17 +234,17 @@ func (c *AdminCommands) announce(ctx context.Context, gid snowflake.Snowflake, g
 
 	desc := trial.GetDescription(ctx)
 	if t := trial.GetTime(ctx); t != "" {
-		desc = fmt.Sprintf("When: %s\n\n%s", t, desc)
+		desc = fmt.Sprintf("%s\n\n%s", p.Sprintf(i18n.EventTime, t), desc)
 	}
 
 	r2 := &cmdhandler.EmbedResponse{
 		To:          fmt.Sprintf("%s %s", toStr, phrase),
 		ToChannel:   announceCid,
-		Title:       fmt.Sprintf("Signups are open for %s", trial.GetName(ctx)),
+		Title:       p.Sprintf(i18n.AnnounceTitle, trial.GetName(ctx)),
 		Description: desc,
 		Fields: []cmdhandler.EmbedField{
 			{
-				Name: "Roles Requested",
+				Name: p.Sprintf(i18n.AnnounceRoles),
 				Val:  fmt.Sprintf("```\n%s\n```\n", strings.Join(roleStrs, "\n")),
 			},
 		},
@@ -233,10 +257,10 @@ func (c *AdminCommands) announce(ctx context.Context, gid snowflake.Snowflake, g
 
 	if signupCid != 0 {
 		r2.Fields = append(r2.Fields, cmdhandler.EmbedField{
-			Name: "Signup Channel",
+			Name: p.Sprintf(i18n.AnnounceSignupChannel),
 			Val:  cmdhandler.ChannelMentionString(signupCid),
 		})
 	}
 
-	return r2, nil
+	return newEventEmbed(ctx, p, r2, trial), nil
 }
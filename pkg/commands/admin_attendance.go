@@ -0,0 +1,358 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+)
+
+// ErrNotSignedUp is returned when attendance is taken for someone who is not signed up for the event
+var ErrNotSignedUp = errors.New("not signed up for the event")
+
+var attendanceStatusOrder = []storage.AttendanceStatus{
+	storage.AttendanceAttended,
+	storage.AttendanceLate,
+	storage.AttendanceNoShow,
+}
+
+var attendanceStatusNames = map[storage.AttendanceStatus]i18n.Key{
+	storage.AttendanceAttended: i18n.AttendanceAttended,
+	storage.AttendanceLate:     i18n.AttendanceLate,
+	storage.AttendanceNoShow:   i18n.AttendanceNoShow,
+}
+
+func (c *AdminCommands) attendanceInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "adminCommands.attendanceInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling admin interaction", "command", "attendance")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var eventName string
+	var all storage.AttendanceStatus
+	exceptions := map[string]storage.AttendanceStatus{}
+
+	for i := range opts {
+		switch opts[i].Name {
+		case "event_name":
+			eventName = opts[i].ValueString
+		case "all":
+			all, err = storage.ParseAttendanceStatus(opts[i].ValueString)
+			if err != nil {
+				return r, nil, err
+			}
+		case "attended", "late", "noshow":
+			status, err := storage.ParseAttendanceStatus(opts[i].Name)
+			if err != nil {
+				return r, nil, err
+			}
+
+			if err := addAttendanceExceptions(exceptions, status, strings.Fields(opts[i].ValueString)); err != nil {
+				return r, nil, err
+			}
+		}
+	}
+
+	occurrence, records, err := c.attendance(ctx, ix.GuildID(), ix.UserID(), eventName, all, exceptions)
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not record attendance")
+	}
+
+	level.Info(logger).Message("attendance recorded", "trial_name", eventName, "occurrence", occurrence, "records", len(records))
+
+	r.Description = formatAttendance(i18n.NewPrinter(gsettings.Language), eventName, occurrence, records)
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *AdminCommands) attendanceHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "adminCommands.attendanceHandler", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	r.SetReplyTo(msg)
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling adminCommand", "command", "attendance", "args", msg.Contents())
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, msghandler.ErrUnauthorized
+	}
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 1 {
+		return r, i18n.NewError(i18n.AttendanceMissingEvent)
+	}
+
+	trialName := msg.Contents()[0]
+
+	all, exceptions, err := parseAttendanceArgs(msg.Contents()[1:])
+	if err != nil {
+		return r, err
+	}
+
+	occurrence, records, err := c.attendance(ctx, msg.GuildID(), msg.UserID(), trialName, all, exceptions)
+	if err != nil {
+		return r, errors.Wrap(err, "could not record attendance")
+	}
+
+	level.Info(logger).Message("attendance recorded", "trial_name", trialName, "occurrence", occurrence, "records", len(records))
+
+	r.Description = formatAttendance(i18n.NewPrinter(gsettings.Language), trialName, occurrence, records)
+	r.SetColor(okColor)
+
+	return r, nil
+}
+
+// parseAttendanceArgs parses arguments like `all=attended late=@a @b noshow=@c`; each mention
+// takes the status named most recently before it
+func parseAttendanceArgs(args []string) (storage.AttendanceStatus, map[string]storage.AttendanceStatus, error) {
+	var all, current storage.AttendanceStatus
+	exceptions := map[string]storage.AttendanceStatus{}
+
+	for _, arg := range args {
+		if arg == "" {
+			continue
+		}
+
+		var err error
+
+		if parts := strings.SplitN(arg, "=", 2); len(parts) == 2 {
+			if strings.ToLower(parts[0]) == "all" {
+				if all, err = storage.ParseAttendanceStatus(parts[1]); err != nil {
+					return all, exceptions, err
+				}
+				continue
+			}
+
+			if current, err = storage.ParseAttendanceStatus(parts[0]); err != nil {
+				return all, exceptions, err
+			}
+
+			if parts[1] == "" {
+				continue
+			}
+			arg = parts[1]
+		}
+
+		if current == "" {
+			return all, exceptions, i18n.NewError(i18n.AttendanceMentionFirst)
+		}
+
+		if err := addAttendanceExceptions(exceptions, current, []string{arg}); err != nil {
+			return all, exceptions, err
+		}
+	}
+
+	return all, exceptions, nil
+}
+
+func addAttendanceExceptions(exceptions map[string]storage.AttendanceStatus, status storage.AttendanceStatus, mentions []string) error {
+	for _, m := range mentions {
+		if !cmdhandler.IsUserMention(m) {
+			return i18n.NewError(i18n.AttendanceNotMention, m)
+		}
+
+		exceptions[attendanceMember(m)] = status
+	}
+
+	return nil
+}
+
+// checkAttendanceExceptions makes sure that every member given a status is signed up for the event
+// (roles maps the signed up members to their role), so that mistyped mentions are not recorded
+func checkAttendanceExceptions(roles map[string]string, exceptions map[string]storage.AttendanceStatus) error {
+	var unknown []string
+	for member := range exceptions {
+		if _, ok := roles[member]; !ok {
+			unknown = append(unknown, member)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+
+	return i18n.WrapError(ErrNotSignedUp, i18n.AttendanceNotSignedUp, strings.Join(unknown, ", "))
+}
+
+// attendanceMember normalizes a signup name so that nickname and account mentions of the same user match
+func attendanceMember(name string) string {
+	if !cmdhandler.IsUserMention(name) {
+		return name
+	}
+
+	m, err := cmdhandler.ForceUserAccountMention(name)
+	if err != nil {
+		return name
+	}
+
+	return m
+}
+
+// attendanceOccurrence identifies which run of an event attendance is being taken for, so that
+// events that are cleared and reused keep separate records for each run
+func attendanceOccurrence(ctx context.Context, trial storage.Trial) string {
+	if start, ok := storage.ParseEventTime(trial.GetTime(ctx)); ok {
+		return start.UTC().Format("2006-01-02 15:04")
+	}
+
+	if t := strings.TrimSpace(trial.GetTime(ctx)); t != "" {
+		return t
+	}
+
+	return time.Now().UTC().Format("2006-01-02")
+}
+
+// attendance records attendance for an event: everyone in the main roster gets the status all
+// (if set) and the exceptions override that; it returns all records for the occurrence
+func (c *AdminCommands) attendance(ctx context.Context, gid, recorder snowflake.Snowflake, eventName string, all storage.AttendanceStatus, exceptions map[string]storage.AttendanceStatus) (string, []storage.AttendanceRecord, error) {
+	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.attendance", "guild_id", gid.ToString())
+	defer span.End()
+
+	t, err := c.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		return "", nil, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, eventName)
+	if err != nil {
+		return "", nil, err
+	}
+
+	occurrence := attendanceOccurrence(ctx, trial)
+
+	// the roles are those the roster assigns; members in overflow are recorded without one
+	roster := trialRoster(ctx, trial)
+	roles := map[string]string{}
+	for _, su := range trial.GetSignups(ctx) {
+		roles[attendanceMember(su.GetName(ctx))] = roster.AssignedRole(su.GetName(ctx))
+	}
+
+	if err := checkAttendanceExceptions(roles, exceptions); err != nil {
+		return occurrence, nil, err
+	}
+
+	statuses := map[string]storage.AttendanceStatus{}
+	if all != "" {
+		for name := range mainRosterRoles(ctx, trial) {
+			statuses[attendanceMember(name)] = all
+		}
+	}
+
+	for member, status := range exceptions {
+		statuses[member] = status
+	}
+
+	if len(statuses) > 0 {
+		records := make([]storage.AttendanceRecord, 0, len(statuses))
+		for member, status := range statuses {
+			records = append(records, storage.AttendanceRecord{
+				GuildID:    gid.ToString(),
+				EventName:  trial.GetName(ctx),
+				Occurrence: occurrence,
+				Member:     member,
+				Role:       roles[member],
+				Status:     status,
+				RecordedBy: cmdhandler.UserMentionString(recorder),
+			})
+		}
+
+		if err := c.deps.AttendanceAPI().RecordAttendance(ctx, records); err != nil {
+			return occurrence, nil, err
+		}
+	}
+
+	records, err := c.deps.AttendanceAPI().EventAttendance(ctx, gid.ToString(), trial.GetName(ctx), occurrence)
+	return occurrence, records, err
+}
+
+func formatAttendance(p i18n.Printer, eventName, occurrence string, records []storage.AttendanceRecord) string {
+	if len(records) == 0 {
+		return p.Sprintf(i18n.AttendanceNone, eventName, occurrence)
+	}
+
+	byStatus := map[storage.AttendanceStatus][]string{}
+	for _, rec := range records {
+		if rec.Role != "" {
+			byStatus[rec.Status] = append(byStatus[rec.Status], fmt.Sprintf("%s (%s)", rec.Member, rec.Role))
+		} else {
+			byStatus[rec.Status] = append(byStatus[rec.Status], rec.Member)
+		}
+	}
+
+	lines := []string{p.Sprintf(i18n.AttendanceTitle, eventName, occurrence)}
+	for _, status := range attendanceStatusOrder {
+		members := byStatus[status]
+		sort.Strings(members)
+		lines = append(lines, fmt.Sprintf("**%s** (%d): %s", p.Sprintf(attendanceStatusNames[status]), len(members), strings.Join(members, ", ")))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	stderrors "errors"
+	"reflect"
+	"testing"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+func TestParseAttendanceArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		args           []string
+		wantAll        storage.AttendanceStatus
+		wantExceptions map[string]storage.AttendanceStatus
+		wantErr        bool
+	}{
+		{
+			name:           "nothing",
+			wantExceptions: map[string]storage.AttendanceStatus{},
+		},
+		{
+			name:           "all",
+			args:           []string{"all=attended"},
+			wantAll:        storage.AttendanceAttended,
+			wantExceptions: map[string]storage.AttendanceStatus{},
+		},
+		{
+			name:    "statuses carry over",
+			args:    []string{"all=present", "late=<@1>", "<@2>", "noshow=", "<@!3>", ""},
+			wantAll: storage.AttendanceAttended,
+			wantExceptions: map[string]storage.AttendanceStatus{
+				"<@1>": storage.AttendanceLate,
+				"<@2>": storage.AttendanceLate,
+				"<@3>": storage.AttendanceNoShow,
+			},
+		},
+		{
+			name: "later status wins",
+			args: []string{"late=<@1>", "absent=<@1>"},
+			wantExceptions: map[string]storage.AttendanceStatus{
+				"<@1>": storage.AttendanceNoShow,
+			},
+		},
+		{name: "bad all", args: []string{"all=maybe"}, wantErr: true},
+		{name: "bad status", args: []string{"maybe=<@1>"}, wantErr: true},
+		{name: "mention before status", args: []string{"<@1>", "late=<@2>"}, wantErr: true},
+		{name: "not a mention", args: []string{"late=someone"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			all, exceptions, err := parseAttendanceArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAttendanceArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if all != tt.wantAll {
+				t.Errorf("parseAttendanceArgs() all = %q, want %q", all, tt.wantAll)
+			}
+
+			if !reflect.DeepEqual(exceptions, tt.wantExceptions) {
+				t.Errorf("parseAttendanceArgs() exceptions = %v, want %v", exceptions, tt.wantExceptions)
+			}
+		})
+	}
+}
+
+func TestCheckAttendanceExceptions(t *testing.T) {
+	t.Parallel()
+
+	roles := map[string]string{"<@1>": "Tank", "<@2>": "Healer"}
+
+	tests := []struct {
+		name       string
+		exceptions map[string]storage.AttendanceStatus
+		wantErr    bool
+	}{
+		{name: "none", exceptions: map[string]storage.AttendanceStatus{}},
+		{name: "signed up", exceptions: map[string]storage.AttendanceStatus{"<@1>": storage.AttendanceLate, "<@2>": storage.AttendanceNoShow}},
+		{name: "not signed up", exceptions: map[string]storage.AttendanceStatus{"<@1>": storage.AttendanceLate, "<@3>": storage.AttendanceNoShow}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		err := checkAttendanceExceptions(roles, tt.exceptions)
+		if tt.wantErr != stderrors.Is(err, ErrNotSignedUp) || (!tt.wantErr && err != nil) {
+			t.Errorf("%s: checkAttendanceExceptions() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
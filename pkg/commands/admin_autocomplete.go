@@ -152,7 +152,7 @@ func (c *AdminCommands) autocompleteEventRoles(ix *cmdhandler.Interaction, opts
 		name := rc.GetRole(ctx)
 		nameLower := strings.ToLower(name)
 
-		if !strings.Contains(nameLower, typed) {
+		if !roleMatches(ctx, rc, typed) {
 			continue
 		}
 
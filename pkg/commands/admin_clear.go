@@ -153,5 +153,7 @@ func (c *AdminCommands) clear(ctx context.Context, gid snowflake.Snowflake, even
 		return errors.Wrap(err, "could not save event")
 	}
 
+	c.deps.LiveMessages().Refresh(gid, trial.GetName(ctx))
+
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
@@ -153,5 +154,7 @@ func (c *AdminCommands) close(ctx context.Context, gid snowflake.Snowflake, even
 		return errors.Wrap(err, "could not close event")
 	}
 
+	c.deps.Webhooks().Notify(webhookEvent(ctx, gid, webhooks.KindClose, trial, "", ""))
+
 	return nil
 }
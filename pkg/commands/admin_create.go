@@ -12,6 +12,7 @@ import (
 
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
@@ -27,8 +28,18 @@ type eventSettings struct {
 	HideReactionsAnnounce *string
 	HideReactionsShow     *string
 	Time                  *string
+	Category              *string
 	RoleOrder             *string
+	RoleGroups            *string
 	Roles                 *string
+	SignupsOpenAt         *string
+	SignupsCloseAt        *string
+	CloseHoursBefore      *string
+	AnnounceStateChanges  *string
+	ReminderOffsets       *string
+	Leader                *string
+	PriorityTiers         *string
+	PriorityHoursBefore   *string
 }
 
 var (
@@ -69,11 +80,20 @@ func (c *AdminCommands) createInteraction(ix *cmdhandler.Interaction, opts []ent
 		return r, nil, msghandler.ErrUnauthorized
 	}
 
+	// without any options, the event details are filled in with a form instead
+	if len(opts) == 0 {
+		return newModalResponse(createEventModal(nil)), nil, nil
+	}
+
 	eventName, es, err := eventSettingsFromOptions(opts, ix.Data.Resolved)
 	if err != nil {
 		return r, nil, errors.Wrap(err, "could not parse interaction data")
 	}
 
+	if eventName == "" || es.Roles == nil {
+		return r, nil, errors.New("event_name and roles are required (or give no options to fill in a form)")
+	}
+
 	if err := c.create(ctx, logger, ix.GuildID(), gsettings, eventName, es); err != nil {
 		return r, nil, errors.Wrap(err, "could not create event")
 	}
@@ -211,6 +231,22 @@ func (c *AdminCommands) create(ctx context.Context, logger log.Logger, gid snowf
 		trial.SetTime(ctx, *settings.Time)
 	}
 
+	if settings.Category != nil {
+		trial.SetCategory(ctx, *settings.Category)
+	}
+
+	if settings.Leader != nil {
+		trial.SetLeader(ctx, *settings.Leader)
+	}
+
+	if err = applyScheduleSettings(ctx, trial, settings); err != nil {
+		return err
+	}
+
+	if err = applyPrioritySettings(ctx, trial, settings); err != nil {
+		return err
+	}
+
 	if settings.RoleOrder != nil {
 		roleOrder := strings.Split(*settings.RoleOrder, ",")
 		for i := range roleOrder {
@@ -219,6 +255,14 @@ func (c *AdminCommands) create(ctx context.Context, logger log.Logger, gid snowf
 		trial.SetRoleOrder(ctx, roleOrder)
 	}
 
+	if settings.RoleGroups != nil {
+		groups, err := parseRoleGroupsString(*settings.RoleGroups)
+		if err != nil {
+			return err
+		}
+		applyRoleGroups(ctx, trial, groups)
+	}
+
 	var roles string
 	if settings.Roles == nil {
 		roles = ""
@@ -230,12 +274,28 @@ func (c *AdminCommands) create(ctx context.Context, logger log.Logger, gid snowf
 	if err != nil {
 		return err
 	}
+	if err = checkRoleEmojis(ctx, c.deps.GuildEmojis(), gid, roleCtEmoList); err != nil {
+		return err
+	}
 	for _, rce := range roleCtEmoList {
-		if rce.ct != 0 {
+		if rce.ct != 0 || rce.noMax {
 			trial.SetRoleCount(ctx, rce.role, rce.emo, rce.ct)
+			if rce.hasReqs {
+				trial.SetRoleRequirements(ctx, rce.role, rce.reqs)
+			}
+			if rce.hasAliases {
+				trial.SetRoleAliases(ctx, rce.role, rce.aliases)
+			}
+			if rce.hasGroup {
+				trial.SetRoleParentGroup(ctx, rce.role, rce.group)
+			}
 		}
 	}
 
+	if err = checkRoleGroups(ctx, trial); err != nil {
+		return err
+	}
+
 	if err = t.SaveTrial(ctx, trial); err != nil {
 		return errors.Wrap(err, "could not save event")
 	}
@@ -244,5 +304,12 @@ func (c *AdminCommands) create(ctx context.Context, logger log.Logger, gid snowf
 		return errors.Wrap(err, "could not save event")
 	}
 
+	c.deps.Webhooks().Notify(webhookEvent(ctx, gid, webhooks.KindCreate, trial, "", ""))
+
+	// the event is already saved, so failing to schedule its jobs is logged rather than reported
+	if err = c.rescheduleEventJobs(ctx, gid, trial); err != nil {
+		level.Error(logger).Err("could not schedule event jobs", err, "trial_name", trial.GetName(ctx))
+	}
+
 	return nil
 }
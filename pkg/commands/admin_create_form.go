@@ -0,0 +1,220 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+var (
+	ErrUnknownForm  = errors.New("unknown form")
+	ErrDraftExpired = errors.New("this form has expired; run /admin create again")
+)
+
+// input ids of the event creation modal
+const (
+	createFormName        = "name"
+	createFormTime        = "time"
+	createFormDescription = "description"
+	createFormRoles       = "roles"
+)
+
+var _ components.ModalHandler = (*AdminCommands)(nil)
+
+type modalResponse struct {
+	*cmdhandler.SimpleResponse
+	modal components.Modal
+}
+
+var _ msghandler.ModalResponse = (*modalResponse)(nil)
+
+func newModalResponse(m components.Modal) *modalResponse {
+	return &modalResponse{
+		SimpleResponse: &cmdhandler.SimpleResponse{},
+		modal:          m,
+	}
+}
+
+func (r *modalResponse) Modal() components.Modal { return r.modal }
+
+type retryResponse struct {
+	*cmdhandler.SimpleEmbedResponse
+	rows []components.ActionRow
+}
+
+var _ msghandler.ComponentResponse = (*retryResponse)(nil)
+
+func (r *retryResponse) Components() []components.ActionRow { return r.rows }
+
+// createEventModal is the event creation form, pre-filled with values from a previous attempt
+func createEventModal(values map[string]string) components.Modal {
+	return components.NewModal(components.CustomID(components.ActionCreateForm, ""), "Create an event",
+		components.TextInput{
+			CustomID:  createFormName,
+			Style:     components.InputShort,
+			Label:     "Event name",
+			Value:     values[createFormName],
+			Required:  true,
+			MaxLength: 100,
+		},
+		components.TextInput{
+			CustomID:    createFormTime,
+			Style:       components.InputShort,
+			Label:       "When the event will occur",
+			Value:       values[createFormTime],
+			Placeholder: "Saturday 8pm EST",
+			MaxLength:   200,
+		},
+		components.TextInput{
+			CustomID:  createFormDescription,
+			Style:     components.InputParagraph,
+			Label:     "Description",
+			Value:     values[createFormDescription],
+			MaxLength: 2000,
+		},
+		components.TextInput{
+			CustomID:    createFormRoles,
+			Style:       components.InputParagraph,
+			Label:       "Roles (one per line)",
+			Value:       values[createFormRoles],
+			Placeholder: "NAME:COUNT[:EMOJI][{ALIAS|ALIAS}][[@ROLE|@ROLE]]\ntank:2:🛡️\nhealer:2{heals}",
+			Required:    true,
+			MaxLength:   2000,
+		},
+	)
+}
+
+// parseCreateForm checks every field of the event creation form, collecting all of the problems
+// rather than stopping at the first one
+func parseCreateForm(values map[string]string) (string, eventSettings, []string) {
+	var problems []string
+	es := eventSettings{}
+
+	eventName := strings.TrimSpace(values[createFormName])
+	if eventName == "" {
+		problems = append(problems, "An event name is required")
+	}
+
+	if v := strings.TrimSpace(values[createFormTime]); v != "" {
+		es.Time = &v
+	}
+
+	if v := strings.TrimSpace(values[createFormDescription]); v != "" {
+		es.Description = &v
+	}
+
+	badRoles := len(problems)
+	entries := strings.FieldsFunc(values[createFormRoles], func(r rune) bool { return r == '\n' || r == ',' })
+	roles := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if _, err := parseRolesString(entry); err != nil {
+			problems = append(problems, fmt.Sprintf("Role `%s`: %v (expected NAME:COUNT[:EMOJI])", entry, err))
+			continue
+		}
+
+		roles = append(roles, entry)
+	}
+
+	if len(roles) == 0 && len(problems) == badRoles {
+		problems = append(problems, "At least one role is required")
+	}
+
+	rolesStr := strings.Join(roles, ",")
+	es.Roles = &rolesStr
+
+	return eventName, es, problems
+}
+
+// HandleModal handles submissions of the forms opened by admin commands
+func (c *AdminCommands) HandleModal(s components.Submission) (cmdhandler.Response, error) {
+	action, _ := components.ParseCustomID(s.CustomID())
+	switch action {
+	case components.ActionCreateForm:
+		return c.createFormSubmit(s)
+	default:
+		return nil, errors.WithDetails(ErrUnknownForm, "custom_id", s.CustomID())
+	}
+}
+
+func (c *AdminCommands) createFormSubmit(s components.Submission) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(s.Context(), "adminCommands.createFormSubmit", "guild_id", s.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := components.LoggerWithSubmission(s, c.deps.Logger())
+	level.Info(logger).Message("handling admin form", "command", "create")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), s.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, s, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, msghandler.ErrUnauthorized
+	}
+
+	eventName, es, problems := parseCreateForm(s.Values())
+	if len(problems) == 0 {
+		if err := c.create(ctx, logger, s.GuildID(), gsettings, eventName, es); err != nil {
+			level.Info(logger).Message("could not create event from form", "trial_name", eventName, "err", err)
+			problems = append(problems, fmt.Sprintf("Could not create event: %v", err))
+		}
+	}
+
+	if len(problems) > 0 {
+		draftID := c.deps.ModalDrafts().Put(s.UserID(), s.Values())
+
+		r.Title = "The event was not created"
+		r.Description = "- " + strings.Join(problems, "\n- ")
+		return &retryResponse{
+			SimpleEmbedResponse: r,
+			rows: components.Rows([]components.Button{{
+				Type:     components.TypeButton,
+				Style:    components.StylePrimary,
+				Label:    "Edit and retry",
+				CustomID: components.CustomID(components.ActionCreateForm, draftID),
+			}}),
+		}, nil
+	}
+
+	level.Info(logger).Message("trial created", "trial_name", eventName)
+	r.Description = fmt.Sprintf("Event %q created successfully", eventName)
+	r.SetColor(okColor)
+
+	return r, nil
+}
+
+func (h *reactionHandler) clickCreateForm(c components.Click, draftID string) (cmdhandler.Response, error) {
+	values, ok := h.deps.ModalDrafts().Get(draftID, c.UserID())
+	if !ok {
+		return &cmdhandler.SimpleEmbedResponse{}, ErrDraftExpired
+	}
+
+	return newModalResponse(createEventModal(values)), nil
+}
@@ -147,16 +147,36 @@ func (c *AdminCommands) debug(ctx context.Context, gid snowflake.Snowflake, even
 		return r, err
 	}
 
+	emojiIDs, err := c.deps.GuildEmojis().Emojis(ctx, gid)
+	if err != nil {
+		level.Error(c.deps.Logger()).Err("could not look up guild emojis for debug", err, "guild_id", gid.ToString())
+	}
+	hasEmoji := func(id snowflake.Snowflake) bool { return emojiIDs[id] }
+
 	rcs := trial.GetRoleCounts(ctx)
 	rsParts := make([]string, 0, len(rcs))
 	for _, rc := range rcs {
-		rsParts = append(rsParts, fmt.Sprintf("'%s' %d '%s'", rc.GetRole(ctx), rc.GetCount(ctx), rc.GetEmoji(ctx)))
+		part := fmt.Sprintf("'%s' %s '%s'", rc.GetRole(ctx), storage.RoleCountString(ctx, rc), rc.GetEmoji(ctx))
+		if group := rc.GetGroup(ctx); group != "" {
+			part += fmt.Sprintf(" (group '%s')", group)
+		}
+		if emojiIDs != nil && emojiUnavailable(hasEmoji, rc.GetEmoji(ctx)) {
+			part += " (emoji not available in this server)"
+		}
+		rsParts = append(rsParts, part)
 	}
 	roleStr := strings.Join(rsParts, "\n		")
 
 	ro := trial.GetRoleOrder(ctx)
 	roleOrderStr := strings.Join(ro, ", ")
 
+	rgs := trial.GetRoleGroups(ctx)
+	rgParts := make([]string, 0, len(rgs))
+	for _, rg := range rgs {
+		rgParts = append(rgParts, fmt.Sprintf("'%s' %d", rg.GetName(ctx), rg.GetCount(ctx)))
+	}
+	roleGroupStr := strings.Join(rgParts, ", ")
+
 	announceChannel := trial.GetAnnounceChannel(ctx)
 	signupChannel := trial.GetSignupChannel(ctx)
 
@@ -191,6 +211,9 @@ Event settings:
 	- HideReactionsAnnounce: '%[12]v',
 	- HideReactionsShow: '%[13]v',
 	- RoleOrder: '%[8]s',
+	- RoleGroups: %[14]s,
+	- PriorityTiers: '%[15]s',
+	- PriorityHoursBefore: %[16]d,
 	- Roles:
 		%[6]s
 %[1]s
@@ -199,7 +222,7 @@ Description:
 %[1]s
 %[7]s
 
-%[1]s`, "```", announceChannel, signupChannel, trial.GetAnnounceTo(ctx), trial.GetState(ctx), roleStr, trial.GetDescription(ctx), roleOrderStr, announceChannelID.ToString(), signupChannelID.ToString(), trial.GetTime(ctx), trial.HideReactionsAnnounce(ctx), trial.HideReactionsShow(ctx))
+%[1]s`, "```", announceChannel, signupChannel, trial.GetAnnounceTo(ctx), trial.GetState(ctx), roleStr, trial.GetDescription(ctx), roleOrderStr, announceChannelID.ToString(), signupChannelID.ToString(), trial.GetTime(ctx), trial.HideReactionsAnnounce(ctx), trial.HideReactionsShow(ctx), roleGroupStr, trial.GetPriorityTiers(ctx), trial.GetPriorityHoursBefore(ctx))
 
 	return r, nil
 }
@@ -10,6 +10,7 @@ import (
 
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
@@ -146,5 +147,23 @@ func (c *AdminCommands) delete(ctx context.Context, gid snowflake.Snowflake, eve
 		return errors.Wrap(err, "could not delete event")
 	}
 
+	c.deps.Webhooks().Notify(webhooks.Event{
+		Kind:      webhooks.KindDelete,
+		GuildID:   gid.ToString(),
+		EventName: eventName,
+	})
+
+	// the live message updater forgets the messages of events that no longer exist
+	c.deps.LiveMessages().Refresh(gid, eventName)
+
+	// the event is already deleted, so failing to clean up after it is logged rather than reported
+	if err = c.deps.EventMessageAPI().RemoveEventMessages(ctx, gid.ToString(), eventName); err != nil {
+		level.Error(c.deps.Logger()).Err("could not remove event messages", err, "guild_id", gid.ToString(), "trial_name", eventName)
+	}
+
+	if err = c.cancelEventJobs(ctx, gid, eventName); err != nil {
+		level.Error(c.deps.Logger()).Err("could not cancel event jobs", err, "guild_id", gid.ToString(), "trial_name", eventName)
+	}
+
 	return nil
 }
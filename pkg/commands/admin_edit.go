@@ -173,6 +173,22 @@ func (c *AdminCommands) edit(ctx context.Context, gid snowflake.Snowflake, event
 		trial.SetTime(ctx, *settings.Time)
 	}
 
+	if settings.Category != nil {
+		trial.SetCategory(ctx, *settings.Category)
+	}
+
+	if settings.Leader != nil {
+		trial.SetLeader(ctx, *settings.Leader)
+	}
+
+	if err = applyScheduleSettings(ctx, trial, settings); err != nil {
+		return err
+	}
+
+	if err = applyPrioritySettings(ctx, trial, settings); err != nil {
+		return err
+	}
+
 	if settings.RoleOrder != nil {
 		roleOrder := strings.Split(*settings.RoleOrder, ",")
 		for i := range roleOrder {
@@ -181,20 +197,44 @@ func (c *AdminCommands) edit(ctx context.Context, gid snowflake.Snowflake, event
 		trial.SetRoleOrder(ctx, roleOrder)
 	}
 
+	if settings.RoleGroups != nil {
+		groups, err := parseRoleGroupsString(*settings.RoleGroups)
+		if err != nil {
+			return err
+		}
+		applyRoleGroups(ctx, trial, groups)
+	}
+
 	if settings.Roles != nil {
 		roleCtEmoList, err := parseRolesString(*settings.Roles)
 		if err != nil {
 			return err
 		}
+		if err = checkRoleEmojis(ctx, c.deps.GuildEmojis(), gid, roleCtEmoList); err != nil {
+			return err
+		}
 		for _, rce := range roleCtEmoList {
-			if rce.ct == 0 {
+			if rce.ct == 0 && !rce.noMax {
 				trial.RemoveRole(ctx, rce.role)
 			} else {
 				trial.SetRoleCount(ctx, rce.role, rce.emo, rce.ct)
+				if rce.hasReqs {
+					trial.SetRoleRequirements(ctx, rce.role, rce.reqs)
+				}
+				if rce.hasAliases {
+					trial.SetRoleAliases(ctx, rce.role, rce.aliases)
+				}
+				if rce.hasGroup {
+					trial.SetRoleParentGroup(ctx, rce.role, rce.group)
+				}
 			}
 		}
 	}
 
+	if err = checkRoleGroups(ctx, trial); err != nil {
+		return err
+	}
+
 	if err = t.SaveTrial(ctx, trial); err != nil {
 		return errors.Wrap(err, "could not save event")
 	}
@@ -203,5 +243,10 @@ func (c *AdminCommands) edit(ctx context.Context, gid snowflake.Snowflake, event
 		return errors.Wrap(err, "could not save event")
 	}
 
+	// the edit is already saved, so failing to reschedule the event's jobs is logged rather than reported
+	if err = c.rescheduleEventJobs(ctx, gid, trial); err != nil {
+		level.Error(c.deps.Logger()).Err("could not reschedule event jobs", err, "guild_id", gid.ToString(), "trial_name", trial.GetName(ctx))
+	}
+
 	return nil
 }
@@ -0,0 +1,336 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/fileupload"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+)
+
+const (
+	exportFormatCSV  = "csv"
+	exportFormatJSON = "json"
+)
+
+var ErrBadExportFormat = errors.New("export format must be csv or json")
+
+// attachmentResponse is an embed response that has files sent along with it
+type attachmentResponse struct {
+	*cmdhandler.SimpleEmbedResponse
+	files []fileupload.File
+}
+
+var _ msghandler.AttachmentResponse = (*attachmentResponse)(nil)
+
+func (r *attachmentResponse) Attachments() []fileupload.File {
+	return r.files
+}
+
+// exportRow is one signup in a roster export
+type exportRow struct {
+	Role        string `json:"role"`
+	Slot        int    `json:"slot"`
+	Overflow    bool   `json:"overflow"`
+	MemberID    string `json:"member_id"`
+	DisplayName string `json:"display_name"`
+	SignedUpAt  string `json:"signed_up_at"`
+	Note        string `json:"note"`
+}
+
+type exportDoc struct {
+	Event   string      `json:"event"`
+	Time    string      `json:"time"`
+	Signups []exportRow `json:"signups"`
+}
+
+func parseExportFormat(val string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(val)) {
+	case "", exportFormatCSV:
+		return exportFormatCSV, nil
+	case exportFormatJSON:
+		return exportFormatJSON, nil
+	default:
+		return "", errors.WithDetails(ErrBadExportFormat, "format", val)
+	}
+}
+
+func (c *AdminCommands) exportInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "adminCommands.exportInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &attachmentResponse{SimpleEmbedResponse: &cmdhandler.SimpleEmbedResponse{}}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling admin interaction", "command", "export")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var eventName, formatStr string
+	for i := range opts {
+		switch opts[i].Name {
+		case "event_name":
+			eventName = opts[i].ValueString
+		case "format":
+			formatStr = opts[i].ValueString
+		}
+	}
+
+	format, err := parseExportFormat(formatStr)
+	if err != nil {
+		return r, nil, err
+	}
+
+	file, count, err := c.export(ctx, ix.GuildID(), eventName, format)
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not export event")
+	}
+
+	level.Info(logger).Message("exported event", "trial_name", eventName, "format", format, "signups", count)
+
+	r.Description = fmt.Sprintf("Exported %d signups for %s.", count, eventName)
+	r.files = []fileupload.File{file}
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *AdminCommands) exportHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "adminCommands.exportHandler", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &attachmentResponse{SimpleEmbedResponse: &cmdhandler.SimpleEmbedResponse{}}
+
+	r.SetReplyTo(msg)
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling adminCommand", "command", "export", "args", msg.Contents())
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, msghandler.ErrUnauthorized
+	}
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 1 {
+		return r, errors.New("need event name")
+	}
+
+	if len(msg.Contents()) > 2 {
+		return r, errors.New("too many arguments")
+	}
+
+	trialName := msg.Contents()[0]
+
+	var formatStr string
+	if len(msg.Contents()) > 1 {
+		formatStr = msg.Contents()[1]
+	}
+
+	format, err := parseExportFormat(formatStr)
+	if err != nil {
+		return r, err
+	}
+
+	file, count, err := c.export(ctx, msg.GuildID(), trialName, format)
+	if err != nil {
+		return r, errors.Wrap(err, "could not export event")
+	}
+
+	level.Info(logger).Message("exported event", "trial_name", trialName, "format", format, "signups", count)
+
+	r.Description = fmt.Sprintf("Exported %d signups for %s.", count, trialName)
+	r.files = []fileupload.File{file}
+	r.SetColor(okColor)
+
+	return r, nil
+}
+
+// exportRows lists the signups of an event by role, in roster order; slots past the role count are overflow
+func exportRows(ctx context.Context, trial storage.Trial, displayName func(string) string) []exportRow {
+	signups := trial.GetSignups(ctx)
+	rows := make([]exportRow, 0, len(signups))
+
+	roster := storage.TrialRoster(ctx, trial)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		main := roster.Main(rc.GetRole(ctx))
+		roleSignups := make([]storage.TrialSignup, 0, len(main)+len(roster.Overflow(rc.GetRole(ctx))))
+		roleSignups = append(append(roleSignups, main...), roster.Overflow(rc.GetRole(ctx))...)
+
+		for i, su := range roleSignups {
+			row := exportRow{
+				Role:        rc.GetRole(ctx),
+				Slot:        i + 1,
+				Overflow:    i >= len(main),
+				DisplayName: displayName(su.GetName(ctx)),
+				Note:        su.GetNote(ctx),
+			}
+
+			if uid, err := userFromMention(su.GetName(ctx)); err == nil {
+				row.MemberID = uid.ToString()
+			}
+
+			if at := su.GetSignedUpAt(ctx); !at.IsZero() {
+				row.SignedUpAt = at.UTC().Format(time.RFC3339)
+			}
+
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
+
+func encodeExportCSV(rows []exportRow) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+
+	if err := w.Write([]string{"role", "slot", "overflow", "member_id", "display_name", "signed_up_at", "note"}); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		err := w.Write([]string{row.Role, strconv.Itoa(row.Slot), strconv.FormatBool(row.Overflow), row.MemberID, row.DisplayName, row.SignedUpAt, row.Note})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// memberDisplayNames looks up the server nickname (or username) of mentioned members, falling back to
+// the signup name for plain-text signups and members that cannot be found
+func (c *AdminCommands) memberDisplayNames(ctx context.Context, gid snowflake.Snowflake) func(string) string {
+	return func(name string) string {
+		uid, err := userFromMention(name)
+		if err != nil {
+			return name
+		}
+
+		gm, err := c.deps.Bot().API().GetGuildMember(ctx, gid, uid)
+		if err != nil {
+			level.Info(c.deps.Logger()).Message("could not look up member for export", "member_id", uid.ToString(), "error", err.Error())
+			return name
+		}
+
+		if gm.Nick != "" {
+			return gm.Nick
+		}
+
+		return gm.User.Username
+	}
+}
+
+// export builds the roster file for an event, returning it along with the number of signups in it
+func (c *AdminCommands) export(ctx context.Context, gid snowflake.Snowflake, eventName, format string) (fileupload.File, int, error) {
+	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.export", "guild_id", gid.ToString())
+	defer span.End()
+
+	t, err := c.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		return fileupload.File{}, 0, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, eventName)
+	if err != nil {
+		return fileupload.File{}, 0, err
+	}
+
+	rows := exportRows(ctx, trial, c.memberDisplayNames(ctx, gid))
+	file := fileupload.File{
+		Name: exportFilename(trial.GetName(ctx), format),
+	}
+
+	switch format {
+	case exportFormatJSON:
+		file.ContentType = "application/json"
+		file.Data, err = json.MarshalIndent(exportDoc{
+			Event:   trial.GetName(ctx),
+			Time:    trial.GetTime(ctx),
+			Signups: rows,
+		}, "", "  ")
+	default:
+		file.ContentType = "text/csv"
+		file.Data, err = encodeExportCSV(rows)
+	}
+
+	return file, len(rows), errors.Wrap(err, "could not encode export", "format", format)
+}
+
+// exportFilename makes a safe file name from the event name
+func exportFilename(eventName, format string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, eventName)
+
+	if strings.Trim(name, "_") == "" {
+		name = "event"
+	}
+
+	return fmt.Sprintf("%s.%s", name, format)
+}
@@ -2,15 +2,16 @@ package commands
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"github.com/gsmcwhirter/go-util/v8/deferutil"
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/templates"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
@@ -66,10 +67,6 @@ func (c *AdminCommands) groupingInteraction(ix *cmdhandler.Interaction, opts []e
 			announceChannel = opts[i].ValueChannel
 		}
 	}
-	if phrase == "" {
-		phrase = fmt.Sprintf("Grouping now for %s!", eventName)
-	}
-
 	r2, err := c.grouping(ctx, ix.GuildID(), gsettings, eventName, phrase)
 	if err != nil {
 		return r, nil, err
@@ -133,10 +130,7 @@ func (c *AdminCommands) groupingHandler(msg cmdhandler.Message) (cmdhandler.Resp
 	}
 
 	trialName := msg.Contents()[0]
-	phrase := fmt.Sprintf("Grouping now for %s!", trialName)
-	if len(msg.Contents()) > 1 {
-		phrase = strings.Join(msg.Contents()[1:], " ")
-	}
+	phrase := strings.Join(msg.Contents()[1:], " ")
 
 	r2, err := c.grouping(ctx, msg.GuildID(), gsettings, trialName, phrase)
 	if err != nil {
@@ -173,11 +167,12 @@ func (c *AdminCommands) grouping(ctx context.Context, gid snowflake.Snowflake, g
 
 	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
 	signups := trial.GetSignups(ctx)
+	roster := storage.TrialRoster(ctx, trial)
 
 	userMentions := make([]string, 0, len(signups))
 
 	for _, rc := range roleCounts {
-		suNames, ofNames := getTrialRoleSignups(ctx, signups, rc)
+		suNames, ofNames := getTrialRoleSignups(ctx, roster, rc)
 
 		userMentions = append(userMentions, suNames...)
 		userMentions = append(userMentions, ofNames...)
@@ -185,6 +180,18 @@ func (c *AdminCommands) grouping(ctx context.Context, gid snowflake.Snowflake, g
 
 	toStr := strings.Join(userMentions, ", ")
 
+	// without a message of its own, the grouping template is used
+	if phrase == "" {
+		phrase, err = renderMessage(ctx, c.deps.TemplateAPI(), gid, trial, templates.KindGrouping, eventTemplateData(ctx, c.deps.BotSession(), gid, trial))
+		if err != nil {
+			return nil, errors.Wrap(err, "could not render grouping template")
+		}
+	}
+
+	if phrase == "" {
+		phrase = i18n.Sprintf(gsettings.Language, i18n.GroupingNow, trial.GetName(ctx))
+	}
+
 	r := &cmdhandler.SimpleEmbedResponse{
 		To:          toStr,
 		ToChannel:   announceCid,
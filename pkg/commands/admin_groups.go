@@ -0,0 +1,180 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+)
+
+func (c *AdminCommands) groupsInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "adminCommands.groupsInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling admin interaction", "command", "groups")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var eventName, quotaStr string
+	var size int
+	for i := range opts {
+		switch opts[i].Name {
+		case "event_name":
+			eventName = opts[i].ValueString
+		case "size":
+			size = opts[i].ValueInt
+		case "quotas":
+			quotaStr = opts[i].ValueString
+		}
+	}
+
+	if size < 1 {
+		return r, nil, i18n.NewError(i18n.GroupsSizeMin)
+	}
+
+	embeds, desc, err := c.groups(ctx, i18n.NewPrinter(gsettings.Language), ix.GuildID(), eventName, size, quotaStr)
+	if err != nil {
+		return r, nil, err
+	}
+
+	extras := make([]cmdhandler.Response, 0, len(embeds))
+	for _, e := range embeds {
+		e.SetColor(okColor)
+		extras = append(extras, e)
+	}
+
+	r.Description = desc
+	r.SetColor(okColor)
+
+	level.Info(logger).Message("trial groups built", "trial_name", eventName, "size", size, "groups", len(embeds))
+
+	return r, extras, nil
+}
+
+// groups splits the roster of an event into groups, returning an embed for each group (and one
+// for anyone who could not be placed) along with a summary of the quotas used
+func (c *AdminCommands) groups(ctx context.Context, p i18n.Printer, gid snowflake.Snowflake, eventName string, size int, quotaStr string) ([]*cmdhandler.EmbedResponse, string, error) {
+	t, err := c.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		return nil, "", err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, eventName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
+	byName := storage.RoleGroupsByName(ctx, trial.GetRoleGroups(ctx))
+	roster := storage.TrialRoster(ctx, trial)
+
+	rosters := make([]roleRoster, 0, len(roleCounts))
+	counts := make(map[string]int, len(roleCounts))
+	for _, rc := range roleCounts {
+		suNames, ofNames := getTrialRoleSignups(ctx, roster, rc)
+		rosters = append(rosters, roleRoster{role: rc.GetRole(ctx), main: suNames, overflow: ofNames})
+		counts[rc.GetRole(ctx)] = int(storage.RoleCapacity(ctx, rc, byName))
+	}
+
+	var quotas map[string]int
+	if strings.TrimSpace(quotaStr) == "" {
+		quotas = defaultGroupQuotas(rosters, counts, size)
+	} else if quotas, err = parseGroupQuotas(quotaStr, rosters, size); err != nil {
+		return nil, "", errors.Wrap(err, "could not parse quotas (use ROLE:COUNT, ROLE:COUNT, ...)")
+	}
+
+	groups, unassigned := buildGroups(rosters, size, quotas)
+
+	quotaStrs := make([]string, 0, len(rosters))
+	for _, rr := range rosters {
+		quotaStrs = append(quotaStrs, fmt.Sprintf("%s: %d", rr.role, quotas[strings.ToLower(rr.role)]))
+	}
+
+	desc := p.Sprintf(i18n.GroupsSummary, len(groups), size, trial.GetName(ctx), strings.Join(quotaStrs, ", "))
+
+	embeds := make([]*cmdhandler.EmbedResponse, 0, len(groups)+1)
+	for i, g := range groups {
+		embeds = append(embeds, &cmdhandler.EmbedResponse{
+			Title:      p.Sprintf(i18n.GroupsTitle, i+1, len(g), size),
+			Fields:     groupFields(p, rosters, g),
+			FooterText: fmt.Sprintf("event:%s", trial.GetName(ctx)),
+		})
+	}
+
+	if len(unassigned) > 0 {
+		desc += "\n" + p.Sprintf(i18n.GroupsUnassigned, len(unassigned))
+		embeds = append(embeds, &cmdhandler.EmbedResponse{
+			Title:      p.Sprintf(i18n.GroupsNotGrouped),
+			Fields:     groupFields(p, rosters, unassigned),
+			FooterText: fmt.Sprintf("event:%s", trial.GetName(ctx)),
+		})
+	}
+
+	return embeds, desc, nil
+}
+
+// groupFields lists the members of a group by role, in the event's role order
+func groupFields(p i18n.Printer, rosters []roleRoster, members []groupMember) []cmdhandler.EmbedField {
+	fields := make([]cmdhandler.EmbedField, 0, len(rosters))
+	for _, rr := range rosters {
+		names := make([]string, 0, len(members))
+		for _, m := range members {
+			if m.role != rr.role {
+				continue
+			}
+
+			if m.overflow {
+				names = append(names, p.Sprintf(i18n.GroupsOverflowName, m.name))
+			} else {
+				names = append(names, m.name)
+			}
+		}
+
+		if len(names) == 0 {
+			continue
+		}
+
+		fields = append(fields, splitField(cmdhandler.EmbedField{
+			Name: fmt.Sprintf("*%s* (%d)", rr.role, len(names)),
+			Val:  strings.Join(names, "\n") + fieldSpacer,
+		})...)
+	}
+
+	return fields
+}
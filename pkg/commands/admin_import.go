@@ -0,0 +1,361 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	log "github.com/gsmcwhirter/go-util/v8/logging"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+)
+
+const (
+	maxImportBytes = 256 * 1024
+	maxImportRows  = 500
+)
+
+// importRow is one line of an import file; line is the line number in the file, for error messages
+type importRow struct {
+	line int
+	user string
+	role string
+}
+
+type importResult struct {
+	rows      int
+	accepted  []string
+	overflows []string
+	errs      []string
+	dryRun    bool
+}
+
+func (c *AdminCommands) importInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "adminCommands.importInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling admin interaction", "command", "import")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var eventName, fileURL string
+	var dryRun bool
+	for i := range opts {
+		switch opts[i].Name {
+		case "event_name":
+			eventName = opts[i].ValueString
+		case "file_url":
+			fileURL = strings.TrimSpace(opts[i].ValueString)
+		case "dry_run":
+			dryRun = opts[i].ValueBool
+		}
+	}
+
+	p := i18n.NewPrinter(gsettings.Language)
+	res, err := c.importRoster(ctx, logger, p, ix.GuildID(), roleAliases(gsettings), eventName, fileURL, dryRun)
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not import roster")
+	}
+
+	r.Description = formatImportResult(p, eventName, res)
+	if len(res.errs) == 0 {
+		r.SetColor(okColor)
+	}
+
+	return r, nil, nil
+}
+
+func (c *AdminCommands) importHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "adminCommands.importHandler", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	r.SetReplyTo(msg)
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling adminCommand", "command", "import", "args", msg.Contents())
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, msghandler.ErrUnauthorized
+	}
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	if len(msg.Contents()) < 2 {
+		return r, i18n.NewError(i18n.ImportMissingArgs)
+	}
+
+	if len(msg.Contents()) > 3 || (len(msg.Contents()) == 3 && strings.ToLower(msg.Contents()[2]) != "dryrun") {
+		return r, i18n.NewError(i18n.ImportUsage)
+	}
+
+	trialName := msg.Contents()[0]
+	dryRun := len(msg.Contents()) == 3
+
+	p := i18n.NewPrinter(gsettings.Language)
+	res, err := c.importRoster(ctx, logger, p, msg.GuildID(), roleAliases(gsettings), trialName, msg.Contents()[1], dryRun)
+	if err != nil {
+		return r, errors.Wrap(err, "could not import roster")
+	}
+
+	r.Description = formatImportResult(p, trialName, res)
+	if len(res.errs) == 0 {
+		r.SetColor(okColor)
+	}
+
+	return r, nil
+}
+
+// parseImportCSV reads `user,role` rows, skipping blank lines and an optional header row; rows that
+// cannot be read are reported as errors rather than stopping the whole file
+func parseImportCSV(p i18n.Printer, data []byte) ([]importRow, []string) {
+	rd := csv.NewReader(bytes.NewReader(data))
+	rd.FieldsPerRecord = -1
+	rd.TrimLeadingSpace = true
+
+	var rows []importRow
+	var errs []string
+
+	for {
+		rec, err := rd.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			pe, ok := err.(*csv.ParseError)
+			if !ok {
+				errs = append(errs, err.Error())
+				break
+			}
+
+			errs = append(errs, p.Sprintf(i18n.ImportLineError, pe.StartLine, pe.Err.Error()))
+			continue
+		}
+
+		line, _ := rd.FieldPos(0)
+
+		if len(rec) == 1 && strings.TrimSpace(rec[0]) == "" {
+			continue
+		}
+
+		if len(rows) == 0 && len(errs) == 0 && len(rec) >= 2 && strings.EqualFold(strings.TrimSpace(rec[1]), "role") {
+			continue // header
+		}
+
+		if len(rec) != 2 {
+			errs = append(errs, p.Sprintf(i18n.ImportColumns, line, len(rec)))
+			continue
+		}
+
+		rows = append(rows, importRow{
+			line: line,
+			user: strings.TrimSpace(rec[0]),
+			role: strings.TrimSpace(rec[1]),
+		})
+	}
+
+	return rows, errs
+}
+
+// resolveImportUser finds the user id for a user column, which may be an id, a mention or a username
+func (c *AdminCommands) resolveImportUser(ctx context.Context, gid snowflake.Snowflake, user string) (snowflake.Snowflake, error) {
+	if cmdhandler.IsUserMention(user) {
+		return userFromMention(user)
+	}
+
+	if uid, err := snowflake.FromString(user); err == nil {
+		return uid, nil
+	}
+
+	return c.deps.MemberSearch().FindByName(ctx, gid, strings.TrimPrefix(user, "@"))
+}
+
+// importRoster validates every row of an import file and then, unless this is a dry run or any row
+// failed, signs everyone up in a single transaction
+func (c *AdminCommands) importRoster(ctx context.Context, logger log.Logger, p i18n.Printer, gid snowflake.Snowflake, aliases map[string]string, eventName, fileURL string, dryRun bool) (importResult, error) {
+	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.importRoster", "guild_id", gid.ToString())
+	defer span.End()
+
+	res := importResult{dryRun: dryRun}
+
+	data, err := c.deps.Uploader().Fetch(ctx, fileURL, maxImportBytes)
+	if err != nil {
+		return res, err
+	}
+
+	rows, errs := parseImportCSV(p, data)
+	res.rows = len(rows)
+	res.errs = errs
+
+	if len(rows) > maxImportRows {
+		return res, errors.WithDetails(errors.New("too many rows in import file"), "max_rows", maxImportRows)
+	}
+
+	t, err := c.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), true)
+	if err != nil {
+		return res, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, eventName)
+	if err != nil {
+		return res, err
+	}
+
+	roleCounts := trial.GetRoleCounts(ctx)
+	mentions := make([]string, len(rows))
+	memberRoles := make([]memberRolesFunc, len(rows))
+	seen := map[snowflake.Snowflake]int{}
+
+	for i, row := range rows {
+		rc, err := findRole(ctx, row.role, roleCounts, aliases)
+		if err != nil {
+			res.errs = append(res.errs, p.Sprintf(i18n.ImportRowError, row.line, row.user, p.Error(err)))
+			continue
+		}
+		rows[i].role = rc.GetRole(ctx)
+
+		uid, err := c.resolveImportUser(ctx, gid, row.user)
+		if err != nil {
+			res.errs = append(res.errs, p.Sprintf(i18n.ImportRowError, row.line, row.user, p.Error(err)))
+			continue
+		}
+
+		if prev, dup := seen[uid]; dup {
+			res.errs = append(res.errs, p.Sprintf(i18n.ImportRowError, row.line, row.user, p.Sprintf(i18n.ImportSameUser, prev)))
+			continue
+		}
+		seen[uid] = row.line
+
+		gm, err := c.deps.Bot().API().GetGuildMember(ctx, gid, uid)
+		if err != nil {
+			res.errs = append(res.errs, p.Sprintf(i18n.ImportRowError, row.line, row.user, p.Sprintf(i18n.ImportNotMember)))
+			continue
+		}
+
+		memberRoles[i] = func(context.Context) ([]snowflake.Snowflake, error) { return gm.RoleSnowflakes, nil }
+		if err := checkRoleRequirements(ctx, rc, memberRoles[i]); err != nil {
+			res.errs = append(res.errs, p.Sprintf(i18n.ImportRowError, row.line, row.user, p.Error(err)))
+			continue
+		}
+
+		mentions[i] = cmdhandler.UserMentionString(uid)
+	}
+
+	level.Info(logger).Message("import validated", "trial_name", eventName, "rows", len(rows), "errors", len(res.errs), "dry_run", dryRun)
+
+	if dryRun || len(res.errs) > 0 {
+		return res, nil
+	}
+
+	for i, row := range rows {
+		// the role requirements were checked above, so they can be reported for every line at once
+		_, overflow, err := signupUser(ctx, trial, mentions[i], row.role, nil, memberRoles[i], false)
+		if err != nil {
+			return res, errors.Wrap(err, "could not sign up user", "line", row.line)
+		}
+
+		if overflow {
+			res.overflows = append(res.overflows, mentions[i])
+		} else {
+			res.accepted = append(res.accepted, mentions[i])
+		}
+	}
+
+	if err = t.SaveTrial(ctx, trial); err != nil {
+		return res, errors.Wrap(err, "could not save event signups")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return res, errors.Wrap(err, "could not save event signups")
+	}
+
+	for i, row := range rows {
+		recordActivity(ctx, logger, c.deps.ActivityAPI(), gid, trial, mentions[i], row.role, storage.ActivitySignup, false)
+		c.deps.Webhooks().Notify(webhookEvent(ctx, gid, webhooks.KindSignup, trial, mentions[i], row.role))
+	}
+	c.deps.LiveMessages().Refresh(gid, trial.GetName(ctx))
+
+	return res, nil
+}
+
+func formatImportResult(p i18n.Printer, eventName string, res importResult) string {
+	var lines []string
+
+	switch {
+	case len(res.errs) > 0:
+		lines = append(lines, p.Sprintf(i18n.ImportFailed, eventName))
+		lines = append(lines, res.errs...)
+	case res.dryRun:
+		lines = append(lines, p.Sprintf(i18n.ImportDryRun, res.rows, eventName))
+	default:
+		lines = append(lines, p.Sprintf(i18n.ImportDone, res.rows, eventName))
+		if len(res.accepted) > 0 {
+			lines = append(lines, p.Sprintf(i18n.ImportMainGroup, strings.Join(res.accepted, ", ")))
+		}
+		if len(res.overflows) > 0 {
+			lines = append(lines, p.Sprintf(i18n.ImportOverflow, strings.Join(res.overflows, ", ")))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
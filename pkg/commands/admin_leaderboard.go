@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+)
+
+func (c *AdminCommands) leaderboardInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "adminCommands.leaderboardInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling admin interaction", "command", "leaderboard")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var daysStr, role string
+	for i := range opts {
+		switch opts[i].Name {
+		case "days":
+			daysStr = opts[i].ValueString
+		case "role":
+			role = opts[i].ValueString
+		}
+	}
+
+	days, err := parseStatsDays(daysStr)
+	if err != nil {
+		return r, nil, err
+	}
+
+	desc, err := c.leaderboard(ctx, i18n.NewPrinter(gsettings.Language), ix.GuildID(), days, role)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.Description = desc
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *AdminCommands) leaderboardHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "adminCommands.leaderboardHandler", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	r.SetReplyTo(msg)
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling adminCommand", "command", "leaderboard", "args", msg.Contents())
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, msg, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, msghandler.ErrUnauthorized
+	}
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	argMap, err := parseSettingDescriptionArgs(msg.Contents())
+	if err != nil {
+		return r, errors.Wrap(err, "could not parse arguments (use days=N role=ROLE)")
+	}
+
+	days, err := parseStatsDays(argMap["days"])
+	if err != nil {
+		return r, err
+	}
+
+	desc, err := c.leaderboard(ctx, i18n.NewPrinter(gsettings.Language), msg.GuildID(), days, argMap["role"])
+	if err != nil {
+		return r, err
+	}
+
+	r.Description = desc
+	r.SetColor(okColor)
+
+	return r, nil
+}
+
+func (c *AdminCommands) leaderboard(ctx context.Context, p i18n.Printer, gid snowflake.Snowflake, days int, role string) (string, error) {
+	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.leaderboard", "guild_id", gid.ToString())
+	defer span.End()
+
+	stats, err := c.deps.ActivityAPI().GuildStats(ctx, gid.ToString(), statsSince(days))
+	if err != nil {
+		return "", errors.Wrap(err, "could not retrieve member stats")
+	}
+
+	return formatLeaderboard(p, leaderboard(stats, role), role, days), nil
+}
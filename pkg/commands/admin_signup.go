@@ -11,8 +11,10 @@ import (
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 	multierror "github.com/hashicorp/go-multierror"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
@@ -86,14 +88,15 @@ func (c *AdminCommands) signupInteraction(ix *cmdhandler.Interaction, opts []ent
 	if r2 != nil {
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
 		r2.SetColor(okColor)
-	} else {
-		r2 = &cmdhandler.EmbedResponse{}
-		r2.To = strings.Join(userMentions, ", ")
-		r2.ToChannel = signupCid
-		r2.Description = descStr
-		r2.SetColor(okColor)
+		return r, []cmdhandler.Response{r2}, nil
 	}
-	return r, []cmdhandler.Response{r2}, nil
+
+	r3 := &cmdhandler.EmbedResponse{}
+	r3.To = strings.Join(userMentions, ", ")
+	r3.ToChannel = signupCid
+	r3.Description = descStr
+	r3.SetColor(okColor)
+	return r, []cmdhandler.Response{r3}, nil
 }
 
 func (c *AdminCommands) signupHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
@@ -187,7 +190,7 @@ func (c *AdminCommands) signupHandler(msg cmdhandler.Message) (cmdhandler.Respon
 	return r, nil
 }
 
-func (c *AdminCommands) signup(ctx context.Context, logger log.Logger, msg msghandler.MessageLike, gid snowflake.Snowflake, gsettings storage.GuildSettings, eventName, role string, userMentions []string) (cid snowflake.Snowflake, r2 *cmdhandler.EmbedResponse, accepted, overflows []string, err error) {
+func (c *AdminCommands) signup(ctx context.Context, logger log.Logger, msg msghandler.MessageLike, gid snowflake.Snowflake, gsettings storage.GuildSettings, eventName, role string, userMentions []string) (cid snowflake.Snowflake, r2 *eventEmbed, accepted, overflows []string, err error) {
 	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.signup", "guild_id", gid.ToString())
 	defer span.End()
 
@@ -209,7 +212,7 @@ func (c *AdminCommands) signup(ctx context.Context, logger log.Logger, msg msgha
 	}
 
 	if trial.GetState(ctx) != storage.TrialStateOpen {
-		return 0, nil, nil, nil, errors.New("cannot sign up for a closed event")
+		return 0, nil, nil, nil, ErrSignupClosed
 	}
 
 	sessionGuild, ok := c.deps.BotSession().Guild(gid)
@@ -228,7 +231,8 @@ func (c *AdminCommands) signup(ctx context.Context, logger log.Logger, msg msgha
 
 	for i, userMention := range userMentions {
 		var serr error
-		ofs[i], serr = signupUser(ctx, trial, userMention, role)
+		memberRoles := adminMemberRoles(logger, c.deps.Bot(), gid, userMention)
+		_, ofs[i], serr = signupUser(ctx, trial, userMention, role, roleAliases(gsettings), memberRoles, false)
 		if serr != nil {
 			err = multierror.Append(err, serr)
 			continue
@@ -253,10 +257,16 @@ func (c *AdminCommands) signup(ctx context.Context, logger log.Logger, msg msgha
 		return signupCid, nil, nil, nil, errors.Wrap(err, "could not save event signup")
 	}
 
+	for _, userMention := range userMentions {
+		recordActivity(ctx, logger, c.deps.ActivityAPI(), gid, trial, userMention, role, storage.ActivitySignup, false)
+		c.deps.Webhooks().Notify(webhookEvent(ctx, gid, webhooks.KindSignup, trial, userMention, role))
+	}
+	c.deps.LiveMessages().Refresh(gid, trial.GetName(ctx))
+
 	if gsettings.ShowAfterSignup == "true" {
 		level.Debug(logger).Message("auto-show after signup", "trial_name", eventName)
 
-		r2 = formatTrialDisplay(ctx, trial, true)
+		r2 = formatTrialDisplay(ctx, i18n.NewPrinter(gsettings.Language), trial, true)
 		r2.To = strings.Join(userMentions, ", ")
 		r2.ToChannel = signupCid
 	}
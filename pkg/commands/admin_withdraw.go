@@ -11,8 +11,10 @@ import (
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 	multierror "github.com/hashicorp/go-multierror"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
@@ -75,14 +77,15 @@ func (c *AdminCommands) withdrawInteraction(ix *cmdhandler.Interaction, opts []e
 	if r2 != nil {
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
 		r2.SetColor(okColor)
-	} else {
-		r2 = &cmdhandler.EmbedResponse{}
-		r2.To = strings.Join(userMentions, ", ")
-		r2.ToChannel = signupCid
-		r2.Description = descStr
-		r2.SetColor(okColor)
+		return r, []cmdhandler.Response{r2}, nil
 	}
-	return r, []cmdhandler.Response{r2}, nil
+
+	r3 := &cmdhandler.EmbedResponse{}
+	r3.To = strings.Join(userMentions, ", ")
+	r3.ToChannel = signupCid
+	r3.Description = descStr
+	r3.SetColor(okColor)
+	return r, []cmdhandler.Response{r3}, nil
 }
 
 func (c *AdminCommands) withdrawHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
@@ -169,7 +172,7 @@ func (c *AdminCommands) withdrawHandler(msg cmdhandler.Message) (cmdhandler.Resp
 	return r, nil
 }
 
-func (c *AdminCommands) withdraw(ctx context.Context, logger log.Logger, msg msghandler.MessageLike, gid snowflake.Snowflake, gsettings storage.GuildSettings, eventName string, userMentions []string) (cid snowflake.Snowflake, r2 *cmdhandler.EmbedResponse, err error) {
+func (c *AdminCommands) withdraw(ctx context.Context, logger log.Logger, msg msghandler.MessageLike, gid snowflake.Snowflake, gsettings storage.GuildSettings, eventName string, userMentions []string) (cid snowflake.Snowflake, r2 *eventEmbed, err error) {
 	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.withdraw", "guild_id", gid.ToString())
 	defer span.End()
 
@@ -191,7 +194,7 @@ func (c *AdminCommands) withdraw(ctx context.Context, logger log.Logger, msg msg
 	}
 
 	if trial.GetState(ctx) != storage.TrialStateOpen {
-		return 0, nil, errors.New("cannot withdraw from a closed event")
+		return 0, nil, ErrWithdrawClosed
 	}
 
 	sessionGuild, ok := c.deps.BotSession().Guild(gid)
@@ -204,6 +207,7 @@ func (c *AdminCommands) withdraw(ctx context.Context, logger log.Logger, msg msg
 		signupCid = scID
 	}
 
+	withdrawnRoles := map[string]string{}
 	for _, m := range userMentions {
 		userAcctMention, werr := cmdhandler.ForceUserAccountMention(m)
 		if err != nil {
@@ -211,6 +215,10 @@ func (c *AdminCommands) withdraw(ctx context.Context, logger log.Logger, msg msg
 			continue
 		}
 
+		if role, ok := signupRole(ctx, trial, m); ok {
+			withdrawnRoles[m] = role
+		}
+
 		trial.RemoveSignup(ctx, userAcctMention)
 		trial.RemoveSignup(ctx, m)
 	}
@@ -227,10 +235,17 @@ func (c *AdminCommands) withdraw(ctx context.Context, logger log.Logger, msg msg
 		return 0, nil, errors.Wrap(err, "could not save event withdraw")
 	}
 
+	// an admin removing someone is not held against that member, however close to the event it is
+	for m, role := range withdrawnRoles {
+		recordActivity(ctx, logger, c.deps.ActivityAPI(), gid, trial, m, role, storage.ActivityWithdraw, false)
+		c.deps.Webhooks().Notify(webhookEvent(ctx, gid, webhooks.KindWithdraw, trial, m, role))
+	}
+	c.deps.LiveMessages().Refresh(gid, trial.GetName(ctx))
+
 	if gsettings.ShowAfterWithdraw == "true" {
 		level.Debug(logger).Message("auto-show after signup", "trial_name", eventName)
 
-		r2 = formatTrialDisplay(ctx, trial, true)
+		r2 = formatTrialDisplay(ctx, i18n.NewPrinter(gsettings.Language), trial, true)
 		r2.To = strings.Join(userMentions, ", ")
 		r2.ToChannel = signupCid
 	}
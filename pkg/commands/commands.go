@@ -9,18 +9,31 @@ import (
 	"github.com/gsmcwhirter/go-util/v8/parser"
 	"github.com/gsmcwhirter/go-util/v8/telemetry"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/calendar"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/fileupload"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/guildemojis"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/livemessages"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/membersearch"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/permissions"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/stats"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 )
 
 type dependencies interface {
 	Logger() Logger
 	TrialAPI() storage.TrialAPI
 	GuildAPI() storage.GuildAPI
+	MemberAPI() storage.MemberAPI
+	ActivityAPI() storage.ActivityAPI
+	TemplateAPI() storage.TemplateAPI
+	Webhooks() *webhooks.Queue
+	LiveMessages() *livemessages.Updater
 	BotSession() *session.Session
 	Bot() *bot.DiscordBot
 	Census() *telemetry.Census
+	CalendarLinks() *calendar.Links
 }
 
 // Options is the way to specify the command indicator string
@@ -58,6 +71,10 @@ type configDependencies interface {
 	Census() *telemetry.Census
 	StatsHub() *stats.Hub
 	PermissionsManager() *permissions.Manager
+	APITokenAPI() storage.APITokenAPI
+	WebhookAPI() storage.WebhookAPI
+	TemplateAPI() storage.TemplateAPI
+	JobAPI() storage.JobAPI
 }
 
 // ConfigHandler creates a new command handler for !config-su
@@ -121,6 +138,17 @@ type adminDependencies interface {
 	Logger() Logger
 	GuildAPI() storage.GuildAPI
 	TrialAPI() storage.TrialAPI
+	JobAPI() storage.JobAPI
+	AttendanceAPI() storage.AttendanceAPI
+	ActivityAPI() storage.ActivityAPI
+	EventMessageAPI() storage.EventMessageAPI
+	TemplateAPI() storage.TemplateAPI
+	Webhooks() *webhooks.Queue
+	LiveMessages() *livemessages.Updater
+	Uploader() *fileupload.Uploader
+	MemberSearch() *membersearch.Searcher
+	GuildEmojis() *guildemojis.Lister
+	ModalDrafts() *components.Drafts
 	BotSession() *session.Session
 	Bot() *bot.DiscordBot
 	Census() *telemetry.Census
@@ -0,0 +1,171 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+var (
+	ErrUnknownButton       = errors.New("unknown button")
+	ErrUnknownEventMessage = i18n.NewError(i18n.UnknownEventMessage)
+)
+
+var _ components.Handler = (*reactionHandler)(nil)
+
+// NewComponentHandler creates the handler for button clicks on event messages; it shares
+// its dependencies (and event lookup) with the reaction handler
+func NewComponentHandler(deps reactionDependencies) components.Handler {
+	return &reactionHandler{
+		deps: deps,
+	}
+}
+
+func (h *reactionHandler) HandleClick(c components.Click) (cmdhandler.Response, error) {
+	action, arg := components.ParseCustomID(c.CustomID())
+	switch action {
+	case components.ActionSignup:
+		return h.clickSignup(c, arg)
+	case components.ActionWithdraw:
+		return h.clickWithdraw(c)
+	case components.ActionShow:
+		return h.clickShow(c)
+	case components.ActionPage:
+		return h.clickPage(c, arg)
+	case components.ActionCreateForm:
+		return h.clickCreateForm(c, arg)
+	default:
+		return nil, errors.WithDetails(ErrUnknownButton, "custom_id", c.CustomID())
+	}
+}
+
+func (h *reactionHandler) clickShow(c components.Click) (cmdhandler.Response, error) {
+	ctx, span := h.deps.Census().StartSpan(c.Context(), "reactionHandler.clickShow", "guild_id", c.GuildID().ToString())
+	defer span.End()
+
+	logger := components.LoggerWithClick(c, h.deps.Logger())
+	level.Info(logger).Message("handling click", "command", "show")
+
+	trialName, err := h.getTrialNameForMessage(ctx, logger, c)
+	if err != nil {
+		return &cmdhandler.SimpleEmbedResponse{}, ErrUnknownEventMessage
+	}
+
+	return h.rosterPage(ctx, c, trialName, 1)
+}
+
+func (h *reactionHandler) clickPage(c components.Click, arg string) (cmdhandler.Response, error) {
+	ctx, span := h.deps.Census().StartSpan(c.Context(), "reactionHandler.clickPage", "guild_id", c.GuildID().ToString())
+	defer span.End()
+
+	logger := components.LoggerWithClick(c, h.deps.Logger())
+	level.Info(logger).Message("handling click", "command", "page")
+
+	page, trialName, err := parsePageArg(arg)
+	if err != nil {
+		return &cmdhandler.SimpleEmbedResponse{}, errors.WithDetails(ErrUnknownButton, "custom_id", c.CustomID())
+	}
+
+	if trialName == "" {
+		trialName, err = h.getTrialNameForMessage(ctx, logger, c)
+		if err != nil {
+			return &cmdhandler.SimpleEmbedResponse{}, ErrUnknownEventMessage
+		}
+	}
+
+	return h.rosterPage(ctx, c, trialName, page)
+}
+
+// rosterPage is a page of an event roster shown only to the clicker, so it gets page buttons
+// but neither signup buttons nor reactions
+func (h *reactionHandler) rosterPage(ctx context.Context, c components.Click, trialName string, page int) (cmdhandler.Response, error) {
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	gsettings, err := storage.GetSettings(ctx, h.deps.GuildAPI(), c.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, c.GuildID().ToString(), false)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, trialName)
+	if err != nil {
+		return r, err
+	}
+
+	r2 := formatTrialDisplayPage(ctx, i18n.NewPrinter(gsettings.Language), trial, true, page)
+	r2.Reactions = nil
+	r2.buttons = nil
+	r2.SetColor(okColor)
+
+	return r2, nil
+}
+
+// eventButtons are the signup buttons for each role of an event, followed by withdraw and show;
+// reservedRows are left free for other buttons
+func eventButtons(ctx context.Context, p i18n.Printer, trial storage.Trial, reservedRows int) []components.ActionRow {
+	roleCounts := trial.GetRoleCounts(ctx)
+	maxRoles := (components.MaxRows-reservedRows)*components.MaxButtonsPerRow - 2
+
+	buttons := make([]components.Button, 0, len(roleCounts)+2)
+	for _, rc := range roleCounts {
+		if len(buttons) == maxRoles {
+			break
+		}
+
+		customID := components.CustomID(components.ActionSignup, rc.GetRole(ctx))
+		if len(customID) > components.MaxCustomIDLen {
+			continue
+		}
+
+		buttons = append(buttons, components.Button{
+			Type:     components.TypeButton,
+			Style:    components.StylePrimary,
+			Label:    truncateRunes(rc.GetRole(ctx), components.MaxLabelLen),
+			CustomID: customID,
+			Emoji:    components.ParseEmoji(rc.GetEmoji(ctx)),
+		})
+	}
+
+	buttons = append(buttons,
+		components.Button{
+			Type:     components.TypeButton,
+			Style:    components.StyleDanger,
+			Label:    p.Sprintf(i18n.ButtonWithdraw),
+			CustomID: components.CustomID(components.ActionWithdraw, ""),
+		},
+		components.Button{
+			Type:     components.TypeButton,
+			Style:    components.StyleSecondary,
+			Label:    p.Sprintf(i18n.ButtonShow),
+			CustomID: components.CustomID(components.ActionShow, ""),
+		},
+	)
+
+	return components.Rows(buttons)
+}
+
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+
+	return string(r[:n])
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
+)
+
+func (h *reactionHandler) clickSignup(c components.Click, role string) (cmdhandler.Response, error) {
+	ctx, span := h.deps.Census().StartSpan(c.Context(), "reactionHandler.clickSignup", "guild_id", c.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := components.LoggerWithClick(c, h.deps.Logger())
+	level.Info(logger).Message("handling click", "command", "signup", "role", role)
+
+	trialName, err := h.getTrialNameForMessage(ctx, logger, c)
+	if err != nil {
+		return r, ErrUnknownEventMessage
+	}
+	level.Info(logger).Message("click event identified", "trial_name", trialName)
+
+	gsettings, err := storage.GetSettings(ctx, h.deps.GuildAPI(), c.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, c.GuildID().ToString(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, trialName)
+	if err != nil {
+		return r, err
+	}
+
+	// buttons only exist on the bot's own event messages, so unlike reactions and
+	// commands there is no signup channel to check
+
+	if trial.GetState(ctx) != storage.TrialStateOpen {
+		return r, ErrSignupClosed
+	}
+
+	userMention := cmdhandler.UserMentionString(c.UserID())
+
+	if err = checkSignupLimit(ctx, t, gsettings, trial, userMention); err != nil {
+		return r, err
+	}
+
+	_, overflow, err := signupUser(ctx, trial, userMention, role, roleAliases(gsettings), guildMemberRoles(h.deps.Bot(), c.GuildID(), c.UserID()), true)
+	if err != nil {
+		return r, err
+	}
+
+	if err = t.SaveTrial(ctx, trial); err != nil {
+		return r, errors.Wrap(err, "could not save event signup")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return r, errors.Wrap(err, "could not save event signup")
+	}
+
+	level.Info(logger).Message("signed up", "overflow", overflow, "role", role, "trial_name", trialName)
+
+	recordActivity(ctx, logger, h.deps.ActivityAPI(), c.GuildID(), trial, userMention, role, storage.ActivitySignup, false)
+	h.deps.Webhooks().Notify(webhookEvent(ctx, c.GuildID(), webhooks.KindSignup, trial, userMention, role))
+	h.deps.LiveMessages().Refresh(c.GuildID(), trial.GetName(ctx))
+
+	r.Description = signupConfirmation(ctx, logger, h.deps.TemplateAPI(), h.deps.BotSession(), c.GuildID(), gsettings, trial, role, overflow)
+	r.SetColor(okColor)
+
+	return r, nil
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
+)
+
+func (h *reactionHandler) clickWithdraw(c components.Click) (cmdhandler.Response, error) {
+	ctx, span := h.deps.Census().StartSpan(c.Context(), "reactionHandler.clickWithdraw", "guild_id", c.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := components.LoggerWithClick(c, h.deps.Logger())
+	level.Info(logger).Message("handling click", "command", "withdraw")
+
+	trialName, err := h.getTrialNameForMessage(ctx, logger, c)
+	if err != nil {
+		return r, ErrUnknownEventMessage
+	}
+	level.Info(logger).Message("click event identified", "trial_name", trialName)
+
+	gsettings, err := storage.GetSettings(ctx, h.deps.GuildAPI(), c.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, c.GuildID().ToString(), true)
+	if err != nil {
+		return r, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, trialName)
+	if err != nil {
+		return r, err
+	}
+
+	if trial.GetState(ctx) != storage.TrialStateOpen {
+		return r, ErrWithdrawClosed
+	}
+
+	userMention := cmdhandler.UserMentionString(c.UserID())
+
+	role, signedUp := signupRole(ctx, trial, userMention)
+	if !signedUp {
+		r.Description = i18n.Sprintf(gsettings.Language, i18n.NotSignedUp, trial.GetName(ctx))
+		r.SetColor(okColor)
+		return r, nil
+	}
+
+	trial.RemoveSignup(ctx, userMention)
+
+	if err = t.SaveTrial(ctx, trial); err != nil {
+		return r, errors.Wrap(err, "could not save event withdraw")
+	}
+
+	if err = t.Commit(ctx); err != nil {
+		return r, errors.Wrap(err, "could not save event withdraw")
+	}
+
+	level.Info(logger).Message("withdrew", "trial_name", trialName)
+
+	recordActivity(ctx, logger, h.deps.ActivityAPI(), c.GuildID(), trial, userMention, role, storage.ActivityWithdraw, withdrawIsLate(ctx, trial, time.Now()))
+	h.deps.Webhooks().Notify(webhookEvent(ctx, c.GuildID(), webhooks.KindWithdraw, trial, userMention, role))
+	h.deps.LiveMessages().Refresh(c.GuildID(), trial.GetName(ctx))
+
+	r.Description = i18n.Sprintf(gsettings.Language, i18n.Withdrew, trial.GetName(ctx))
+	r.SetColor(okColor)
+
+	return r, nil
+}
@@ -77,6 +77,80 @@ func (c *ConfigCommands) HandleInteraction(ix *cmdhandler.Interaction) (cmdhandl
 		default:
 			return nil, nil, parser.ErrUnknownCommand
 		}
+	case "apitoken":
+		var atsc string
+		var atopts []entity.ApplicationCommandInteractionOption
+
+		for i := range opts {
+			if opts[i].Type != entity.OptTypeSubCommand {
+				continue
+			}
+
+			atsc = opts[i].Name
+			atopts = opts[i].Options
+			break
+		}
+
+		switch atsc {
+		case "create":
+			return c.apitokenCreateInteraction(ix, atopts)
+		case "list":
+			return c.apitokenListInteraction(ix, atopts)
+		case "revoke":
+			return c.apitokenRevokeInteraction(ix, atopts)
+		default:
+			return nil, nil, parser.ErrUnknownCommand
+		}
+	case "webhook":
+		var whsc string
+		var whopts []entity.ApplicationCommandInteractionOption
+
+		for i := range opts {
+			if opts[i].Type != entity.OptTypeSubCommand {
+				continue
+			}
+
+			whsc = opts[i].Name
+			whopts = opts[i].Options
+			break
+		}
+
+		switch whsc {
+		case "add":
+			return c.webhookAddInteraction(ix, whopts)
+		case "list":
+			return c.webhookListInteraction(ix, whopts)
+		case "remove":
+			return c.webhookRemoveInteraction(ix, whopts)
+		case "secret":
+			return c.webhookSecretInteraction(ix, whopts)
+		default:
+			return nil, nil, parser.ErrUnknownCommand
+		}
+	case "template":
+		var tsc string
+		var topts []entity.ApplicationCommandInteractionOption
+
+		for i := range opts {
+			if opts[i].Type != entity.OptTypeSubCommand {
+				continue
+			}
+
+			tsc = opts[i].Name
+			topts = opts[i].Options
+			break
+		}
+
+		switch tsc {
+		case "set":
+			return c.templateSetInteraction(ix, topts)
+		case "reset":
+			return c.templateResetInteraction(ix, topts)
+		case "preview":
+			return c.templatePreviewInteraction(ix, topts)
+		default:
+			return nil, nil, parser.ErrUnknownCommand
+		}
 	default:
 		return nil, nil, parser.ErrUnknownCommand
 	}
@@ -146,6 +220,12 @@ func (c *ConfigCommands) GuildCommands(gid snowflake.Snowflake) ([]cmdhandler.In
 		"adminrole",
 		"messagecolor",
 		"errorcolor",
+		"signuplimit",
+		"signuplimitpercategory",
+		"reminderoffsets",
+		"reminderdelivery",
+		"language",
+		"rolealiases",
 	}
 
 	settingOptions := make([]entity.ApplicationCommandOptionChoice, 0, len(settings))
@@ -266,6 +346,65 @@ func (c *ConfigCommands) GuildCommands(gid snowflake.Snowflake) ([]cmdhandler.In
 								Name:        "errorcolor",
 								Description: "Color code for error messages",
 							},
+							{
+								Type:        entity.OptTypeString,
+								Name:        "signuplimit",
+								Description: "Max open events a user may be on the main roster of at once (0 for no limit)",
+							},
+							{
+								Type:        entity.OptTypeBoolean,
+								Name:        "signuplimitpercategory",
+								Description: "Whether or not the signup limit applies separately per event category",
+							},
+							{
+								Type:        entity.OptTypeString,
+								Name:        "reminderoffsets",
+								Description: "Default reminder times before an event (e.g., 1d,30m), or blank for none",
+							},
+							{
+								Type:        entity.OptTypeString,
+								Name:        "reminderdelivery",
+								Description: "How reminders are sent: 'channel' (mention in the signup channel) or 'dm'",
+								Choices: []entity.ApplicationCommandOptionChoice{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "channel",
+										ValueString: "channel",
+									},
+									{
+										Type:        entity.OptTypeString,
+										Name:        "dm",
+										ValueString: "dm",
+									},
+								},
+							},
+							{
+								Type:        entity.OptTypeString,
+								Name:        "language",
+								Description: "The language the bot uses for messages in this server",
+								Choices: []entity.ApplicationCommandOptionChoice{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "English",
+										ValueString: "en",
+									},
+									{
+										Type:        entity.OptTypeString,
+										Name:        "Deutsch",
+										ValueString: "de",
+									},
+									{
+										Type:        entity.OptTypeString,
+										Name:        "Français",
+										ValueString: "fr",
+									},
+								},
+							},
+							{
+								Type:        entity.OptTypeString,
+								Name:        "rolealiases",
+								Description: "Other names for roles in all events (e.g., dd=dps, damage=dps), or blank for none",
+							},
 						},
 					},
 					{
@@ -316,6 +455,162 @@ func (c *ConfigCommands) GuildCommands(gid snowflake.Snowflake) ([]cmdhandler.In
 							},
 						},
 					},
+					{
+						Type:        entity.OptTypeSubCommandGroup,
+						Name:        "apitoken",
+						Description: "Manage tokens for the read-only JSON API",
+						Options: []entity.ApplicationCommandOption{
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "list",
+								Description: "List all api tokens",
+							},
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "create",
+								Description: "Create an api token (it is only shown once)",
+								Options: []entity.ApplicationCommandOption{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "label",
+										Description: "What the token is for",
+										Required:    true,
+									},
+								},
+							},
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "revoke",
+								Description: "Revoke an api token",
+								Options: []entity.ApplicationCommandOption{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "id",
+										Description: "The id of the token, from the list",
+										Required:    true,
+									},
+								},
+							},
+						},
+					},
+					{
+						Type:        entity.OptTypeSubCommandGroup,
+						Name:        "webhook",
+						Description: "Manage webhooks notified of roster changes",
+						Options: []entity.ApplicationCommandOption{
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "list",
+								Description: "List all webhooks",
+							},
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "add",
+								Description: "Add a webhook url",
+								Options: []entity.ApplicationCommandOption{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "url",
+										Description: "The https url to POST roster changes to",
+										Required:    true,
+									},
+								},
+							},
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "remove",
+								Description: "Remove a webhook",
+								Options: []entity.ApplicationCommandOption{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "id",
+										Description: "The id of the webhook, from the list",
+										Required:    true,
+									},
+								},
+							},
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "secret",
+								Description: "Replace the secret webhook payloads are signed with",
+							},
+						},
+					},
+					{
+						Type:        entity.OptTypeSubCommandGroup,
+						Name:        "template",
+						Description: "Manage the templates for announcement, grouping and signup messages",
+						Options: []entity.ApplicationCommandOption{
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "set",
+								Description: "Set a message template for the server, or for one event",
+								Options: []entity.ApplicationCommandOption{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "kind",
+										Description: "The kind of message",
+										Required:    true,
+										Choices:     templateKindChoices(),
+									},
+									{
+										Type:        entity.OptTypeString,
+										Name:        "template",
+										Description: "The template, e.g. {{.Name}} starts {{.Time}}! (\\n for a line break)",
+										Required:    true,
+									},
+									{
+										Type:        entity.OptTypeString,
+										Name:        "event_name",
+										Description: "The event to set the template for (omit for the whole server)",
+									},
+								},
+							},
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "reset",
+								Description: "Go back to the default template (or the server's, for an event)",
+								Options: []entity.ApplicationCommandOption{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "kind",
+										Description: "The kind of message",
+										Required:    true,
+										Choices:     templateKindChoices(),
+									},
+									{
+										Type:        entity.OptTypeString,
+										Name:        "event_name",
+										Description: "The event to reset the template for (omit for the whole server)",
+									},
+								},
+							},
+							{
+								Type:        entity.OptTypeSubCommand,
+								Name:        "preview",
+								Description: "Show what a message would look like",
+								Options: []entity.ApplicationCommandOption{
+									{
+										Type:        entity.OptTypeString,
+										Name:        "kind",
+										Description: "The kind of message",
+										Required:    true,
+										Choices:     templateKindChoices(),
+									},
+									{
+										Type:        entity.OptTypeString,
+										Name:        "event_name",
+										Description: "The event to preview with (omit for example data)",
+									},
+									{
+										Type:        entity.OptTypeString,
+										Name:        "template",
+										Description: "A template to try out (omit for the one in effect)",
+									},
+								},
+							},
+						},
+					},
 				},
 				DefaultPermission: false,
 			},
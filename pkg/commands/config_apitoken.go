@@ -0,0 +1,196 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// apitokenCreateInteraction creates a new api token; the token is only ever shown here, and only to
+// the person who created it
+func (c *ConfigCommands) apitokenCreateInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.apitokenCreateInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+	r.SetEphemeral(true)
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "apitoken create")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var label string
+	for i := range opts {
+		if opts[i].Name == "label" {
+			label = strings.TrimSpace(opts[i].ValueString)
+		}
+	}
+
+	if label == "" {
+		return r, nil, errors.New("the token needs a label")
+	}
+
+	token, err := storage.NewAPIToken()
+	if err != nil {
+		return r, nil, err
+	}
+
+	id, err := c.deps.APITokenAPI().AddToken(ctx, ix.GuildID().ToString(), token, label, cmdhandler.UserMentionString(ix.UserID()))
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not save api token")
+	}
+
+	level.Info(logger).Message("api token created", "token_id", id, "label", label)
+
+	r.Title = "API Token Created"
+	r.Description = fmt.Sprintf("Token %d (%s):\n\n`%s`\n\nThis is the only time the token will be shown. Send it as `Authorization: Bearer TOKEN` to `/api/v1/guilds/%s/...`.", id, label, token, ix.GuildID().ToString())
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *ConfigCommands) apitokenListInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.apitokenListInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "apitoken list")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	tokens, err := c.deps.APITokenAPI().ListTokens(ctx, ix.GuildID().ToString())
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not list api tokens")
+	}
+
+	lines := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		lastUsed := "never used"
+		if !tok.LastUsedAt.IsZero() {
+			lastUsed = fmt.Sprintf("last used <t:%d:R>", tok.LastUsedAt.Unix())
+		}
+
+		lines = append(lines, fmt.Sprintf("%d. %s (created by %s <t:%d:d>, %s)", tok.ID, tok.Label, tok.CreatedBy, tok.CreatedAt.Unix(), lastUsed))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "(no api tokens)")
+	}
+
+	r.Title = "API Tokens"
+	r.Description = strings.Join(lines, "\n")
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *ConfigCommands) apitokenRevokeInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.apitokenRevokeInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "apitoken revoke")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var idStr string
+	for i := range opts {
+		if opts[i].Name == "id" {
+			idStr = strings.TrimSpace(opts[i].ValueString)
+		}
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return r, nil, errors.WithDetails(errors.New("token id must be a number"), "id", idStr)
+	}
+
+	err = c.deps.APITokenAPI().RevokeToken(ctx, ix.GuildID().ToString(), id)
+	if err == storage.ErrTokenNotExist {
+		return r, nil, errors.New("no api token with that id")
+	}
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not revoke api token")
+	}
+
+	level.Info(logger).Message("api token revoked", "token_id", id)
+
+	r.Description = fmt.Sprintf("API token %d revoked.", id)
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
@@ -131,6 +131,12 @@ GuildSettings:
 	- ShowAfterWithdraw: '%[8]s',
 	- MessageColor: '%[16]s',
 	- ErrorColor: '%[17]s',
+	- SignupLimit: '%[18]s',
+	- SignupLimitPerCategory: '%[19]s',
+	- ReminderOffsets: '%[20]s',
+	- ReminderDelivery: '%[21]s',
+	- Language: '%[22]s',
+	- RoleAliases: '%[23]s',
 	
 	- AnnounceChannel: '#%[3]s',
 	- AnnounceChannel ID: %[11]s,
@@ -162,6 +168,12 @@ GuildSettings:
 		gsettings.AdminRoles,
 		gsettings.MessageColor,
 		gsettings.ErrorColor,
+		gsettings.SignupLimit,
+		gsettings.SignupLimitPerCategory,
+		gsettings.ReminderOffsets,
+		gsettings.ReminderDelivery,
+		gsettings.Language,
+		gsettings.RoleAliases,
 	)
 
 	r.Description = dbgString
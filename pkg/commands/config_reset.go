@@ -77,6 +77,8 @@ func (c *ConfigCommands) reset(ctx context.Context, gid snowflake.Snowflake) err
 		return errors.Wrap(err, "unable to find or add guild")
 	}
 
+	oldOffsets := bGuild.GetSettings(ctx).ReminderOffsets
+
 	s := storage.GuildSettings{}
 	bGuild.SetSettings(ctx, s)
 
@@ -90,5 +92,11 @@ func (c *ConfigCommands) reset(ctx context.Context, gid snowflake.Snowflake) err
 		return errors.Wrap(err, "could not save guild settings")
 	}
 
+	if oldOffsets != "" {
+		if err := rescheduleDefaultReminders(ctx, c.deps.TrialAPI(), c.deps.JobAPI(), gid, s); err != nil {
+			return errors.Wrap(err, "could not reschedule reminders")
+		}
+	}
+
 	return errors.Wrap(c.deps.PermissionsManager().RefreshPermissions(ctx, c.deps.Bot().Config().ClientID, gid), "could not refresh command permissions")
 }
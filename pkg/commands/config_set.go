@@ -107,6 +107,22 @@ func (c *ConfigCommands) setInteraction(ix *cmdhandler.Interaction, opts []entit
 			ap.val = opts[i].ValueString
 		case "errorcolor":
 			ap.val = opts[i].ValueString
+		case "signuplimit":
+			ap.val = opts[i].ValueString
+		case "reminderoffsets":
+			ap.val = opts[i].ValueString
+		case "reminderdelivery":
+			ap.val = opts[i].ValueString
+		case "language":
+			ap.val = opts[i].ValueString
+		case "rolealiases":
+			ap.val = opts[i].ValueString
+		case "signuplimitpercategory":
+			if opts[i].ValueBool {
+				ap.val = "true"
+			} else {
+				ap.val = "false"
+			}
 		default:
 			return r, nil, errors.WithDetails(errors.New("unknown setting"), "setting_name", name)
 		}
@@ -232,6 +248,7 @@ func (c *ConfigCommands) setSettings(ctx context.Context, gid snowflake.Snowflak
 	}
 
 	s := bGuild.GetSettings(ctx)
+	oldOffsets := s.ReminderOffsets
 	for _, ap := range aps {
 		err = s.SetSettingString(ctx, ap.key, ap.val)
 		if err != nil {
@@ -245,6 +262,14 @@ func (c *ConfigCommands) setSettings(ctx context.Context, gid snowflake.Snowflak
 		return errors.Wrap(err, "could not save guild settings")
 	}
 
-	err = t.Commit(ctx)
-	return errors.Wrap(err, "could not save guild settings")
+	if err = t.Commit(ctx); err != nil {
+		return errors.Wrap(err, "could not save guild settings")
+	}
+
+	if s.ReminderOffsets == oldOffsets {
+		return nil
+	}
+
+	err = rescheduleDefaultReminders(ctx, c.deps.TrialAPI(), c.deps.JobAPI(), gid, s)
+	return errors.Wrap(err, "could not reschedule reminders")
 }
@@ -0,0 +1,281 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/templates"
+)
+
+type templateArgs struct {
+	kind      string
+	text      string
+	eventName string
+}
+
+// templateArgsFromOptions reads the template subcommand options; since slash command options
+// cannot contain line breaks, a literal `\n` in the template becomes one
+func templateArgsFromOptions(opts []entity.ApplicationCommandInteractionOption) templateArgs {
+	var args templateArgs
+	for i := range opts {
+		switch opts[i].Name {
+		case "kind":
+			args.kind = opts[i].ValueString
+		case "template":
+			args.text = strings.ReplaceAll(opts[i].ValueString, `\n`, "\n")
+		case "event_name":
+			args.eventName = strings.TrimSpace(opts[i].ValueString)
+		}
+	}
+
+	return args
+}
+
+func templateKindChoices() []entity.ApplicationCommandOptionChoice {
+	kinds := templates.Kinds()
+	choices := make([]entity.ApplicationCommandOptionChoice, 0, len(kinds))
+	for _, k := range kinds {
+		choices = append(choices, entity.ApplicationCommandOptionChoice{
+			Type:        entity.OptTypeString,
+			Name:        k,
+			ValueString: k,
+		})
+	}
+
+	return choices
+}
+
+// setEventTemplate sets (or, for an empty template, removes) an event's own template
+func (c *ConfigCommands) setEventTemplate(ctx context.Context, gid snowflake.Snowflake, eventName, kind, text string) error {
+	t, err := c.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), true)
+	if err != nil {
+		return err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, eventName)
+	if err != nil {
+		return err
+	}
+
+	trial.SetTemplate(ctx, kind, text)
+
+	if err = t.SaveTrial(ctx, trial); err != nil {
+		return errors.Wrap(err, "could not save event template")
+	}
+
+	return errors.Wrap(t.Commit(ctx), "could not save event template")
+}
+
+func (c *ConfigCommands) templateSetInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.templateSetInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "template set")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	args := templateArgsFromOptions(opts)
+	if strings.TrimSpace(args.text) == "" {
+		return r, nil, errors.New("template is required (use reset to remove one)")
+	}
+
+	if err = templates.Validate(args.kind, args.text); err != nil {
+		return r, nil, errors.Wrap(err, "invalid template")
+	}
+
+	if args.eventName != "" {
+		err = c.setEventTemplate(ctx, ix.GuildID(), args.eventName, args.kind, args.text)
+	} else {
+		err = c.deps.TemplateAPI().SetGuildTemplate(ctx, ix.GuildID().ToString(), args.kind, args.text)
+	}
+	if err != nil {
+		return r, nil, err
+	}
+
+	level.Info(logger).Message("template set", "kind", args.kind, "trial_name", args.eventName)
+
+	r.Description = fmt.Sprintf("The %s template was set", args.kind)
+	if args.eventName != "" {
+		r.Description += fmt.Sprintf(" for %s", args.eventName)
+	}
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *ConfigCommands) templateResetInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.templateResetInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "template reset")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	args := templateArgsFromOptions(opts)
+	if _, err = templates.Default(args.kind); err != nil {
+		return r, nil, err
+	}
+
+	if args.eventName != "" {
+		err = c.setEventTemplate(ctx, ix.GuildID(), args.eventName, args.kind, "")
+	} else {
+		err = c.deps.TemplateAPI().RemoveGuildTemplate(ctx, ix.GuildID().ToString(), args.kind)
+	}
+	if err != nil {
+		return r, nil, err
+	}
+
+	level.Info(logger).Message("template reset", "kind", args.kind, "trial_name", args.eventName)
+
+	if args.eventName != "" {
+		r.Description = fmt.Sprintf("%s now uses the server's %s template", args.eventName, args.kind)
+	} else {
+		r.Description = fmt.Sprintf("The %s template was reset to the default", args.kind)
+	}
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+// templatePreviewInteraction renders a template (the given one, or else the one in effect) with
+// an event's data, or with example data if no event is named
+func (c *ConfigCommands) templatePreviewInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.templatePreviewInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+	r.SetEphemeral(true)
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "template preview")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	args := templateArgsFromOptions(opts)
+	if _, err = templates.Default(args.kind); err != nil {
+		return r, nil, err
+	}
+
+	var trial storage.Trial
+	data := templates.Example(args.kind)
+
+	if args.eventName != "" {
+		t, err := c.deps.TrialAPI().NewTransaction(ctx, ix.GuildID().ToString(), false)
+		if err != nil {
+			return r, nil, err
+		}
+		defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+		if trial, err = t.GetTrial(ctx, args.eventName); err != nil {
+			return r, nil, err
+		}
+
+		data = eventTemplateData(ctx, c.deps.BotSession(), ix.GuildID(), trial)
+		if args.kind == templates.KindSignup && len(data.Roles) > 0 {
+			data.Role = data.Roles[0].Name
+		}
+	}
+
+	text := args.text
+	if text == "" {
+		if text, err = messageTemplate(ctx, c.deps.TemplateAPI(), ix.GuildID(), trial, args.kind); err != nil {
+			return r, nil, err
+		}
+	}
+
+	r.Title = fmt.Sprintf("Preview of the %s template", args.kind)
+
+	if text == "" {
+		r.Description = "There is no template, so the built-in message is used."
+		r.SetColor(okColor)
+		return r, nil, nil
+	}
+
+	msg, err := templates.Render(text, data)
+	if err != nil {
+		return r, nil, errors.Wrap(err, "invalid template")
+	}
+
+	r.Description = fmt.Sprintf("Template:\n```\n%s\n```\nRenders as:\n\n%s", text, msg)
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
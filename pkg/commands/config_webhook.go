@@ -0,0 +1,273 @@
+package commands
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
+)
+
+func parseWebhookURL(val string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(val))
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "", errors.Wrap(webhooks.ErrInsecureURL, "invalid webhook url")
+	}
+
+	return u.String(), nil
+}
+
+func webhookSecretMessage(secret string) string {
+	return fmt.Sprintf("Payloads are signed with this secret:\n\n`%s`\n\nThe `%s` header is `sha256=` followed by the hex HMAC-SHA256 of the request body. This is the only time the secret will be shown.", secret, webhooks.SignatureHeader)
+}
+
+// webhookAddInteraction adds a webhook url; the first webhook for a guild also creates the signing secret,
+// which is shown only to the person who added it
+func (c *ConfigCommands) webhookAddInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.webhookAddInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+	r.SetEphemeral(true)
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "webhook add")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var hookURL string
+	for i := range opts {
+		if opts[i].Name == "url" {
+			hookURL = opts[i].ValueString
+		}
+	}
+
+	hookURL, err = parseWebhookURL(hookURL)
+	if err != nil {
+		return r, nil, err
+	}
+
+	secret, err := c.deps.WebhookAPI().WebhookSecret(ctx, ix.GuildID().ToString())
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not get webhook secret")
+	}
+
+	newSecret := secret == ""
+	if newSecret {
+		if secret, err = storage.NewWebhookSecret(); err != nil {
+			return r, nil, err
+		}
+
+		if err = c.deps.WebhookAPI().SetWebhookSecret(ctx, ix.GuildID().ToString(), secret); err != nil {
+			return r, nil, errors.Wrap(err, "could not save webhook secret")
+		}
+	}
+
+	id, err := c.deps.WebhookAPI().AddWebhook(ctx, ix.GuildID().ToString(), hookURL, cmdhandler.UserMentionString(ix.UserID()))
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not save webhook")
+	}
+
+	level.Info(logger).Message("webhook added", "webhook_id", id)
+
+	r.Title = "Webhook Added"
+	r.Description = fmt.Sprintf("Webhook %d added.", id)
+	if newSecret {
+		r.Description += "\n\n" + webhookSecretMessage(secret)
+	}
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *ConfigCommands) webhookListInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.webhookListInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+	r.SetEphemeral(true)
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "webhook list")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	hooks, err := c.deps.WebhookAPI().ListWebhooks(ctx, ix.GuildID().ToString())
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not list webhooks")
+	}
+
+	lines := make([]string, 0, len(hooks))
+	for _, h := range hooks {
+		lines = append(lines, fmt.Sprintf("%d. %s (added by %s <t:%d:d>)", h.ID, h.URL, h.CreatedBy, h.CreatedAt.Unix()))
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, "(no webhooks)")
+	}
+
+	r.Title = "Webhooks"
+	r.Description = strings.Join(lines, "\n")
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *ConfigCommands) webhookRemoveInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.webhookRemoveInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "webhook remove")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	var idStr string
+	for i := range opts {
+		if opts[i].Name == "id" {
+			idStr = strings.TrimSpace(opts[i].ValueString)
+		}
+	}
+
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return r, nil, errors.WithDetails(errors.New("webhook id must be a number"), "id", idStr)
+	}
+
+	err = c.deps.WebhookAPI().RemoveWebhook(ctx, ix.GuildID().ToString(), id)
+	if err == storage.ErrWebhookNotExist {
+		return r, nil, errors.New("no webhook with that id")
+	}
+	if err != nil {
+		return r, nil, errors.Wrap(err, "could not remove webhook")
+	}
+
+	level.Info(logger).Message("webhook removed", "webhook_id", id)
+
+	r.Description = fmt.Sprintf("Webhook %d removed.", id)
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+// webhookSecretInteraction replaces the signing secret; it takes effect for the next delivery
+func (c *ConfigCommands) webhookSecretInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "configCommands.webhookSecretInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+	r.SetEphemeral(true)
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling config interaction", "command", "webhook secret")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+
+	if !isAdminChannel(logger, ix, gsettings.AdminChannel, c.deps.BotSession()) {
+		level.Info(logger).Message("command not in admin channel", "admin_channel", gsettings.AdminChannel)
+		return r, nil, msghandler.ErrUnauthorized
+	}
+
+	secret, err := storage.NewWebhookSecret()
+	if err != nil {
+		return r, nil, err
+	}
+
+	if err = c.deps.WebhookAPI().SetWebhookSecret(ctx, ix.GuildID().ToString(), secret); err != nil {
+		return r, nil, errors.Wrap(err, "could not save webhook secret")
+	}
+
+	level.Info(logger).Message("webhook secret rotated")
+
+	r.Title = "Webhook Secret Rotated"
+	r.Description = webhookSecretMessage(secret)
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/guildemojis"
+)
+
+// normalizeEmoji converts a custom emoji to the <:name:id> (or <a:name:id>) form used in messages;
+// unicode emoji are only trimmed
+func normalizeEmoji(s string) string {
+	if e, ok := guildemojis.ParseCustom(s); ok {
+		return e.String()
+	}
+
+	return strings.TrimSpace(s)
+}
+
+// emojiMatches reports whether a reaction emoji (a unicode emoji, a custom emoji name, or a
+// custom emoji in any form) is the emoji configured for a role
+func emojiMatches(roleEmoji, reacted string) bool {
+	roleEmoji, reacted = normalizeEmoji(roleEmoji), normalizeEmoji(reacted)
+	if roleEmoji == "" || reacted == "" {
+		return false
+	}
+
+	if roleEmoji == reacted {
+		return true
+	}
+
+	re, ok := guildemojis.ParseCustom(roleEmoji)
+	if !ok {
+		return false
+	}
+
+	if e, ok := guildemojis.ParseCustom(reacted); ok {
+		return e.ID == re.ID
+	}
+
+	return reacted == re.Name
+}
+
+// emojiUnavailable reports whether emo is a custom emoji that is not one of the guild's own
+// (it was deleted, or it belongs to another server), so the bot cannot react with it
+func emojiUnavailable(hasEmoji func(snowflake.Snowflake) bool, emo string) bool {
+	e, ok := guildemojis.ParseCustom(emo)
+	if !ok {
+		return false
+	}
+
+	id, err := snowflake.FromString(e.ID)
+	if err != nil {
+		return true
+	}
+
+	return !hasEmoji(id)
+}
+
+// checkRoleEmojis makes sure the bot will be able to react with every role emoji; the guild's emoji
+// are only looked up if a role has a custom emoji
+func checkRoleEmojis(ctx context.Context, lister *guildemojis.Lister, gid snowflake.Snowflake, roles []roleCtEmo) error {
+	var custom []roleCtEmo
+	for _, rce := range roles {
+		if _, ok := guildemojis.ParseCustom(rce.emo); ok && (rce.ct != 0 || rce.noMax) {
+			custom = append(custom, rce)
+		}
+	}
+
+	if len(custom) == 0 {
+		return nil
+	}
+
+	ids, err := lister.Emojis(ctx, gid)
+	if err != nil {
+		return errors.Wrap(err, "could not check role emojis")
+	}
+
+	for _, rce := range custom {
+		if emojiUnavailable(func(id snowflake.Snowflake) bool { return ids[id] }, rce.emo) {
+			return fmt.Errorf("emoji %s for role '%s' is not a custom emoji of this server", rce.emo, rce.role)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/guildemojis"
+)
+
+func Test_normalizeEmoji(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"", ""},
+		{" 🛡️ ", "🛡️"},
+		{"<:shield:123>", "<:shield:123>"},
+		{"< :shield: 123 >", "<:shield:123>"},
+		{"<a:dance:456>", "<a:dance:456>"},
+		{"shield:123", "<:shield:123>"},
+		{":shield:123", "<:shield:123>"},
+		{":shield:", ":shield:"},
+	} {
+		if got := normalizeEmoji(tt.in); got != tt.want {
+			t.Errorf("normalizeEmoji(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func Test_emojiMatches(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		roleEmoji, reacted string
+		want               bool
+	}{
+		{"🛡️", "🛡️", true},
+		{"🛡️", "💚", false},
+		{"", "", false},
+		{"<:shield:123>", "shield", true},
+		{"<:shield:123>", "shield:123", true},
+		{"<:shield:123>", "<:shield:123>", true},
+		{"<a:dance:456>", "dance", true},
+		{"<a:dance:456>", "<a:dance:456>", true},
+		{"<a:dance:456>", "dance:456", true},
+		{"<:shield:123>", "shield:999", false},
+		{"<:shield:123>", "sword", false},
+	} {
+		if got := emojiMatches(tt.roleEmoji, tt.reacted); got != tt.want {
+			t.Errorf("emojiMatches(%q, %q) = %v, want %v", tt.roleEmoji, tt.reacted, got, tt.want)
+		}
+	}
+}
+
+func Test_emojiUnavailable(t *testing.T) {
+	t.Parallel()
+
+	hasEmoji := func(id snowflake.Snowflake) bool {
+		return id.ToString() == "123"
+	}
+
+	for _, tt := range []struct {
+		emo  string
+		want bool
+	}{
+		{"", false},
+		{"🛡️", false},
+		{"<:shield:123>", false},
+		{"<a:dance:456>", true},
+	} {
+		if got := emojiUnavailable(hasEmoji, tt.emo); got != tt.want {
+			t.Errorf("emojiUnavailable(%q) = %v, want %v", tt.emo, got, tt.want)
+		}
+	}
+}
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) { return f(r) }
+
+func Test_checkRoleEmojis(t *testing.T) {
+	t.Parallel()
+
+	lookups := 0
+	doer := doerFunc(func(r *http.Request) (*http.Response, error) {
+		lookups++
+		body := `[{"id": "123", "name": "shield"}, {"id": "456", "name": "dance", "animated": true}]`
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+	})
+	lister := guildemojis.NewLister(discordrest.NewClient(doer, "https://discord.test/api", http.Header{}, nil, discordrest.Options{}))
+
+	for _, tt := range []struct {
+		name        string
+		roles       []roleCtEmo
+		wantErr     bool
+		wantLookups int
+	}{
+		{name: "unicode only", roles: []roleCtEmo{{role: "Tank", ct: 1, emo: "🛡️"}}},
+		{name: "guild emoji", roles: []roleCtEmo{{role: "Tank", ct: 1, emo: "<:shield:123>"}, {role: "Dps", ct: 1, emo: "<a:dance:456>"}}, wantLookups: 1},
+		{name: "foreign emoji", roles: []roleCtEmo{{role: "Tank", ct: 1, emo: "<:shield:999>"}}, wantErr: true, wantLookups: 1},
+		{name: "removed role", roles: []roleCtEmo{{role: "Tank", emo: "<:shield:999>"}}},
+	} {
+		lookups = 0
+		err := checkRoleEmojis(context.Background(), lister, 1, tt.roles)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: checkRoleEmojis() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+
+		if lookups != tt.wantLookups {
+			t.Errorf("%s: looked up the guild emoji %d times, want %d", tt.name, lookups, tt.wantLookups)
+		}
+	}
+}
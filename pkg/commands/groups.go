@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// maxGroups keeps the number of group embeds sent in reply to a single command reasonable
+const maxGroups = 10
+
+var ErrBadQuotas = errors.New("could not parse group quotas")
+
+// roleRoster is the main roster and overflow for one role of an event, in signup order
+type roleRoster struct {
+	role     string
+	main     []string
+	overflow []string
+}
+
+type groupMember struct {
+	name     string
+	role     string
+	overflow bool
+}
+
+// defaultGroupQuotas splits the group size between the roles in proportion to the role counts of
+// the event (largest remainders get the leftover slots, ties going to the earlier role)
+func defaultGroupQuotas(roles []roleRoster, counts map[string]int, size int) map[string]int {
+	total := 0
+	for _, rr := range roles {
+		total += counts[rr.role]
+	}
+
+	quotas := map[string]int{}
+	if total == 0 {
+		return quotas
+	}
+
+	type remainder struct {
+		role string
+		rem  int
+	}
+
+	rems := make([]remainder, 0, len(roles))
+	assigned := 0
+	for _, rr := range roles {
+		share := counts[rr.role] * size
+		quotas[strings.ToLower(rr.role)] = share / total
+		assigned += share / total
+		rems = append(rems, remainder{role: strings.ToLower(rr.role), rem: share % total})
+	}
+
+	sort.SliceStable(rems, func(i, j int) bool {
+		return rems[i].rem > rems[j].rem
+	})
+
+	for i := 0; assigned < size && i < len(rems); i++ {
+		if rems[i].rem == 0 {
+			break
+		}
+		quotas[rems[i].role]++
+		assigned++
+	}
+
+	return quotas
+}
+
+// parseGroupQuotas parses a list of ROLE:COUNT pairs, such as "Tank:2, Healer:2, DPS:8", keyed
+// by lower-cased role name
+func parseGroupQuotas(val string, roles []roleRoster, size int) (map[string]int, error) {
+	known := map[string]bool{}
+	for _, rr := range roles {
+		known[strings.ToLower(rr.role)] = true
+	}
+
+	quotas := map[string]int{}
+	total := 0
+	for _, part := range strings.Split(val, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		idx := strings.LastIndex(part, ":")
+		if idx < 0 {
+			return nil, errors.Wrap(ErrBadQuotas, "expected ROLE:COUNT", "quota", part)
+		}
+
+		role := strings.ToLower(strings.TrimSpace(part[:idx]))
+		if !known[role] {
+			return nil, errors.Wrap(ErrUnknownRole, "unknown role in group quotas", "role", role)
+		}
+
+		ct, err := strconv.Atoi(strings.TrimSpace(part[idx+1:]))
+		if err != nil || ct < 0 {
+			return nil, errors.Wrap(ErrBadQuotas, "count must be a non-negative number", "quota", part)
+		}
+
+		quotas[role] = ct
+		total += ct
+	}
+
+	if total > size {
+		return nil, errors.Wrap(ErrBadQuotas, "quotas add up to more than the group size", "total", total, "size", size)
+	}
+
+	return quotas, nil
+}
+
+// buildGroups splits an event roster into groups of at most size members. Each role is dealt out
+// to the groups in turn up to its per-group quota, topping up from the role's overflow when its
+// main roster runs short; then any remaining main roster members, followed by remaining overflow,
+// fill the free places in the smallest groups. Whoever does not fit is returned separately.
+func buildGroups(roles []roleRoster, size int, quotas map[string]int) ([][]groupMember, []groupMember) {
+	mainCount := 0
+	for _, rr := range roles {
+		mainCount += len(rr.main)
+	}
+
+	numGroups := (mainCount + size - 1) / size
+	if numGroups < 1 {
+		numGroups = 1
+	}
+	if numGroups > maxGroups {
+		numGroups = maxGroups
+	}
+
+	groups := make([][]groupMember, numGroups)
+
+	var leftMain, leftOverflow []groupMember
+	for _, rr := range roles {
+		main, overflow := rr.main, rr.overflow
+		quota := quotas[strings.ToLower(rr.role)]
+
+	quotaLoop:
+		for round := 0; round < quota; round++ {
+			for g := range groups {
+				if len(groups[g]) >= size {
+					continue
+				}
+
+				switch {
+				case len(main) > 0:
+					groups[g] = append(groups[g], groupMember{name: main[0], role: rr.role})
+					main = main[1:]
+				case len(overflow) > 0:
+					groups[g] = append(groups[g], groupMember{name: overflow[0], role: rr.role, overflow: true})
+					overflow = overflow[1:]
+				default:
+					break quotaLoop
+				}
+			}
+		}
+
+		for _, name := range main {
+			leftMain = append(leftMain, groupMember{name: name, role: rr.role})
+		}
+		for _, name := range overflow {
+			leftOverflow = append(leftOverflow, groupMember{name: name, role: rr.role, overflow: true})
+		}
+	}
+
+	var unassigned []groupMember
+	for _, m := range append(leftMain, leftOverflow...) {
+		smallest := 0
+		for g := range groups {
+			if len(groups[g]) < len(groups[smallest]) {
+				smallest = g
+			}
+		}
+
+		if len(groups[smallest]) >= size {
+			unassigned = append(unassigned, m)
+			continue
+		}
+
+		groups[smallest] = append(groups[smallest], m)
+	}
+
+	return groups, unassigned
+}
@@ -0,0 +1,174 @@
+package commands
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func names(prefix string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("%s%d", prefix, i+1)
+	}
+	return out
+}
+
+func Test_buildGroups(t *testing.T) {
+	t.Parallel()
+
+	rosters := []roleRoster{
+		{role: "DPS", main: names("d", 16), overflow: names("do", 1)},
+		{role: "Healer", main: names("h", 4)},
+		{role: "Tank", main: names("t", 3), overflow: names("to", 2)},
+	}
+	quotas := map[string]int{"dps": 8, "healer": 2, "tank": 2}
+
+	groups, unassigned := buildGroups(rosters, 12, quotas)
+	if len(groups) != 2 {
+		t.Fatalf("got %d groups, want 2", len(groups))
+	}
+
+	for i, g := range groups {
+		if len(g) != 12 {
+			t.Errorf("group %d has %d members, want 12", i, len(g))
+		}
+
+		roles := map[string]int{}
+		for _, m := range g {
+			roles[m.role]++
+		}
+		if want := map[string]int{"DPS": 8, "Healer": 2, "Tank": 2}; !reflect.DeepEqual(roles, want) {
+			t.Errorf("group %d roles = %v, want %v", i, roles, want)
+		}
+	}
+
+	// the tank shortfall in the second group is filled from overflow
+	if last := groups[1][len(groups[1])-1]; last != (groupMember{name: "to1", role: "Tank", overflow: true}) {
+		t.Errorf("last member of group 2 = %+v", last)
+	}
+
+	want := []groupMember{
+		{name: "do1", role: "DPS", overflow: true},
+		{name: "to2", role: "Tank", overflow: true},
+	}
+	if !reflect.DeepEqual(unassigned, want) {
+		t.Errorf("unassigned = %+v, want %+v", unassigned, want)
+	}
+}
+
+func Test_buildGroups_fillsSmallest(t *testing.T) {
+	t.Parallel()
+
+	rosters := []roleRoster{
+		{role: "DPS", main: names("d", 7)},
+		{role: "Tank", main: names("t", 1)},
+	}
+
+	groups, unassigned := buildGroups(rosters, 5, map[string]int{"tank": 1})
+	if len(unassigned) != 0 {
+		t.Errorf("unassigned = %+v, want none", unassigned)
+	}
+
+	if len(groups) != 2 || len(groups[0]) != 4 || len(groups[1]) != 4 {
+		t.Errorf("groups are not balanced: %+v", groups)
+	}
+}
+
+func Test_defaultGroupQuotas(t *testing.T) {
+	t.Parallel()
+
+	rosters := []roleRoster{{role: "DPS"}, {role: "Healer"}, {role: "Tank"}}
+
+	tests := []struct {
+		name   string
+		counts map[string]int
+		size   int
+		want   map[string]int
+	}{
+		{
+			name:   "exact",
+			counts: map[string]int{"DPS": 16, "Healer": 4, "Tank": 4},
+			size:   12,
+			want:   map[string]int{"dps": 8, "healer": 2, "tank": 2},
+		},
+		{
+			name:   "remainders",
+			counts: map[string]int{"DPS": 1, "Healer": 1, "Tank": 1},
+			size:   4,
+			want:   map[string]int{"dps": 2, "healer": 1, "tank": 1},
+		},
+		{
+			name:   "no slots",
+			counts: map[string]int{},
+			size:   4,
+			want:   map[string]int{},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := defaultGroupQuotas(rosters, tt.counts, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaultGroupQuotas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseGroupQuotas(t *testing.T) {
+	t.Parallel()
+
+	rosters := []roleRoster{{role: "DPS"}, {role: "Healer"}, {role: "Tank"}}
+
+	tests := []struct {
+		name    string
+		val     string
+		want    map[string]int
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			val:  "Tank:2, healer: 2,DPS:8",
+			want: map[string]int{"dps": 8, "healer": 2, "tank": 2},
+		},
+		{
+			name:    "unknown role",
+			val:     "Bard:2",
+			wantErr: true,
+		},
+		{
+			name:    "bad count",
+			val:     "Tank:two",
+			wantErr: true,
+		},
+		{
+			name:    "missing count",
+			val:     "Tank",
+			wantErr: true,
+		},
+		{
+			name:    "too many",
+			val:     "Tank:4,Healer:4,DPS:8",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseGroupQuotas(tt.val, rosters, 12)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseGroupQuotas() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGroupQuotas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
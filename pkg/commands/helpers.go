@@ -6,13 +6,17 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/bot"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/bot/session"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
 	"github.com/gsmcwhirter/go-util/v8/errors"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 )
@@ -24,7 +28,13 @@ type Logger = interface {
 	Printf(string, ...interface{})
 }
 
-var ErrUnknownRole = errors.New("unknown role")
+var (
+	ErrUnknownRole         = i18n.NewError(i18n.UnknownRole)
+	ErrMissingRequiredRole = errors.New("missing a required discord role")
+	ErrSignupLimit         = errors.New("signup limit reached")
+	ErrSignupClosed        = i18n.NewError(i18n.SignupClosed)
+	ErrWithdrawClosed      = i18n.NewError(i18n.WithdrawClosed)
+)
 
 var (
 	isAdminAuthorized = msghandler.IsAdminAuthorized
@@ -43,30 +53,23 @@ func isSignupChannel(ctx context.Context, logger Logger, msg msghandler.MessageL
 	return isAdminAuthorized(ctx, logger, msg, adminRoles, sess, b)
 }
 
-func signupsForRole(ctx context.Context, role string, signups []storage.TrialSignup, sorted bool) []string {
-	roleLower := strings.ToLower(role)
-	t := make([]string, 0, len(signups))
-	for _, s := range signups {
-		if strings.ToLower(s.GetRole(ctx)) == roleLower {
-			t = append(t, s.GetName(ctx))
-		}
-	}
-
-	if sorted {
-		sort.Strings(t)
-	}
-
-	return t
-}
-
+// roleCountByName finds an event role by its name or one of its aliases (ignoring case)
 func roleCountByName(ctx context.Context, role string, roleCounts []storage.RoleCount) (storage.RoleCount, bool) {
-	roleLower := strings.ToLower(role)
+	roleLower := strings.ToLower(strings.TrimSpace(role))
 	for _, rc := range roleCounts {
 		if strings.ToLower(rc.GetRole(ctx)) == roleLower {
 			return rc, true
 		}
 	}
 
+	for _, rc := range roleCounts {
+		for _, alias := range rc.GetAliases(ctx) {
+			if alias == roleLower {
+				return rc, true
+			}
+		}
+	}
+
 	return nil, false
 }
 
@@ -124,14 +127,54 @@ func loadEventSettings(sMap map[string]string) eventSettings {
 		es.Time = &v
 	}
 
+	if v, ok := sMap["category"]; ok {
+		es.Category = &v
+	}
+
 	if v, ok := sMap["roleorder"]; ok {
 		es.RoleOrder = &v
 	}
 
+	if v, ok := sMap["rolegroups"]; ok {
+		es.RoleGroups = &v
+	}
+
 	if v, ok := sMap["roles"]; ok {
 		es.Roles = &v
 	}
 
+	if v, ok := sMap["signupsopenat"]; ok {
+		es.SignupsOpenAt = &v
+	}
+
+	if v, ok := sMap["signupscloseat"]; ok {
+		es.SignupsCloseAt = &v
+	}
+
+	if v, ok := sMap["closehoursbefore"]; ok {
+		es.CloseHoursBefore = &v
+	}
+
+	if v, ok := sMap["announcestatechanges"]; ok {
+		es.AnnounceStateChanges = &v
+	}
+
+	if v, ok := sMap["reminders"]; ok {
+		es.ReminderOffsets = &v
+	}
+
+	if v, ok := sMap["leader"]; ok {
+		es.Leader = &v
+	}
+
+	if v, ok := sMap["prioritytiers"]; ok {
+		es.PriorityTiers = &v
+	}
+
+	if v, ok := sMap["priorityhoursbefore"]; ok {
+		es.PriorityHoursBefore = &v
+	}
+
 	return es
 }
 
@@ -157,6 +200,12 @@ func eventSettingsFromOptions(opts []entity.ApplicationCommandInteractionOption,
 			continue
 		}
 
+		if opts[i].Name == "category" {
+			v := opts[i].ValueString
+			es.Category = &v
+			continue
+		}
+
 		if opts[i].Name == "description" {
 			v := opts[i].ValueString
 			es.Description = &v
@@ -207,6 +256,63 @@ func eventSettingsFromOptions(opts []entity.ApplicationCommandInteractionOption,
 			es.RoleOrder = &v
 			continue
 		}
+
+		if opts[i].Name == "rolegroups" {
+			v := opts[i].ValueString
+			es.RoleGroups = &v
+			continue
+		}
+
+		if opts[i].Name == "signupsopenat" {
+			v := opts[i].ValueString
+			es.SignupsOpenAt = &v
+			continue
+		}
+
+		if opts[i].Name == "signupscloseat" {
+			v := opts[i].ValueString
+			es.SignupsCloseAt = &v
+			continue
+		}
+
+		if opts[i].Name == "closehoursbefore" {
+			v := opts[i].ValueString
+			es.CloseHoursBefore = &v
+			continue
+		}
+
+		if opts[i].Name == "announcestatechanges" {
+			if opts[i].ValueBool {
+				es.AnnounceStateChanges = &trueString
+			} else {
+				es.AnnounceStateChanges = &falseString
+			}
+			continue
+		}
+
+		if opts[i].Name == "reminders" {
+			v := opts[i].ValueString
+			es.ReminderOffsets = &v
+			continue
+		}
+
+		if opts[i].Name == "leader" {
+			v := cmdhandler.UserMentionString(opts[i].ValueUser)
+			es.Leader = &v
+			continue
+		}
+
+		if opts[i].Name == "prioritytiers" {
+			v := opts[i].ValueString
+			es.PriorityTiers = &v
+			continue
+		}
+
+		if opts[i].Name == "priorityhoursbefore" {
+			v := opts[i].ValueString
+			es.PriorityHoursBefore = &v
+			continue
+		}
 	}
 
 	return eventName, es, nil
@@ -216,6 +322,58 @@ type roleCtEmo struct {
 	role string
 	ct   uint64
 	emo  string
+
+	// reqs is only applied when hasReqs is set, so that an empty `[]` can clear requirements
+	reqs    []string
+	hasReqs bool
+
+	// likewise aliases, with an empty `{}`
+	aliases    []string
+	hasAliases bool
+
+	// likewise the role group, with an empty `/ROLE`; noMax is a `*` count, for a role in a
+	// group that is only limited by the group's count
+	group    string
+	hasGroup bool
+	noMax    bool
+}
+
+// parseRoleRequirements parses the `ROLE|ROLE` list of discord roles (as mentions or ids)
+// that are allowed to sign up for an event role
+func parseRoleRequirements(reqStr string) ([]string, error) {
+	reqParts := strings.Split(reqStr, "|")
+	reqs := make([]string, 0, len(reqParts))
+
+	for _, req := range reqParts {
+		req = strings.TrimSpace(req)
+		if req == "" {
+			continue
+		}
+
+		req = strings.TrimSuffix(strings.TrimPrefix(req, "<@&"), ">")
+		rid, err := snowflake.FromString(req)
+		if err != nil {
+			return reqs, errors.Wrap(err, "could not parse role requirement", "requirement", req)
+		}
+
+		reqs = append(reqs, rid.ToString())
+	}
+
+	return reqs, nil
+}
+
+// parseRoleAliasList parses the `ALIAS|ALIAS` list of other names for an event role
+func parseRoleAliasList(aliasStr string) []string {
+	parts := strings.Split(aliasStr, "|")
+	aliases := make([]string, 0, len(parts))
+
+	for _, alias := range parts {
+		if alias = strings.ToLower(strings.TrimSpace(alias)); alias != "" {
+			aliases = append(aliases, alias)
+		}
+	}
+
+	return aliases
 }
 
 func parseRolesString(args string) ([]roleCtEmo, error) {
@@ -223,132 +381,330 @@ func parseRolesString(args string) ([]roleCtEmo, error) {
 	roleEmoCt := make([]roleCtEmo, 0, len(roles))
 
 	for _, roleStr := range roles {
+		roleStr = strings.TrimSpace(roleStr)
 		if roleStr == "" {
 			continue
 		}
 
+		var reqs []string
+		var hasReqs bool
+		if strings.HasSuffix(roleStr, "]") {
+			start := strings.LastIndex(roleStr, "[")
+			if start < 0 {
+				return roleEmoCt, errors.New("could not parse role requirements")
+			}
+
+			var err error
+			reqs, err = parseRoleRequirements(roleStr[start+1 : len(roleStr)-1])
+			if err != nil {
+				return roleEmoCt, err
+			}
+			hasReqs = true
+			roleStr = roleStr[:start]
+		}
+
+		var aliases []string
+		var hasAliases bool
+		if strings.HasSuffix(roleStr, "}") {
+			start := strings.LastIndex(roleStr, "{")
+			if start < 0 {
+				return roleEmoCt, errors.New("could not parse role aliases")
+			}
+
+			aliases = parseRoleAliasList(roleStr[start+1 : len(roleStr)-1])
+			hasAliases = true
+			roleStr = roleStr[:start]
+		}
+
 		roleParts := strings.SplitN(roleStr, ":", 3)
 		if len(roleParts) < 2 {
 			return roleEmoCt, errors.New("could not parse roles")
 		}
 
-		roleCt, err := strconv.Atoi(roleParts[1])
-		if err != nil {
-			return roleEmoCt, err
+		name := roleParts[0]
+		var group string
+		var hasGroup bool
+		if i := strings.Index(name, "/"); i >= 0 {
+			group = strings.TrimSpace(name[:i])
+			name = strings.TrimSpace(name[i+1:])
+			hasGroup = true
+		}
+
+		var roleCt int
+		noMax := strings.TrimSpace(roleParts[1]) == "*"
+		if !noMax {
+			var err error
+			roleCt, err = strconv.Atoi(roleParts[1])
+			if err != nil {
+				return roleEmoCt, err
+			}
 		}
 
 		var emo string
 		if len(roleParts) == 3 {
-			emo = roleParts[2]
+			emo = normalizeEmoji(roleParts[2])
 		}
 
 		roleEmoCt = append(roleEmoCt, roleCtEmo{
-			role: roleParts[0],
-			ct:   uint64(roleCt),
-			emo:  emo,
+			role:       name,
+			ct:         uint64(roleCt),
+			emo:        emo,
+			reqs:       reqs,
+			hasReqs:    hasReqs,
+			aliases:    aliases,
+			hasAliases: hasAliases,
+			group:      group,
+			hasGroup:   hasGroup,
+			noMax:      noMax,
 		})
 	}
 
 	return roleEmoCt, nil
 }
 
-func getTrialRoleSignups(ctx context.Context, signups []storage.TrialSignup, rc storage.RoleCount) ([]string, []string) {
-	lowerRole := strings.ToLower(rc.GetRole(ctx))
-	suNames := make([]string, 0, len(signups))
-	ofNames := make([]string, 0, len(signups))
-	for _, su := range signups {
-		if strings.ToLower(su.GetRole(ctx)) != lowerRole {
-			continue
-		}
+// getTrialRoleSignups lists the names in the main roster and in the overflow of a role
+func getTrialRoleSignups(ctx context.Context, roster storage.Roster, rc storage.RoleCount) ([]string, []string) {
+	return signupNames(ctx, roster.Main(rc.GetRole(ctx))), signupNames(ctx, roster.Overflow(rc.GetRole(ctx)))
+}
 
-		if uint64(len(suNames)) < rc.GetCount(ctx) {
-			suNames = append(suNames, su.GetName(ctx))
-		} else {
-			ofNames = append(ofNames, su.GetName(ctx))
-		}
+func signupNames(ctx context.Context, signups []storage.TrialSignup) []string {
+	names := make([]string, 0, len(signups))
+	for _, su := range signups {
+		names = append(names, su.GetName(ctx))
 	}
 
-	return suNames, ofNames
+	return names
 }
 
-func formatTrialDisplay(ctx context.Context, trial storage.Trial, withState bool) *cmdhandler.EmbedResponse {
-	r := &cmdhandler.EmbedResponse{}
+// eventEmbed is an embed that displays an event; the messages it is sent as are indexed so
+// that reactions to them can be mapped back to the event
+type eventEmbed struct {
+	*cmdhandler.EmbedResponse
+	eventName string
+	buttons   []components.ActionRow
+	nav       []components.ActionRow
+	page      int
+	pages     int
+}
 
-	if withState {
-		r.Title = fmt.Sprintf("__%s__ (%s)", trial.GetName(ctx), string(trial.GetState(ctx)))
-	} else {
-		r.Title = fmt.Sprintf("__%s__", trial.GetName(ctx))
+var (
+	_ msghandler.EventResponse     = (*eventEmbed)(nil)
+	_ msghandler.ComponentResponse = (*eventEmbed)(nil)
+	_ msghandler.PageResponse      = (*eventEmbed)(nil)
+)
+
+func newEventEmbed(ctx context.Context, p i18n.Printer, r *cmdhandler.EmbedResponse, trial storage.Trial) *eventEmbed {
+	return &eventEmbed{
+		EmbedResponse: r,
+		eventName:     trial.GetName(ctx),
+		buttons:       eventButtons(ctx, p, trial, 0),
+		page:          1,
+		pages:         1,
 	}
+}
+
+func (r *eventEmbed) EventName() string {
+	return r.eventName
+}
 
-	desc := trial.GetDescription(ctx)
-	if t := trial.GetTime(ctx); t != "" {
-		desc = fmt.Sprintf("When: %s\n\n%s", t, desc)
+func (r *eventEmbed) Components() []components.ActionRow {
+	if len(r.nav) == 0 {
+		return r.buttons
 	}
 
-	r.Description = desc
-	r.Fields = []cmdhandler.EmbedField{}
+	rows := make([]components.ActionRow, 0, len(r.buttons)+len(r.nav))
+	rows = append(rows, r.buttons...)
+	return append(rows, r.nav...)
+}
 
-	overflowFields := []cmdhandler.EmbedField{}
+func (r *eventEmbed) Page() (page, pages int) {
+	return r.page, r.pages
+}
 
-	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
-	signups := trial.GetSignups(ctx)
+// formatTrialDisplay shows the first page of an event roster
+func formatTrialDisplay(ctx context.Context, p i18n.Printer, trial storage.Trial, withState bool) *eventEmbed {
+	return formatTrialDisplayPage(ctx, p, trial, withState, 1)
+}
 
-	emojis := make([]string, 0, len(roleCounts))
+// memberRolesFunc lazily looks up the discord roles of the member signing up
+type memberRolesFunc func(ctx context.Context) ([]snowflake.Snowflake, error)
 
-	for _, rc := range roleCounts {
-		suNames, ofNames := getTrialRoleSignups(ctx, signups, rc)
+// cached makes the lookup happen at most once
+func (f memberRolesFunc) cached() memberRolesFunc {
+	if f == nil {
+		return nil
+	}
 
-		emoji := rc.GetEmoji(ctx)
+	var rids []snowflake.Snowflake
+	var err error
+	var done bool
 
-		if len(suNames) > 0 {
-			r.Fields = append(r.Fields, cmdhandler.EmbedField{
-				Name: fmt.Sprintf("*%s* %s (%d/%d)", rc.GetRole(ctx), emoji, len(suNames), rc.GetCount(ctx)),
-				Val:  strings.Join(suNames, "\n") + "\n_ _\n",
-			})
-		} else {
-			r.Fields = append(r.Fields, cmdhandler.EmbedField{
-				Name: fmt.Sprintf("*%s* %s (%d/%d)", rc.GetRole(ctx), emoji, len(suNames), rc.GetCount(ctx)),
-				Val:  "(empty)\n_ _\n",
-			})
+	return func(ctx context.Context) ([]snowflake.Snowflake, error) {
+		if !done {
+			rids, err = f(ctx)
+			done = true
 		}
 
-		if len(ofNames) > 0 {
-			overflowFields = append(overflowFields, cmdhandler.EmbedField{
-				Name: fmt.Sprintf("*Overflow %s* %s (%d)", rc.GetRole(ctx), emoji, len(ofNames)),
-				Val:  strings.Join(ofNames, "\n") + "\n_ _\n",
-			})
+		return rids, err
+	}
+}
+
+func guildMemberRoles(b *bot.DiscordBot, gid, uid snowflake.Snowflake) memberRolesFunc {
+	return func(ctx context.Context) ([]snowflake.Snowflake, error) {
+		gm, err := b.API().GetGuildMember(ctx, gid, uid)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get guild member", "guild_id", gid.ToString(), "member_id", uid.ToString())
 		}
 
-		if emoji != "" {
-			emojis = append(emojis, emoji)
+		return gm.RoleSnowflakes, nil
+	}
+}
+
+func checkRoleRequirements(ctx context.Context, rc storage.RoleCount, memberRoles memberRolesFunc) error {
+	reqs := rc.GetRequiredRoles(ctx)
+	if len(reqs) == 0 || memberRoles == nil {
+		return nil
+	}
+
+	rids, err := memberRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	mentions := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		for _, rid := range rids {
+			if rid.ToString() == req {
+				return nil
+			}
 		}
+
+		mentions = append(mentions, fmt.Sprintf("<@&%s>", req))
 	}
 
-	r.Fields = append(r.Fields, overflowFields...)
+	return i18n.WrapError(ErrMissingRequiredRole, i18n.RoleRequirements, rc.GetRole(ctx), strings.Join(mentions, ", "))
+}
 
-	if !trial.HideReactionsShow(ctx) {
-		r.Reactions = emojis
+// parseRolePreferences splits a ranked list of roles, such as "healer > tank > dps"
+func parseRolePreferences(roles string) []string {
+	prefs := []string{}
+	for _, role := range strings.Split(roles, ">") {
+		if role = strings.TrimSpace(role); role != "" {
+			prefs = append(prefs, role)
+		}
 	}
-	r.FooterText = fmt.Sprintf("event:%s", trial.GetName(ctx))
 
-	return r
+	return prefs
 }
 
-func signupUser(ctx context.Context, trial storage.Trial, userMentionStr, role string) (bool, error) {
+// trialRoster is the effective roster of the trial, with priority tiers and alternate roles taken into account
+func trialRoster(ctx context.Context, trial storage.Trial) storage.Roster {
+	return storage.TrialRoster(ctx, trial)
+}
+
+// signupUser adds the user to the trial in the given role, or the first of a ranked list of roles
+// with the rest as alternates. The member's discord roles (memberRoles) give them their priority tier,
+// and are checked against the role requirements unless checkRequirements is false (admins may sign
+// anyone up for any role); if memberRoles is nil, no priority tier is given. It returns the role the
+// user was placed in (the first choice if they are overflow).
+func signupUser(ctx context.Context, trial storage.Trial, userMentionStr, roles string, aliases map[string]string, memberRoles memberRolesFunc, checkRequirements bool) (string, bool, error) {
+	prefs := parseRolePreferences(roles)
+	if len(prefs) == 0 {
+		return "", false, ErrUnknownRole
+	}
+
+	memberRoles = memberRoles.cached()
+
 	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
-	rc, known := roleCountByName(ctx, role, roleCounts)
-	if !known {
-		return false, ErrUnknownRole
+	for i, role := range prefs {
+		rc, err := findRole(ctx, role, roleCounts, aliases)
+		if err != nil {
+			return "", false, err
+		}
+
+		if checkRequirements {
+			if err := checkRoleRequirements(ctx, rc, memberRoles); err != nil {
+				return "", false, err
+			}
+		}
+
+		prefs[i] = rc.GetRole(ctx)
+	}
+
+	trial.AddSignup(ctx, userMentionStr, prefs[0])
+	trial.SetSignupAlternates(ctx, userMentionStr, prefs[1:])
+
+	if err := setSignupPriority(ctx, trial, userMentionStr, memberRoles, time.Now()); err != nil {
+		return "", false, err
+	}
+
+	if role := trialRoster(ctx, trial).AssignedRole(userMentionStr); role != "" {
+		return role, false, nil
+	}
+
+	return prefs[0], true, nil
+}
+
+func inMainRoster(ctx context.Context, trial storage.Trial, userMentionStr string) bool {
+	return trialRoster(ctx, trial).AssignedRole(userMentionStr) != ""
+}
+
+// mainRosterRoles maps the name of everyone currently in the main roster of an event to their role
+func mainRosterRoles(ctx context.Context, trial storage.Trial) map[string]string {
+	members := map[string]string{}
+
+	roster := trialRoster(ctx, trial)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		for _, name := range signupNames(ctx, roster.Main(rc.GetRole(ctx))) {
+			members[name] = rc.GetRole(ctx)
+		}
+	}
+
+	return members
+}
+
+// checkSignupLimit makes sure the user does not already hold a main roster spot in too many
+// other open events (only counting events in the same category, if so configured)
+func checkSignupLimit(ctx context.Context, t storage.TrialAPITx, gsettings storage.GuildSettings, trial storage.Trial, userMentionStr string) error {
+	limit, err := strconv.Atoi(gsettings.SignupLimit)
+	if err != nil || limit <= 0 {
+		return nil
 	}
 
-	trial.AddSignup(ctx, userMentionStr, role)
+	perCategory := gsettings.SignupLimitPerCategory == "true"
+	trialName := strings.ToLower(trial.GetName(ctx))
+	category := strings.ToLower(trial.GetCategory(ctx))
 
-	signups := trial.GetSignups(ctx)
-	roleSignups := signupsForRole(ctx, role, signups, false)
+	held := make([]string, 0, limit)
+	for _, other := range t.GetTrials(ctx) {
+		if strings.ToLower(other.GetName(ctx)) == trialName {
+			continue
+		}
 
-	overflow := uint64(len(roleSignups)) > rc.GetCount(ctx)
+		if other.GetState(ctx) != storage.TrialStateOpen {
+			continue
+		}
+
+		if perCategory && strings.ToLower(other.GetCategory(ctx)) != category {
+			continue
+		}
+
+		if inMainRoster(ctx, other, userMentionStr) {
+			held = append(held, other.GetName(ctx))
+		}
+	}
+
+	if len(held) < limit {
+		return nil
+	}
+
+	sort.Strings(held)
+
+	if perCategory && category != "" {
+		return i18n.WrapError(ErrSignupLimit, i18n.SignupLimitCategory, limit, trial.GetCategory(ctx), strings.Join(held, ", "))
+	}
 
-	return overflow, nil
+	return i18n.WrapError(ErrSignupLimit, i18n.SignupLimit, limit, strings.Join(held, ", "))
 }
 
 func colorToInt(c string) (int, error) {
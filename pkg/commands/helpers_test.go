@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+)
+
+func Test_parseRolesString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    string
+		want    []roleCtEmo
+		wantErr bool
+	}{
+		{
+			name: "plain roles",
+			args: "tank:2,healer:1:<:heal:123>",
+			want: []roleCtEmo{
+				{role: "tank", ct: 2},
+				{role: "healer", ct: 1, emo: "<:heal:123>"},
+			},
+		},
+		{
+			name: "role requirements",
+			args: "healer:2:<:heal:123>[<@&456>|789], dps:4[<@&101>]",
+			want: []roleCtEmo{
+				{role: "healer", ct: 2, emo: "<:heal:123>", reqs: []string{"456", "789"}, hasReqs: true},
+				{role: "dps", ct: 4, reqs: []string{"101"}, hasReqs: true},
+			},
+		},
+		{
+			name: "clear requirements",
+			args: "healer:2[]",
+			want: []roleCtEmo{
+				{role: "healer", ct: 2, reqs: []string{}, hasReqs: true},
+			},
+		},
+		{
+			name: "aliases",
+			args: "dps:4:<:dps:1>{DD|damage}[<@&101>], tank:2{}",
+			want: []roleCtEmo{
+				{role: "dps", ct: 4, emo: "<:dps:1>", reqs: []string{"101"}, hasReqs: true, aliases: []string{"dd", "damage"}, hasAliases: true},
+				{role: "tank", ct: 2, aliases: []string{}, hasAliases: true},
+			},
+		},
+		{
+			name: "role groups",
+			args: "DPS/ranged:2, dps / melee:*:⚔️, /tank:2",
+			want: []roleCtEmo{
+				{role: "ranged", ct: 2, group: "DPS", hasGroup: true},
+				{role: "melee", emo: "⚔️", group: "dps", hasGroup: true, noMax: true},
+				{role: "tank", ct: 2, hasGroup: true},
+			},
+		},
+		{
+			name: "emoji forms",
+			args: "tank:2: shield:123 , dance:1:<a:dance:456>",
+			want: []roleCtEmo{
+				{role: "tank", ct: 2, emo: "<:shield:123>"},
+				{role: "dance", ct: 1, emo: "<a:dance:456>"},
+			},
+		},
+		{
+			name:    "bad requirement",
+			args:    "healer:2[healers]",
+			wantErr: true,
+		},
+		{
+			name:    "missing count",
+			args:    "healer",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseRolesString(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseRolesString() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRolesString() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_encodeExportCSV(t *testing.T) {
+	t.Parallel()
+
+	got, err := encodeExportCSV([]exportRow{
+		{Role: "tank", Slot: 1, MemberID: "123", DisplayName: "Tanky", SignedUpAt: "2021-01-02T03:04:05Z", Note: "bringing, food"},
+		{Role: "tank", Slot: 2, Overflow: true, DisplayName: "someone"},
+	})
+	if err != nil {
+		t.Fatalf("encodeExportCSV() error = %v", err)
+	}
+
+	want := "role,slot,overflow,member_id,display_name,signed_up_at,note\n" +
+		"tank,1,false,123,Tanky,2021-01-02T03:04:05Z,\"bringing, food\"\n" +
+		"tank,2,true,,someone,,\n"
+	if string(got) != want {
+		t.Errorf("encodeExportCSV() = %q, want %q", got, want)
+	}
+}
+
+func Test_exportFilename(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		event string
+		want  string
+	}{
+		{event: "vAS HM", want: "vAS_HM.csv"},
+		{event: "trial-1_a", want: "trial-1_a.csv"},
+		{event: "../..", want: "event.csv"},
+	}
+
+	for _, tt := range tests {
+		if got := exportFilename(tt.event, exportFormatCSV); got != tt.want {
+			t.Errorf("exportFilename(%q) = %q, want %q", tt.event, got, tt.want)
+		}
+	}
+}
+
+func Test_parseImportCSV(t *testing.T) {
+	t.Parallel()
+
+	data := "user,role\n<@123>, tank\n\n456,healer\nsomeone\n\"bad,dps\n"
+	rows, errs := parseImportCSV(i18n.NewPrinter(i18n.English), []byte(data))
+
+	wantRows := []importRow{
+		{line: 2, user: "<@123>", role: "tank"},
+		{line: 4, user: "456", role: "healer"},
+	}
+	if !reflect.DeepEqual(rows, wantRows) {
+		t.Errorf("parseImportCSV() rows = %+v, want %+v", rows, wantRows)
+	}
+
+	if len(errs) != 2 {
+		t.Errorf("parseImportCSV() errs = %q, want 2 errors", errs)
+	}
+}
+
+func Test_parseCreateForm(t *testing.T) {
+	t.Parallel()
+
+	name, es, problems := parseCreateForm(map[string]string{
+		createFormName:  " Raid ",
+		createFormTime:  "Saturday",
+		createFormRoles: "tank:2\nhealer:x\n\ndps:4, bard",
+	})
+
+	if name != "Raid" {
+		t.Errorf("name = %q, want %q", name, "Raid")
+	}
+
+	if es.Time == nil || *es.Time != "Saturday" || es.Description != nil {
+		t.Errorf("unexpected settings %+v", es)
+	}
+
+	if es.Roles == nil || *es.Roles != "tank:2,dps:4" {
+		t.Errorf("roles = %v, want %q", es.Roles, "tank:2,dps:4")
+	}
+
+	if len(problems) != 2 {
+		t.Errorf("problems = %q, want 2 entries", problems)
+	}
+
+	_, _, problems = parseCreateForm(map[string]string{})
+	if len(problems) != 2 {
+		t.Errorf("problems = %q, want missing name and roles", problems)
+	}
+}
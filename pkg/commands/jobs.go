@@ -0,0 +1,350 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/bot/session"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/directmsg"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/scheduler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
+)
+
+// Job kinds
+const (
+	jobKindOpenSignups  = "open_signups"
+	jobKindCloseSignups = "close_signups"
+	jobKindReminder     = "reminder"
+)
+
+var ErrBadTime = errors.New("could not understand time (use a discord timestamp like <t:1640995200> or YYYY-MM-DD HH:MM in UTC)")
+
+type jobDependencies interface {
+	Logger() Logger
+	TrialAPI() storage.TrialAPI
+	GuildAPI() storage.GuildAPI
+	MemberAPI() storage.MemberAPI
+	LiveMessageAPI() storage.LiveMessageAPI
+	BotSession() *session.Session
+	Census() *telemetry.Census
+	MessageHandler() msghandler.Handlers
+	DirectMessages() *directmsg.Opener
+	Webhooks() *webhooks.Queue
+}
+
+// JobHandlers runs the scheduled jobs for events
+type JobHandlers struct {
+	deps jobDependencies
+}
+
+// NewJobHandlers creates a new JobHandlers
+func NewJobHandlers(deps jobDependencies) *JobHandlers {
+	return &JobHandlers{
+		deps: deps,
+	}
+}
+
+// RegisterJobs registers the event job handlers with the scheduler
+func (j *JobHandlers) RegisterJobs(s *scheduler.Scheduler) error {
+	if err := s.Register(jobKindOpenSignups, j.setStateJob(storage.TrialStateOpen)); err != nil {
+		return err
+	}
+
+	if err := s.Register(jobKindCloseSignups, j.setStateJob(storage.TrialStateClosed)); err != nil {
+		return err
+	}
+
+	return s.Register(jobKindReminder, j.reminderJob)
+}
+
+func (j *JobHandlers) setStateJob(state storage.TrialState) scheduler.Handler {
+	return func(ctx context.Context, job storage.Job) error {
+		ctx, span := j.deps.Census().StartSpan(ctx, "jobHandlers.setState", "guild_id", job.GuildID)
+		defer span.End()
+
+		logger := j.deps.Logger()
+
+		gid, err := snowflake.FromString(job.GuildID)
+		if err != nil {
+			return errors.Wrap(err, "could not parse guild id", "guild_id", job.GuildID)
+		}
+
+		t, err := j.deps.TrialAPI().NewTransaction(ctx, job.GuildID, true)
+		if err != nil {
+			return err
+		}
+		defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+		trial, err := t.GetTrial(ctx, job.EventName)
+		if err == storage.ErrTrialNotExist {
+			level.Info(logger).Message("event for scheduled state change no longer exists", "guild_id", job.GuildID, "trial_name", job.EventName)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if trial.GetState(ctx) == state {
+			return nil
+		}
+
+		trial.SetState(ctx, state)
+
+		if err = t.SaveTrial(ctx, trial); err != nil {
+			return errors.Wrap(err, "could not save event")
+		}
+
+		if err = t.Commit(ctx); err != nil {
+			return errors.Wrap(err, "could not save event")
+		}
+
+		level.Info(logger).Message("event state changed by schedule", "guild_id", job.GuildID, "trial_name", trial.GetName(ctx), "state", string(state))
+
+		kind := webhooks.KindClose
+		if state == storage.TrialStateOpen {
+			kind = webhooks.KindOpen
+		}
+		j.deps.Webhooks().Notify(webhookEvent(ctx, gid, kind, trial, "", ""))
+
+		if !trial.AnnounceStateChanges(ctx) {
+			return nil
+		}
+
+		gsettings, err := storage.GetSettings(ctx, j.deps.GuildAPI(), gid)
+		if err != nil {
+			return err
+		}
+
+		okColor, err := colorToInt(gsettings.MessageColor)
+		if err != nil {
+			return err
+		}
+
+		sessionGuild, ok := j.deps.BotSession().Guild(gid)
+		if !ok {
+			return ErrGuildNotFound
+		}
+
+		signupCid, ok := sessionGuild.ChannelWithName(trial.GetSignupChannel(ctx))
+		if !ok {
+			return errors.Wrap(ErrMissingData, "could not find signup channel", "signup_channel", trial.GetSignupChannel(ctx))
+		}
+
+		r := &cmdhandler.SimpleEmbedResponse{
+			ToChannel: signupCid,
+		}
+		r.SetColor(okColor)
+
+		if state == storage.TrialStateOpen {
+			r.Description = i18n.Sprintf(gsettings.Language, i18n.SignupsNowOpen, trial.GetName(ctx))
+		} else {
+			r.Description = i18n.Sprintf(gsettings.Language, i18n.SignupsNowClosed, trial.GetName(ctx))
+		}
+
+		j.deps.MessageHandler().Send(ctx, r, signupCid, gid)
+
+		return nil
+	}
+}
+
+func parseOptionalTime(val string) (time.Time, error) {
+	if strings.TrimSpace(val) == "" {
+		return time.Time{}, nil
+	}
+
+	t, ok := storage.ParseEventTime(val)
+	if !ok {
+		return time.Time{}, errors.Wrap(ErrBadTime, "could not parse time", "val", val)
+	}
+
+	return t, nil
+}
+
+// applyScheduleSettings sets the automatic open/close settings for an event; an event
+// whose signups open in the future starts out closed
+func applyScheduleSettings(ctx context.Context, trial storage.Trial, settings eventSettings) error {
+	if settings.SignupsOpenAt != nil {
+		openAt, err := parseOptionalTime(*settings.SignupsOpenAt)
+		if err != nil {
+			return err
+		}
+
+		trial.SetSignupsOpenAt(ctx, openAt)
+		if openAt.After(time.Now()) {
+			trial.SetState(ctx, storage.TrialStateClosed)
+		}
+	}
+
+	if settings.SignupsCloseAt != nil {
+		closeAt, err := parseOptionalTime(*settings.SignupsCloseAt)
+		if err != nil {
+			return err
+		}
+
+		trial.SetSignupsCloseAt(ctx, closeAt)
+	}
+
+	if settings.CloseHoursBefore != nil {
+		var hours uint64
+		if v := strings.TrimSpace(*settings.CloseHoursBefore); v != "" {
+			var err error
+			hours, err = strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "could not parse closehoursbefore", "val", v)
+			}
+		}
+
+		trial.SetCloseHoursBefore(ctx, hours)
+	}
+
+	if settings.AnnounceStateChanges != nil {
+		if err := trial.SetAnnounceStateChanges(ctx, *settings.AnnounceStateChanges); err != nil {
+			return err
+		}
+	}
+
+	if settings.ReminderOffsets != nil {
+		if err := trial.SetReminderOffsets(ctx, *settings.ReminderOffsets); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// signupsCloseAt determines when signups for an event should close, if ever
+func signupsCloseAt(ctx context.Context, trial storage.Trial) (time.Time, bool) {
+	if closeAt := trial.GetSignupsCloseAt(ctx); !closeAt.IsZero() {
+		return closeAt, true
+	}
+
+	if hours := trial.GetCloseHoursBefore(ctx); hours > 0 {
+		if start, ok := storage.ParseEventTime(trial.GetTime(ctx)); ok {
+			return start.Add(-time.Duration(hours) * time.Hour), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// cancelEventJobs cancels all pending scheduled jobs for an event
+func (c *AdminCommands) cancelEventJobs(ctx context.Context, gid snowflake.Snowflake, eventName string) error {
+	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.cancelEventJobs", "guild_id", gid.ToString())
+	defer span.End()
+
+	if _, err := c.deps.JobAPI().CancelEventJobs(ctx, gid.ToString(), eventName); err != nil {
+		return errors.Wrap(err, "could not cancel scheduled jobs", "event_name", eventName)
+	}
+
+	return nil
+}
+
+func (c *AdminCommands) scheduleEventJob(ctx context.Context, gid snowflake.Snowflake, trial storage.Trial, kind string, at time.Time, payload string) error {
+	return addEventJob(ctx, c.deps.JobAPI(), gid, trial, kind, at, payload)
+}
+
+func addEventJob(ctx context.Context, jobs storage.JobAPI, gid snowflake.Snowflake, trial storage.Trial, kind string, at time.Time, payload string) error {
+	_, err := jobs.AddJob(ctx, storage.Job{
+		GuildID:   gid.ToString(),
+		EventName: trial.GetName(ctx),
+		Kind:      kind,
+		RunAt:     at,
+		Payload:   payload,
+	})
+
+	return errors.Wrap(err, "could not schedule job", "kind", kind)
+}
+
+// rescheduleEventJobs brings the pending scheduled jobs for an event in line with its current settings
+func (c *AdminCommands) rescheduleEventJobs(ctx context.Context, gid snowflake.Snowflake, trial storage.Trial) error {
+	ctx, span := c.deps.Census().StartSpan(ctx, "adminCommands.rescheduleEventJobs", "guild_id", gid.ToString())
+	defer span.End()
+
+	if err := c.cancelEventJobs(ctx, gid, trial.GetName(ctx)); err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	if openAt := trial.GetSignupsOpenAt(ctx); openAt.After(now) {
+		if err := c.scheduleEventJob(ctx, gid, trial, jobKindOpenSignups, openAt, ""); err != nil {
+			return err
+		}
+	}
+
+	if closeAt, ok := signupsCloseAt(ctx, trial); ok && closeAt.After(now) {
+		if err := c.scheduleEventJob(ctx, gid, trial, jobKindCloseSignups, closeAt, ""); err != nil {
+			return err
+		}
+	}
+
+	start, ok := storage.ParseEventTime(trial.GetTime(ctx))
+	if !ok {
+		return nil
+	}
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), gid)
+	if err != nil {
+		return err
+	}
+
+	return scheduleReminderJobs(ctx, c.deps.JobAPI(), gid, trial, gsettings, start, now)
+}
+
+// scheduleReminderJobs adds a job for each of the event's reminders that is still to come
+func scheduleReminderJobs(ctx context.Context, jobs storage.JobAPI, gid snowflake.Snowflake, trial storage.Trial, gsettings storage.GuildSettings, start, now time.Time) error {
+	for _, offset := range reminderOffsets(ctx, trial, gsettings) {
+		if at := start.Add(-offset); at.After(now) {
+			if err := addEventJob(ctx, jobs, gid, trial, jobKindReminder, at, offset.String()); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rescheduleDefaultReminders replaces the pending reminder jobs of the events that use the guild's
+// default reminder offsets, after that default has changed
+func rescheduleDefaultReminders(ctx context.Context, trials storage.TrialAPI, jobs storage.JobAPI, gid snowflake.Snowflake, gsettings storage.GuildSettings) error {
+	t, err := trials.NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		return err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	now := time.Now()
+
+	for _, trial := range t.GetTrials(ctx) {
+		if trial.GetReminderOffsets(ctx) != "" {
+			continue // the event has its own offsets
+		}
+
+		start, ok := storage.ParseEventTime(trial.GetTime(ctx))
+		if !ok || !start.After(now) {
+			continue
+		}
+
+		if _, err := jobs.CancelEventJobs(ctx, gid.ToString(), trial.GetName(ctx), jobKindReminder); err != nil {
+			return errors.Wrap(err, "could not cancel scheduled reminders", "event_name", trial.GetName(ctx))
+		}
+
+		if err := scheduleReminderJobs(ctx, jobs, gid, trial, gsettings, start, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// reminderOffsets determines the reminder offsets for an event, falling back to the guild default
+func reminderOffsets(ctx context.Context, trial storage.Trial, gsettings storage.GuildSettings) []time.Duration {
+	val := trial.GetReminderOffsets(ctx)
+	if val == "" {
+		val = gsettings.ReminderOffsets
+	}
+
+	offsets, err := storage.ParseReminderOffsets(val)
+	if err != nil {
+		return nil
+	}
+
+	return offsets
+}
+
+// rosterMemberRoles maps the user id of everyone currently in the main roster of an event to their role
+func rosterMemberRoles(ctx context.Context, trial storage.Trial) map[snowflake.Snowflake]string {
+	members := map[snowflake.Snowflake]string{}
+
+	for name, role := range mainRosterRoles(ctx, trial) {
+		uid, err := userFromMention(name)
+		if err != nil {
+			continue
+		}
+
+		members[uid] = role
+	}
+
+	return members
+}
+
+func userFromMention(mention string) (snowflake.Snowflake, error) {
+	if !strings.HasPrefix(mention, "<@") || !strings.HasSuffix(mention, ">") {
+		return 0, errors.Wrap(ErrMissingData, "not a user mention", "mention", mention)
+	}
+
+	return snowflake.FromString(strings.TrimPrefix(mention[2:len(mention)-1], "!"))
+}
+
+// eventJumpLink links to the newest message showing the event roster (msgs are newest first), preferring
+// announcements in the signup channel, and falls back to the signup channel itself if there is none
+func eventJumpLink(gid, signupCid snowflake.Snowflake, msgs []storage.LiveMessage) string {
+	rank := func(m storage.LiveMessage) int {
+		switch {
+		case m.ChannelID == signupCid.ToString() && m.Kind == storage.LiveMessageAnnounce:
+			return 2
+		case m.ChannelID == signupCid.ToString():
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	best := -1
+	for i, m := range msgs {
+		if best < 0 || rank(m) > rank(msgs[best]) {
+			best = i
+		}
+	}
+
+	if best < 0 {
+		return fmt.Sprintf("https://discord.com/channels/%s/%s", gid.ToString(), signupCid.ToString())
+	}
+
+	return fmt.Sprintf("https://discord.com/channels/%s/%s/%s", gid.ToString(), msgs[best].ChannelID, msgs[best].MessageID)
+}
+
+func (j *JobHandlers) reminderJob(ctx context.Context, job storage.Job) error {
+	ctx, span := j.deps.Census().StartSpan(ctx, "jobHandlers.reminder", "guild_id", job.GuildID)
+	defer span.End()
+
+	logger := j.deps.Logger()
+
+	gid, err := snowflake.FromString(job.GuildID)
+	if err != nil {
+		return errors.Wrap(err, "could not parse guild id", "guild_id", job.GuildID)
+	}
+
+	t, err := j.deps.TrialAPI().NewTransaction(ctx, job.GuildID, false)
+	if err != nil {
+		return err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, job.EventName)
+	if err == storage.ErrTrialNotExist {
+		level.Info(logger).Message("event for scheduled reminder no longer exists", "guild_id", job.GuildID, "trial_name", job.EventName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	gsettings, err := storage.GetSettings(ctx, j.deps.GuildAPI(), gid)
+	if err != nil {
+		return err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return err
+	}
+
+	sessionGuild, ok := j.deps.BotSession().Guild(gid)
+	if !ok {
+		return ErrGuildNotFound
+	}
+
+	signupCid, ok := sessionGuild.ChannelWithName(trial.GetSignupChannel(ctx))
+	if !ok {
+		return errors.Wrap(ErrMissingData, "could not find signup channel", "signup_channel", trial.GetSignupChannel(ctx))
+	}
+
+	// the roster is computed now rather than when the reminder was scheduled, so anyone
+	// who withdrew in the meantime is skipped
+	members := rosterMemberRoles(ctx, trial)
+	if len(members) == 0 {
+		return nil
+	}
+
+	memberIDs := make([]string, 0, len(members))
+	for uid := range members {
+		memberIDs = append(memberIDs, uid.ToString())
+	}
+
+	optedOut, err := j.deps.MemberAPI().RemindersOptedOut(ctx, job.GuildID, memberIDs)
+	if err != nil {
+		return err
+	}
+
+	for uid := range members {
+		if optedOut[uid.ToString()] {
+			delete(members, uid)
+		}
+	}
+
+	if len(members) == 0 {
+		return nil
+	}
+
+	startStr := trial.GetTime(ctx)
+	if start, ok := storage.ParseEventTime(startStr); ok {
+		startStr = fmt.Sprintf("<t:%d:R>", start.Unix())
+	}
+
+	liveMsgs, err := j.deps.LiveMessageAPI().ListLiveMessages(ctx, job.GuildID, strings.ToLower(job.EventName))
+	if err != nil {
+		level.Error(logger).Err("could not look up event messages for reminder link", err, "guild_id", job.GuildID, "trial_name", job.EventName)
+	}
+	jumpLink := eventJumpLink(gid, signupCid, liveMsgs)
+
+	if gsettings.ReminderDelivery == storage.ReminderDeliveryDM {
+		sent := 0
+		for uid, role := range members {
+			dmCid, err := j.deps.DirectMessages().OpenChannel(ctx, uid)
+			if err != nil {
+				level.Error(logger).Err("could not open dm channel for reminder", err, "user_id", uid.ToString())
+				continue
+			}
+
+			r := &cmdhandler.SimpleEmbedResponse{
+				ToChannel:   dmCid,
+				Description: fmt.Sprintf("%s\n\n%s", i18n.Sprintf(gsettings.Language, i18n.ReminderMember, trial.GetName(ctx), startStr, role), jumpLink),
+			}
+			r.SetColor(okColor)
+
+			j.deps.MessageHandler().Send(ctx, r, dmCid, gid)
+			sent++
+		}
+
+		level.Info(logger).Message("sent event reminders", "guild_id", job.GuildID, "trial_name", trial.GetName(ctx), "delivery", storage.ReminderDeliveryDM, "count", sent)
+		return nil
+	}
+
+	roleMembers := map[string][]string{}
+	mentions := make([]string, 0, len(members))
+	for uid, role := range members {
+		m := cmdhandler.UserMentionString(uid)
+		roleMembers[role] = append(roleMembers[role], m)
+		mentions = append(mentions, m)
+	}
+	sort.Strings(mentions)
+
+	lines := make([]string, 0, len(roleMembers))
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		ms, ok := roleMembers[rc.GetRole(ctx)]
+		if !ok {
+			continue
+		}
+		sort.Strings(ms)
+		lines = append(lines, fmt.Sprintf("%s: %s", rc.GetRole(ctx), strings.Join(ms, ", ")))
+	}
+
+	r := &cmdhandler.SimpleEmbedResponse{
+		To:          strings.Join(mentions, " "),
+		ToChannel:   signupCid,
+		Description: fmt.Sprintf("%s\n\n%s\n\n%s", i18n.Sprintf(gsettings.Language, i18n.ReminderRoster, trial.GetName(ctx), startStr), strings.Join(lines, "\n"), jumpLink),
+	}
+	r.SetColor(okColor)
+
+	j.deps.MessageHandler().Send(ctx, r, signupCid, gid)
+
+	level.Info(logger).Message("sent event reminders", "guild_id", job.GuildID, "trial_name", trial.GetName(ctx), "delivery", storage.ReminderDeliveryChannel, "count", len(members))
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+func TestEventJumpLink(t *testing.T) {
+	t.Parallel()
+
+	announce := storage.LiveMessage{MessageID: "30", ChannelID: "2", Kind: storage.LiveMessageAnnounce}
+	show := storage.LiveMessage{MessageID: "31", ChannelID: "2", Kind: storage.LiveMessageShow}
+	elsewhere := storage.LiveMessage{MessageID: "32", ChannelID: "3", Kind: storage.LiveMessageAnnounce}
+	older := storage.LiveMessage{MessageID: "20", ChannelID: "2", Kind: storage.LiveMessageAnnounce}
+
+	tests := []struct {
+		name string
+		msgs []storage.LiveMessage
+		want string
+	}{
+		{name: "no messages", want: "https://discord.com/channels/1/2"},
+		{name: "announcement", msgs: []storage.LiveMessage{show, announce}, want: "https://discord.com/channels/1/2/30"},
+		{name: "newest announcement", msgs: []storage.LiveMessage{announce, older}, want: "https://discord.com/channels/1/2/30"},
+		{name: "signup channel first", msgs: []storage.LiveMessage{elsewhere, show}, want: "https://discord.com/channels/1/2/31"},
+		{name: "other channel", msgs: []storage.LiveMessage{elsewhere}, want: "https://discord.com/channels/1/3/32"},
+	}
+
+	for _, tt := range tests {
+		if got := eventJumpLink(1, 2, tt.msgs); got != tt.want {
+			t.Errorf("%s: eventJumpLink() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+type reminderTrial struct {
+	storage.Trial
+	name, time, offsets string
+}
+
+func (t *reminderTrial) GetName(context.Context) string            { return t.name }
+func (t *reminderTrial) GetTime(context.Context) string            { return t.time }
+func (t *reminderTrial) GetReminderOffsets(context.Context) string { return t.offsets }
+
+type reminderTrialAPI struct {
+	storage.TrialAPITx
+	trials []storage.Trial
+}
+
+func (a *reminderTrialAPI) NewTransaction(context.Context, string, bool) (storage.TrialAPITx, error) {
+	return a, nil
+}
+
+func (a *reminderTrialAPI) GetTrials(context.Context) []storage.Trial { return a.trials }
+func (a *reminderTrialAPI) Rollback(context.Context) error            { return nil }
+
+type reminderJobAPI struct {
+	storage.JobAPI
+	canceled []string
+	added    []string
+}
+
+func (a *reminderJobAPI) CancelEventJobs(_ context.Context, _, eventName string, kinds ...string) (int64, error) {
+	if len(kinds) != 1 || kinds[0] != jobKindReminder {
+		return 0, nil // only reminders may be touched
+	}
+
+	a.canceled = append(a.canceled, eventName)
+	return 1, nil
+}
+
+func (a *reminderJobAPI) AddJob(_ context.Context, job storage.Job) (int64, error) {
+	a.added = append(a.added, job.EventName+" "+job.Payload)
+	return int64(len(a.added)), nil
+}
+
+func TestRescheduleDefaultReminders(t *testing.T) {
+	t.Parallel()
+
+	future := time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339)
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+
+	trials := &reminderTrialAPI{trials: []storage.Trial{
+		&reminderTrial{name: "Raid", time: future},
+		&reminderTrial{name: "Own", time: future, offsets: "2h"},
+		&reminderTrial{name: "Done", time: past},
+		&reminderTrial{name: "Whenever", time: "soon"},
+	}}
+	jobs := &reminderJobAPI{}
+
+	if err := rescheduleDefaultReminders(context.Background(), trials, jobs, 1, storage.GuildSettings{ReminderOffsets: "1h,30m"}); err != nil {
+		t.Fatalf("rescheduleDefaultReminders() error = %v", err)
+	}
+
+	if want := []string{"Raid"}; !reflect.DeepEqual(jobs.canceled, want) {
+		t.Errorf("rescheduleDefaultReminders() canceled = %v, want %v", jobs.canceled, want)
+	}
+
+	sort.Strings(jobs.added)
+	if want := []string{"Raid 1h0m0s", "Raid 30m0s"}; !reflect.DeepEqual(jobs.added, want) {
+		t.Errorf("rescheduleDefaultReminders() added = %v, want %v", jobs.added, want)
+	}
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/bot/session"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/livemessages"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// ErrUnknownLiveKind is the error returned when asked to render an unknown kind of live message
+var ErrUnknownLiveKind = errors.New("unknown live message kind")
+
+// liveResponse is an event embed whose message is edited as the roster changes
+type liveResponse struct {
+	*eventEmbed
+	kind string
+}
+
+var _ msghandler.LiveResponse = (*liveResponse)(nil)
+
+func newLiveResponse(r *eventEmbed, kind string) *liveResponse {
+	return &liveResponse{
+		eventEmbed: r,
+		kind:       kind,
+	}
+}
+
+func (r *liveResponse) LiveEvent() (string, string) {
+	return r.eventName, r.kind
+}
+
+type liveRendererDependencies interface {
+	TrialAPI() storage.TrialAPI
+	GuildAPI() storage.GuildAPI
+	BotSession() *session.Session
+	Census() *telemetry.Census
+}
+
+type liveRenderer struct {
+	deps liveRendererDependencies
+}
+
+var _ livemessages.Renderer = (*liveRenderer)(nil)
+
+// NewLiveRenderer creates the renderer for live announcement and show messages
+func NewLiveRenderer(deps liveRendererDependencies) livemessages.Renderer {
+	return &liveRenderer{
+		deps: deps,
+	}
+}
+
+func (l *liveRenderer) Render(ctx context.Context, gid snowflake.Snowflake, eventName, kind string) (cmdhandler.Response, error) {
+	ctx, span := l.deps.Census().StartSpan(ctx, "liveRenderer.Render", "guild_id", gid.ToString(), "kind", kind)
+	defer span.End()
+
+	gsettings, err := storage.GetSettings(ctx, l.deps.GuildAPI(), gid)
+	if err != nil {
+		return nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := l.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		return nil, err
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, eventName)
+	if err != nil {
+		return nil, err
+	}
+
+	var r *eventEmbed
+	switch kind {
+	case storage.LiveMessageAnnounce:
+		r, err = formatAnnouncement(ctx, l.deps.BotSession(), gid, trial, gsettings, "")
+		if err != nil {
+			return nil, err
+		}
+	case storage.LiveMessageShow:
+		r = formatTrialDisplay(ctx, i18n.NewPrinter(gsettings.Language), trial, true)
+	default:
+		return nil, errors.WithDetails(ErrUnknownLiveKind, "kind", kind)
+	}
+
+	r.SetColor(okColor)
+
+	return r, nil
+}
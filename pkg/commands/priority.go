@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/bot"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	log "github.com/gsmcwhirter/go-util/v8/logging"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// applyPrioritySettings sets the priority tiers of an event and when its priority window ends
+func applyPrioritySettings(ctx context.Context, trial storage.Trial, settings eventSettings) error {
+	if settings.PriorityTiers != nil {
+		if err := trial.SetPriorityTiers(ctx, *settings.PriorityTiers); err != nil {
+			return err
+		}
+	}
+
+	if settings.PriorityHoursBefore != nil {
+		var hours uint64
+		if v := strings.TrimSpace(*settings.PriorityHoursBefore); v != "" {
+			var err error
+			hours, err = strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return errors.Wrap(err, "could not parse priorityhoursbefore", "val", v)
+			}
+		}
+
+		trial.SetPriorityHoursBefore(ctx, hours)
+	}
+
+	return nil
+}
+
+// priorityWindowOpen determines whether signups still get a priority tier: until the set number
+// of hours before the event, or always if that is not set (or the event time is not a known time)
+func priorityWindowOpen(ctx context.Context, trial storage.Trial, now time.Time) bool {
+	hours := trial.GetPriorityHoursBefore(ctx)
+	if hours == 0 {
+		return true
+	}
+
+	start, ok := storage.ParseEventTime(trial.GetTime(ctx))
+	if !ok {
+		return true
+	}
+
+	return now.Before(start.Add(-time.Duration(hours) * time.Hour))
+}
+
+// setSignupPriority records which priority tier a signup is in, based on the member's discord
+// roles. Signups after the priority window keep whatever tier they already had, and signups
+// without memberRoles get none.
+func setSignupPriority(ctx context.Context, trial storage.Trial, userMentionStr string, memberRoles memberRolesFunc, now time.Time) error {
+	if memberRoles == nil || !priorityWindowOpen(ctx, trial, now) {
+		return nil
+	}
+
+	tiers, err := storage.ParsePriorityTiers(trial.GetPriorityTiers(ctx))
+	if err != nil || len(tiers) == 0 {
+		return nil
+	}
+
+	rids, err := memberRoles(ctx)
+	if err != nil {
+		return err
+	}
+
+	ridStrs := make([]string, 0, len(rids))
+	for _, rid := range rids {
+		ridStrs = append(ridStrs, rid.ToString())
+	}
+
+	trial.SetSignupPriorityRole(ctx, userMentionStr, storage.PriorityRole(tiers, ridStrs))
+
+	return nil
+}
+
+// adminMemberRoles looks up the discord roles of a member an admin is signing up, so that they get
+// their priority tier as well; a member that cannot be looked up just gets no tier
+func adminMemberRoles(logger log.Logger, b *bot.DiscordBot, gid snowflake.Snowflake, userMentionStr string) memberRolesFunc {
+	uid, err := userFromMention(userMentionStr)
+	if err != nil {
+		return nil
+	}
+
+	lookup := guildMemberRoles(b, gid, uid)
+
+	return func(ctx context.Context) ([]snowflake.Snowflake, error) {
+		rids, err := lookup(ctx)
+		if err != nil {
+			level.Info(logger).Message("could not look up member roles for priority tier", "member", userMentionStr, "error", err.Error())
+			return nil, nil
+		}
+
+		return rids, nil
+	}
+}
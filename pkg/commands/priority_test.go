@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type testSignup struct {
+	name, role, priorityRole string
+	alternates               []string
+}
+
+func (s *testSignup) GetName(context.Context) string          { return s.name }
+func (s *testSignup) GetRole(context.Context) string          { return s.role }
+func (s *testSignup) GetSignedUpAt(context.Context) time.Time { return time.Time{} }
+func (s *testSignup) GetNote(context.Context) string          { return "" }
+func (s *testSignup) GetAlternates(context.Context) []string  { return s.alternates }
+func (s *testSignup) GetPriorityRole(context.Context) string  { return s.priorityRole }
+
+// signupTrial is an event with just enough behind it to sign members up
+type signupTrial struct {
+	storage.Trial
+	roleCounts    []storage.RoleCount
+	priorityTiers string
+	signups       []*testSignup
+}
+
+func (t *signupTrial) signup(name string) *testSignup {
+	for _, s := range t.signups {
+		if s.name == name {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func (t *signupTrial) GetName(context.Context) string                    { return "Raid" }
+func (t *signupTrial) GetTime(context.Context) string                    { return "" }
+func (t *signupTrial) GetRoleCounts(context.Context) []storage.RoleCount { return t.roleCounts }
+func (t *signupTrial) GetRoleGroups(context.Context) []storage.RoleGroup { return nil }
+func (t *signupTrial) GetPriorityTiers(context.Context) string           { return t.priorityTiers }
+func (t *signupTrial) GetPriorityHoursBefore(context.Context) uint64     { return 0 }
+func (t *signupTrial) AddSignup(_ context.Context, name, role string) {
+	t.signups = append(t.signups, &testSignup{name: name, role: role})
+}
+
+func (t *signupTrial) SetSignupAlternates(_ context.Context, name string, roles []string) {
+	t.signup(name).alternates = roles
+}
+
+func (t *signupTrial) SetSignupPriorityRole(_ context.Context, name, roleID string) {
+	t.signup(name).priorityRole = roleID
+}
+
+func (t *signupTrial) GetSignups(context.Context) []storage.TrialSignup {
+	signups := make([]storage.TrialSignup, 0, len(t.signups))
+	for _, s := range t.signups {
+		signups = append(signups, s)
+	}
+
+	return signups
+}
+
+func TestSignupUser_admin(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	officer := func(context.Context) ([]snowflake.Snowflake, error) { return []snowflake.Snowflake{10}, nil }
+
+	trial := &signupTrial{
+		roleCounts:    []storage.RoleCount{testRoleCount{role: "Tank", required: []string{"99"}}},
+		priorityTiers: "<@&10>",
+	}
+
+	// members signing themselves up must have the required role
+	if _, _, err := signupUser(ctx, trial, "<@1>", "tank", nil, officer, true); !stderrors.Is(err, ErrMissingRequiredRole) {
+		t.Fatalf("signupUser() error = %v, want %v", err, ErrMissingRequiredRole)
+	}
+
+	// an admin signup of a member whose roles could not be looked up gets no tier
+	if _, _, err := signupUser(ctx, trial, "<@2>", "tank", nil, nil, false); err != nil {
+		t.Fatalf("signupUser() error = %v", err)
+	}
+
+	if got := trial.signup("<@2>").priorityRole; got != "" {
+		t.Errorf("priority role = %q, want none", got)
+	}
+
+	// an admin signup skips the requirements, but still puts the member in their priority tier,
+	// ahead of the member who signed up first
+	role, overflow, err := signupUser(ctx, trial, "<@1>", "tank", nil, officer, false)
+	if err != nil {
+		t.Fatalf("signupUser() error = %v", err)
+	}
+
+	if role != "Tank" || overflow {
+		t.Errorf("signupUser() = %q, %v; want %q, false", role, overflow, "Tank")
+	}
+
+	if got := trial.signup("<@1>").priorityRole; got != "10" {
+		t.Errorf("priority role = %q, want %q", got, "10")
+	}
+
+	if got := trialRoster(ctx, trial).AssignedRole("<@2>"); got != "" {
+		t.Errorf("AssignedRole(<@2>) = %q, want overflow", got)
+	}
+}
@@ -7,18 +7,28 @@ import (
 	"github.com/gsmcwhirter/discord-bot-lib/v23/bot"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/bot/session"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 	"github.com/gsmcwhirter/go-util/v8/telemetry"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/livemessages"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/reactions"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 )
 
 type reactionDependencies interface {
 	Logger() Logger
 	TrialAPI() storage.TrialAPI
 	GuildAPI() storage.GuildAPI
+	ActivityAPI() storage.ActivityAPI
+	EventMessageAPI() storage.EventMessageAPI
+	TemplateAPI() storage.TemplateAPI
+	Webhooks() *webhooks.Queue
+	LiveMessages() *livemessages.Updater
+	ModalDrafts() *components.Drafts
 	BotSession() *session.Session
 	Bot() *bot.DiscordBot
 	Census() *telemetry.Census
@@ -52,7 +62,27 @@ func (h *reactionHandler) HandleReactionRemove(r reactions.Reaction) (cmdhandler
 	return h.withdraw(r)
 }
 
-func (h *reactionHandler) getTrialNameForReaction(ctx context.Context, logger Logger, msg reactions.Reaction) (string, error) {
+// postedMessage identifies a message that was reacted to or clicked on
+type postedMessage interface {
+	ChannelID() snowflake.Snowflake
+	MessageID() snowflake.Snowflake
+}
+
+func (h *reactionHandler) getTrialNameForMessage(ctx context.Context, logger Logger, msg postedMessage) (string, error) {
+	trialName, err := h.deps.EventMessageAPI().EventForMessage(ctx, msg.ChannelID().ToString(), msg.MessageID().ToString())
+	if err == nil {
+		return trialName, nil
+	}
+
+	if err != storage.ErrEventMessageNotExist {
+		level.Error(logger).Err("could not look up event message", err)
+	}
+
+	// messages posted before they were indexed can still be identified by their footer
+	return h.getTrialNameFromFooter(ctx, logger, msg)
+}
+
+func (h *reactionHandler) getTrialNameFromFooter(ctx context.Context, logger Logger, msg postedMessage) (string, error) {
 	msgInfo, err := h.deps.Bot().API().GetMessage(ctx, msg.ChannelID(), msg.MessageID())
 	if err != nil {
 		level.Error(logger).Err("could not get message information", err)
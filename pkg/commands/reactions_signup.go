@@ -2,15 +2,16 @@ package commands
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/gsmcwhirter/go-util/v8/deferutil"
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/reactions"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 )
@@ -26,7 +27,7 @@ func (c *reactionHandler) signup(msg reactions.Reaction) (cmdhandler.Response, e
 	logger := reactions.LoggerWithReaction(msg, c.deps.Logger())
 	level.Info(logger).Message("handling reaction", "command", "signup")
 
-	trialName, err := c.getTrialNameForReaction(ctx, logger, msg)
+	trialName, err := c.getTrialNameForMessage(ctx, logger, msg)
 	if err != nil {
 		return r, msghandler.ErrNoResponse
 	}
@@ -56,8 +57,6 @@ func (c *reactionHandler) signup(msg reactions.Reaction) (cmdhandler.Response, e
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
 
-	var descStr string
-
 	trial, err := t.GetTrial(ctx, trialName)
 	if err != nil {
 		return r, err
@@ -72,8 +71,7 @@ func (c *reactionHandler) signup(msg reactions.Reaction) (cmdhandler.Response, e
 	role := ""
 	roleCounts := trial.GetRoleCounts(ctx)
 	for _, rc := range roleCounts {
-		rcEmoji := rc.GetEmoji(ctx)
-		if rcEmoji == msg.Emoji() || strings.HasPrefix(rcEmoji, fmt.Sprintf("<:%s:", msg.Emoji())) {
+		if emojiMatches(rc.GetEmoji(ctx), msg.Emoji()) {
 			role = rc.GetRole(ctx)
 		}
 	}
@@ -87,10 +85,14 @@ func (c *reactionHandler) signup(msg reactions.Reaction) (cmdhandler.Response, e
 	}
 
 	if trial.GetState(ctx) != storage.TrialStateOpen {
-		return r, errors.New("cannot sign up for a closed trial")
+		return r, ErrSignupClosed
+	}
+
+	if err = checkSignupLimit(ctx, t, gsettings, trial, cmdhandler.UserMentionString(msg.UserID())); err != nil {
+		return r, err
 	}
 
-	overflow, err := signupUser(ctx, trial, cmdhandler.UserMentionString(msg.UserID()), role)
+	_, overflow, err := signupUser(ctx, trial, cmdhandler.UserMentionString(msg.UserID()), role, roleAliases(gsettings), guildMemberRoles(c.deps.Bot(), msg.GuildID(), msg.UserID()), true)
 	if err != nil {
 		return r, err
 	}
@@ -101,18 +103,22 @@ func (c *reactionHandler) signup(msg reactions.Reaction) (cmdhandler.Response, e
 
 	if overflow {
 		level.Info(logger).Message("signed up", "overflow", true, "role", role, "trial_name", trialName)
-		descStr += fmt.Sprintf("Signed up as OVERFLOW for %s in %s\n", role, trialName)
 	} else {
 		level.Info(logger).Message("signed up", "overflow", false, "role", role, "trial_name", trialName)
-		descStr += fmt.Sprintf("Signed up for %s in %s\n", role, trialName)
 	}
 
 	if err = t.Commit(ctx); err != nil {
 		return r, errors.Wrap(err, "could not save trial signup")
 	}
 
+	recordActivity(ctx, logger, c.deps.ActivityAPI(), msg.GuildID(), trial, cmdhandler.UserMentionString(msg.UserID()), role, storage.ActivitySignup, false)
+	c.deps.Webhooks().Notify(webhookEvent(ctx, msg.GuildID(), webhooks.KindSignup, trial, cmdhandler.UserMentionString(msg.UserID()), role))
+	c.deps.LiveMessages().Refresh(msg.GuildID(), trial.GetName(ctx))
+
+	descStr := signupConfirmation(ctx, logger, c.deps.TemplateAPI(), c.deps.BotSession(), msg.GuildID(), gsettings, trial, role, overflow) + "\n"
+
 	if gsettings.ShowAfterSignup == "true" {
-		r2 := formatTrialDisplay(ctx, trial, true)
+		r2 := formatTrialDisplay(ctx, i18n.NewPrinter(gsettings.Language), trial, true)
 
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
 		r2.To = cmdhandler.UserMentionString(msg.UserID())
@@ -2,14 +2,17 @@ package commands
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v8/deferutil"
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/reactions"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 )
@@ -25,7 +28,7 @@ func (c *reactionHandler) withdraw(msg reactions.Reaction) (cmdhandler.Response,
 	logger := reactions.LoggerWithReaction(msg, c.deps.Logger())
 	level.Info(logger).Message("handling reaction", "command", "withdraw")
 
-	trialName, err := c.getTrialNameForReaction(ctx, logger, msg)
+	trialName, err := c.getTrialNameForMessage(ctx, logger, msg)
 	if err != nil {
 		return r, msghandler.ErrNoResponse
 	}
@@ -66,9 +69,11 @@ func (c *reactionHandler) withdraw(msg reactions.Reaction) (cmdhandler.Response,
 	}
 
 	if trial.GetState(ctx) != storage.TrialStateOpen {
-		return r, errors.New("cannot withdraw from a closed trial")
+		return r, ErrWithdrawClosed
 	}
 
+	role, signedUp := signupRole(ctx, trial, cmdhandler.UserMentionString(msg.UserID()))
+
 	trial.RemoveSignup(ctx, cmdhandler.UserMentionString(msg.UserID()))
 
 	if err = t.SaveTrial(ctx, trial); err != nil {
@@ -79,13 +84,19 @@ func (c *reactionHandler) withdraw(msg reactions.Reaction) (cmdhandler.Response,
 		return r, errors.Wrap(err, "could not save trial withdraw")
 	}
 
+	if signedUp {
+		recordActivity(ctx, logger, c.deps.ActivityAPI(), msg.GuildID(), trial, cmdhandler.UserMentionString(msg.UserID()), role, storage.ActivityWithdraw, withdrawIsLate(ctx, trial, time.Now()))
+		c.deps.Webhooks().Notify(webhookEvent(ctx, msg.GuildID(), webhooks.KindWithdraw, trial, cmdhandler.UserMentionString(msg.UserID()), role))
+		c.deps.LiveMessages().Refresh(msg.GuildID(), trial.GetName(ctx))
+	}
+
 	level.Info(logger).Message("withdrew", "trial_name", trialName)
-	descStr := fmt.Sprintf("Withdrew from %s", trialName)
+	descStr := i18n.Sprintf(gsettings.Language, i18n.Withdrew, trialName)
 
 	if gsettings.ShowAfterWithdraw == "true" {
 		level.Debug(logger).Message("auto-show after withdraw", "trial_name", trialName)
 
-		r2 := formatTrialDisplay(ctx, trial, true)
+		r2 := formatTrialDisplay(ctx, i18n.NewPrinter(gsettings.Language), trial, true)
 		r2.To = cmdhandler.UserMentionString(msg.UserID())
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
 		r2.Color = okColor
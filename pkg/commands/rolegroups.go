@@ -0,0 +1,124 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// ErrUnknownRoleGroup is the error returned when a role is put in a role group the event does not have
+var ErrUnknownRoleGroup = errors.New("unknown role group")
+
+type roleGroupCt struct {
+	name string
+	ct   uint64
+}
+
+// parseRoleGroupsString parses a comma-separated list of GROUP:COUNT pairs (e.g., "DPS:8, Support:4");
+// a count of 0 removes the group
+func parseRoleGroupsString(args string) ([]roleGroupCt, error) {
+	parts := strings.Split(strings.TrimSpace(args), ",")
+	groups := make([]roleGroupCt, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		pair := strings.SplitN(part, ":", 2)
+		if len(pair) != 2 || strings.TrimSpace(pair[0]) == "" {
+			return groups, errors.New("could not parse role groups")
+		}
+
+		ct, err := strconv.ParseUint(strings.TrimSpace(pair[1]), 10, 64)
+		if err != nil {
+			return groups, errors.Wrap(err, "could not parse role group count", "group", pair[0])
+		}
+
+		groups = append(groups, roleGroupCt{name: strings.TrimSpace(pair[0]), ct: ct})
+	}
+
+	return groups, nil
+}
+
+// applyRoleGroups sets (or removes) the role groups of an event
+func applyRoleGroups(ctx context.Context, trial storage.Trial, groups []roleGroupCt) {
+	for _, g := range groups {
+		if g.ct == 0 {
+			trial.RemoveRoleGroup(ctx, g.name)
+		} else {
+			trial.SetRoleGroup(ctx, g.name, g.ct)
+		}
+	}
+}
+
+// checkRoleGroups makes sure every role in a group belongs to a group the event has, and that
+// only roles in a group go without a count of their own
+func checkRoleGroups(ctx context.Context, trial storage.Trial) error {
+	groups := storage.RoleGroupsByName(ctx, trial.GetRoleGroups(ctx))
+
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		group := rc.GetGroup(ctx)
+		if group == "" {
+			if rc.GetCount(ctx) == 0 {
+				return fmt.Errorf("role '%s' needs a count, since it is not in a role group", rc.GetRole(ctx))
+			}
+			continue
+		}
+
+		if _, ok := groups[strings.ToLower(group)]; !ok {
+			return errors.Wrap(ErrUnknownRoleGroup, fmt.Sprintf("role '%s' is in group '%s', which is not one of the event's role groups", rc.GetRole(ctx), group))
+		}
+	}
+
+	return nil
+}
+
+// roleTreeEntry is a role on its own, or a role group and the roles in it
+type roleTreeEntry struct {
+	group storage.RoleGroup
+	roles []storage.RoleCount
+}
+
+// roleTree arranges the roles of an event (in role order) under their groups; each group is
+// placed where its first role is
+func roleTree(ctx context.Context, roleCounts []storage.RoleCount, groups []storage.RoleGroup) []roleTreeEntry {
+	byName := storage.RoleGroupsByName(ctx, groups)
+
+	entries := make([]roleTreeEntry, 0, len(roleCounts))
+	groupEntry := make(map[string]int, len(byName))
+
+	for _, rc := range roleCounts {
+		group := strings.ToLower(rc.GetGroup(ctx))
+		g, ok := byName[group]
+		if !ok {
+			entries = append(entries, roleTreeEntry{roles: []storage.RoleCount{rc}})
+			continue
+		}
+
+		if i, ok := groupEntry[group]; ok {
+			entries[i].roles = append(entries[i].roles, rc)
+			continue
+		}
+
+		groupEntry[group] = len(entries)
+		entries = append(entries, roleTreeEntry{group: g, roles: []storage.RoleCount{rc}})
+	}
+
+	return entries
+}
+
+// roleFill is how full a role is for display, e.g. "2/4", or just "2" for a role limited only by its group
+func roleFill(ctx context.Context, n int, rc storage.RoleCount) string {
+	if ct := storage.RoleCountString(ctx, rc); ct != "*" {
+		return fmt.Sprintf("%d/%s", n, ct)
+	}
+
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_parseRoleGroupsString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		args    string
+		want    []roleGroupCt
+		wantErr bool
+	}{
+		{
+			name: "groups",
+			args: "DPS:8, Support : 4,",
+			want: []roleGroupCt{{name: "DPS", ct: 8}, {name: "Support", ct: 4}},
+		},
+		{
+			name: "remove",
+			args: "DPS:0",
+			want: []roleGroupCt{{name: "DPS", ct: 0}},
+		},
+		{
+			name:    "missing count",
+			args:    "DPS",
+			wantErr: true,
+		},
+		{
+			name:    "bad count",
+			args:    "DPS:lots",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseRoleGroupsString(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRoleGroupsString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseRoleGroupsString() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
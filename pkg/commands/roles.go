@@ -0,0 +1,116 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// roleAliases is the guild-wide role alias dictionary (it was validated when it was set)
+func roleAliases(gsettings storage.GuildSettings) map[string]string {
+	aliases, err := storage.ParseRoleAliases(gsettings.RoleAliases)
+	if err != nil {
+		return nil
+	}
+
+	return aliases
+}
+
+// roleMatches reports whether the role's name or one of its aliases contains typed (lower-cased)
+func roleMatches(ctx context.Context, rc storage.RoleCount, typed string) bool {
+	if strings.Contains(strings.ToLower(rc.GetRole(ctx)), typed) {
+		return true
+	}
+
+	for _, alias := range rc.GetAliases(ctx) {
+		if strings.Contains(alias, typed) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findRole looks up an event role by its name or one of its aliases, then by the guild's role
+// aliases, and then by a prefix of exactly one role name. If nothing matches, the error
+// suggests the closest role name.
+func findRole(ctx context.Context, role string, roleCounts []storage.RoleCount, aliases map[string]string) (storage.RoleCount, error) {
+	if rc, ok := roleCountByName(ctx, role, roleCounts); ok {
+		return rc, nil
+	}
+
+	roleLower := strings.ToLower(strings.TrimSpace(role))
+	if target, ok := aliases[roleLower]; ok {
+		if rc, ok := roleCountByName(ctx, target, roleCounts); ok {
+			return rc, nil
+		}
+	}
+
+	if roleLower == "" || len(roleCounts) == 0 {
+		return nil, ErrUnknownRole
+	}
+
+	var prefixed []storage.RoleCount
+	for _, rc := range roleCounts {
+		if strings.HasPrefix(strings.ToLower(rc.GetRole(ctx)), roleLower) {
+			prefixed = append(prefixed, rc)
+		}
+	}
+
+	if len(prefixed) == 1 {
+		return prefixed[0], nil
+	}
+
+	// an ambiguous prefix suggests one of the roles it could be
+	candidates := roleCounts
+	if len(prefixed) > 1 {
+		candidates = prefixed
+	}
+
+	best, bestDist := "", -1
+	for _, rc := range candidates {
+		if d := editDistance(roleLower, strings.ToLower(rc.GetRole(ctx))); bestDist < 0 || d < bestDist {
+			best, bestDist = rc.GetRole(ctx), d
+		}
+	}
+
+	return nil, i18n.NewError(i18n.UnknownRoleSuggest, role, best)
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
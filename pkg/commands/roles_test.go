@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type testRoleCount struct {
+	role     string
+	aliases  []string
+	required []string
+}
+
+func (rc testRoleCount) GetRole(context.Context) string            { return rc.role }
+func (rc testRoleCount) GetCount(context.Context) uint64           { return 1 }
+func (rc testRoleCount) GetEmoji(context.Context) string           { return "" }
+func (rc testRoleCount) GetRequiredRoles(context.Context) []string { return rc.required }
+func (rc testRoleCount) GetAliases(context.Context) []string       { return rc.aliases }
+func (rc testRoleCount) GetGroup(context.Context) string           { return "" }
+func (rc testRoleCount) Index() int                                { return 0 }
+
+func Test_findRole(t *testing.T) {
+	t.Parallel()
+
+	roleCounts := []storage.RoleCount{
+		testRoleCount{role: "DPS", aliases: []string{"dd"}},
+		testRoleCount{role: "Healer"},
+		testRoleCount{role: "Hunter"},
+		testRoleCount{role: "Tank"},
+	}
+	aliases := map[string]string{"damage": "dps", "heals": "healer", "nope": "bard"}
+
+	tests := []struct {
+		name    string
+		role    string
+		want    string
+		wantErr string
+	}{
+		{name: "exact", role: "tank", want: "Tank"},
+		{name: "role alias", role: "DD", want: "DPS"},
+		{name: "guild alias", role: "damage", want: "DPS"},
+		{name: "guild alias to another name", role: "heals", want: "Healer"},
+		{name: "unique prefix", role: "ta", want: "Tank"},
+		{name: "ambiguous prefix", role: "h", wantErr: "unknown role 'h' (did you mean 'Healer'?)"},
+		{name: "typo", role: "heeler", wantErr: "unknown role 'heeler' (did you mean 'Healer'?)"},
+		{name: "alias to missing role", role: "nope", wantErr: "unknown role 'nope' (did you mean 'DPS'?)"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rc, err := findRole(context.Background(), tt.role, roleCounts, aliases)
+			if tt.wantErr != "" {
+				e, ok := i18n.Find(err)
+				if !ok {
+					t.Fatalf("findRole() error = %v, want %q", err, tt.wantErr)
+				}
+
+				if got := e.Localize(i18n.Default); got != tt.wantErr {
+					t.Errorf("findRole() error = %q, want %q", got, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("findRole() error = %v", err)
+			}
+
+			if got := rc.GetRole(context.Background()); got != tt.want {
+				t.Errorf("findRole() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"dps", "", 3},
+		{"healer", "heeler", 1},
+		{"kitten", "sitting", 3},
+		{"tänk", "tank", 1},
+	} {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// Discord limits on embeds
+const (
+	maxEmbedFields     = 25
+	maxEmbedFieldValue = 1024
+	maxEmbedChars      = 6000
+)
+
+// rosterSlack is room left on each page for the page marker in the title and for text
+// that callers prepend to the description (e.g., "... signed up as ...")
+const rosterSlack = 300
+
+const fieldSpacer = "\n_ _\n"
+
+func embedLen(s string) int {
+	return utf8.RuneCountInString(s)
+}
+
+// rosterFields are the fields of an event roster: one per role (split into several if needed),
+// with the roles of a role group listed under a field for the group, followed by the overflow
+// for each role
+func rosterFields(ctx context.Context, p i18n.Printer, trial storage.Trial) ([]cmdhandler.EmbedField, []string) {
+	fields := []cmdhandler.EmbedField{}
+	overflowFields := []cmdhandler.EmbedField{}
+
+	roleCounts := trial.GetRoleCounts(ctx) // already sorted by name
+	groups := trial.GetRoleGroups(ctx)
+	roster := storage.TrialRoster(ctx, trial)
+
+	emojis := make([]string, 0, len(roleCounts))
+
+	for _, entry := range roleTree(ctx, roleCounts, groups) {
+		indent := ""
+		if entry.group != nil {
+			filled := 0
+			for _, rc := range entry.roles {
+				filled += len(roster.Main(rc.GetRole(ctx)))
+			}
+
+			fields = append(fields, cmdhandler.EmbedField{
+				Name: fmt.Sprintf("*%s* (%d/%d)", entry.group.GetName(ctx), filled, entry.group.GetCount(ctx)),
+				Val:  "_ _",
+			})
+			indent = "↳ "
+		}
+
+		for _, rc := range entry.roles {
+			suNames := rosterNames(ctx, roster.Main(rc.GetRole(ctx)))
+			ofNames := rosterNames(ctx, roster.Overflow(rc.GetRole(ctx)))
+
+			emoji := rc.GetEmoji(ctx)
+
+			if len(suNames) > 0 {
+				fields = append(fields, splitField(cmdhandler.EmbedField{
+					Name: fmt.Sprintf("%s*%s* %s (%s)", indent, rc.GetRole(ctx), emoji, roleFill(ctx, len(suNames), rc)),
+					Val:  strings.Join(suNames, "\n") + fieldSpacer,
+				})...)
+			} else {
+				fields = append(fields, cmdhandler.EmbedField{
+					Name: fmt.Sprintf("%s*%s* %s (%s)", indent, rc.GetRole(ctx), emoji, roleFill(ctx, len(suNames), rc)),
+					Val:  p.Sprintf(i18n.RoleEmpty) + fieldSpacer,
+				})
+			}
+
+			if len(ofNames) > 0 {
+				overflowFields = append(overflowFields, splitField(cmdhandler.EmbedField{
+					Name: fmt.Sprintf("*%s* %s (%d)", p.Sprintf(i18n.RoleOverflow, rc.GetRole(ctx)), emoji, len(ofNames)),
+					Val:  strings.Join(ofNames, "\n") + fieldSpacer,
+				})...)
+			}
+
+			if emoji != "" {
+				emojis = append(emojis, emoji)
+			}
+		}
+	}
+
+	return append(fields, overflowFields...), emojis
+}
+
+// rosterNames lists signups for display; those who offered alternate roles are shown with their
+// roles in order of preference
+func rosterNames(ctx context.Context, signups []storage.TrialSignup) []string {
+	names := make([]string, 0, len(signups))
+	for _, su := range signups {
+		alts := su.GetAlternates(ctx)
+		if len(alts) == 0 {
+			names = append(names, su.GetName(ctx))
+			continue
+		}
+
+		names = append(names, fmt.Sprintf("%s (%s > %s)", su.GetName(ctx), su.GetRole(ctx), strings.Join(alts, " > ")))
+	}
+
+	return names
+}
+
+// splitField breaks a field whose value is too long into continuation fields, at line boundaries
+func splitField(f cmdhandler.EmbedField) []cmdhandler.EmbedField {
+	if embedLen(f.Val) <= maxEmbedFieldValue {
+		return []cmdhandler.EmbedField{f}
+	}
+
+	maxLen := maxEmbedFieldValue - embedLen(fieldSpacer)
+
+	var fields []cmdhandler.EmbedField
+	var chunk []string
+	chunkLen := 0
+
+	flush := func() {
+		name := f.Name
+		if len(fields) > 0 {
+			name += " (cont.)"
+		}
+
+		fields = append(fields, cmdhandler.EmbedField{
+			Name: name,
+			Val:  strings.Join(chunk, "\n") + fieldSpacer,
+		})
+		chunk = nil
+		chunkLen = 0
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(f.Val, fieldSpacer), "\n") {
+		line = truncateRunes(line, maxLen)
+
+		lineLen := embedLen(line)
+		if len(chunk) > 0 {
+			lineLen++ // newline
+		}
+
+		if len(chunk) > 0 && chunkLen+lineLen > maxLen {
+			flush()
+			lineLen = embedLen(line)
+		}
+
+		chunk = append(chunk, line)
+		chunkLen += lineLen
+	}
+
+	if len(chunk) > 0 {
+		flush()
+	}
+
+	return fields
+}
+
+// paginateFields packs fields into pages, keeping their order, such that each page fits in an
+// embed which already has reserved characters used by its title, description and footer
+func paginateFields(fields []cmdhandler.EmbedField, reserved int) [][]cmdhandler.EmbedField {
+	pages := [][]cmdhandler.EmbedField{}
+
+	var page []cmdhandler.EmbedField
+	size := reserved
+	for _, f := range fields {
+		fieldLen := embedLen(f.Name) + embedLen(f.Val)
+		if len(page) > 0 && (len(page) == maxEmbedFields || size+fieldLen > maxEmbedChars) {
+			pages = append(pages, page)
+			page = nil
+			size = reserved
+		}
+
+		page = append(page, f)
+		size += fieldLen
+	}
+
+	if len(page) > 0 || len(pages) == 0 {
+		pages = append(pages, page)
+	}
+
+	return pages
+}
+
+// formatTrialDisplayPage shows one page of an event roster; page numbers start at 1, and
+// out-of-range pages are clamped
+func formatTrialDisplayPage(ctx context.Context, p i18n.Printer, trial storage.Trial, withState bool, page int) *eventEmbed {
+	r := &cmdhandler.EmbedResponse{}
+
+	if withState {
+		r.Title = fmt.Sprintf("__%s__ (%s)", trial.GetName(ctx), stateName(p, trial.GetState(ctx)))
+	} else {
+		r.Title = fmt.Sprintf("__%s__", trial.GetName(ctx))
+	}
+
+	desc := trial.GetDescription(ctx)
+	if t := trial.GetTime(ctx); t != "" {
+		desc = fmt.Sprintf("%s\n\n%s", p.Sprintf(i18n.EventTime, t), desc)
+	}
+
+	r.Description = desc
+	r.FooterText = fmt.Sprintf("event:%s", trial.GetName(ctx))
+
+	fields, emojis := rosterFields(ctx, p, trial)
+	pages := paginateFields(fields, embedLen(r.Title)+embedLen(r.Description)+embedLen(r.FooterText)+rosterSlack)
+
+	if page < 1 {
+		page = 1
+	}
+	if page > len(pages) {
+		page = len(pages)
+	}
+
+	r.Fields = pages[page-1]
+	if len(pages) > 1 {
+		r.Title = fmt.Sprintf("%s — %s", r.Title, p.Sprintf(i18n.Page, page, len(pages)))
+	}
+
+	if !trial.HideReactionsShow(ctx) {
+		r.Reactions = emojis
+	}
+
+	e := newEventEmbed(ctx, p, r, trial)
+	e.page, e.pages = page, len(pages)
+	if len(pages) > 1 {
+		e.buttons = eventButtons(ctx, p, trial, 1)
+		e.nav = pageButtons(p, trial.GetName(ctx), page, len(pages))
+	}
+
+	return e
+}
+
+// pageButtons is the row of previous/next buttons for a paginated roster; the event name is
+// included in the custom ids when it fits, since ephemeral messages cannot be looked up later
+func pageButtons(p i18n.Printer, eventName string, page, pages int) []components.ActionRow {
+	customID := func(p int) string {
+		id := components.CustomID(components.ActionPage, strconv.Itoa(p)+":"+eventName)
+		if len(id) > components.MaxCustomIDLen {
+			id = components.CustomID(components.ActionPage, strconv.Itoa(p))
+		}
+		return id
+	}
+
+	return components.Rows([]components.Button{
+		{
+			Type:     components.TypeButton,
+			Style:    components.StyleSecondary,
+			Label:    p.Sprintf(i18n.PagePrevious),
+			CustomID: customID(page - 1),
+			Disabled: page <= 1,
+		},
+		{
+			Type:     components.TypeButton,
+			Style:    components.StyleSecondary,
+			Label:    p.Sprintf(i18n.PageNext),
+			CustomID: customID(page + 1),
+			Disabled: page >= pages,
+		},
+	})
+}
+
+// stateName is the display name of an event state
+func stateName(p i18n.Printer, state storage.TrialState) string {
+	switch state {
+	case storage.TrialStateOpen:
+		return p.Sprintf(i18n.StateOpen)
+	case storage.TrialStateClosed:
+		return p.Sprintf(i18n.StateClosed)
+	default:
+		return string(state)
+	}
+}
+
+// parsePageArg splits the page number and (optional) event name out of a page button's custom id
+func parsePageArg(arg string) (int, string, error) {
+	parts := strings.SplitN(arg, ":", 2)
+
+	page, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", err
+	}
+
+	if len(parts) == 1 {
+		return page, "", nil
+	}
+
+	return page, parts[1], nil
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+)
+
+func Test_splitField(t *testing.T) {
+	t.Parallel()
+
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = fmt.Sprintf("<@%018d>", i)
+	}
+
+	f := cmdhandler.EmbedField{Name: "*dps*", Val: strings.Join(names, "\n") + fieldSpacer}
+	fields := splitField(f)
+	if len(fields) < 3 {
+		t.Fatalf("got %d fields, want at least 3", len(fields))
+	}
+
+	var got []string
+	for i, sf := range fields {
+		if embedLen(sf.Val) > maxEmbedFieldValue {
+			t.Errorf("field %d is %d characters long", i, embedLen(sf.Val))
+		}
+
+		if i > 0 && sf.Name != "*dps* (cont.)" {
+			t.Errorf("field %d name = %q", i, sf.Name)
+		}
+
+		got = append(got, strings.Split(strings.TrimSuffix(sf.Val, fieldSpacer), "\n")...)
+	}
+
+	if strings.Join(got, ",") != strings.Join(names, ",") {
+		t.Error("names were lost or reordered while splitting")
+	}
+
+	short := cmdhandler.EmbedField{Name: "tank", Val: "a" + fieldSpacer}
+	if fields := splitField(short); len(fields) != 1 || fields[0] != short {
+		t.Errorf("short field was changed: %+v", fields)
+	}
+}
+
+func Test_paginateFields(t *testing.T) {
+	t.Parallel()
+
+	fields := make([]cmdhandler.EmbedField, 60)
+	for i := range fields {
+		fields[i] = cmdhandler.EmbedField{Name: fmt.Sprintf("role %d", i), Val: strings.Repeat("x", 200)}
+	}
+
+	pages := paginateFields(fields, 500)
+
+	i := 0
+	for p, page := range pages {
+		if len(page) > maxEmbedFields {
+			t.Errorf("page %d has %d fields", p, len(page))
+		}
+
+		size := 500
+		for _, f := range page {
+			if f.Name != fields[i].Name {
+				t.Errorf("page %d has %q where %q was expected", p, f.Name, fields[i].Name)
+			}
+			size += embedLen(f.Name) + embedLen(f.Val)
+			i++
+		}
+
+		if size > maxEmbedChars {
+			t.Errorf("page %d is %d characters long", p, size)
+		}
+	}
+
+	if i != len(fields) {
+		t.Errorf("paginated %d fields, want %d", i, len(fields))
+	}
+
+	if pages := paginateFields(nil, 0); len(pages) != 1 || len(pages[0]) != 0 {
+		t.Errorf("expected a single empty page, got %+v", pages)
+	}
+}
+
+func Test_parsePageArg(t *testing.T) {
+	t.Parallel()
+
+	page, name, err := parsePageArg("2:raid: night")
+	if err != nil || page != 2 || name != "raid: night" {
+		t.Errorf("got (%d, %q, %v)", page, name, err)
+	}
+
+	page, name, err = parsePageArg("3")
+	if err != nil || page != 3 || name != "" {
+		t.Errorf("got (%d, %q, %v)", page, name, err)
+	}
+
+	if _, _, err = parsePageArg("x"); err == nil {
+		t.Error("expected an error for a non-numeric page")
+	}
+}
@@ -0,0 +1,112 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/bot/session"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	log "github.com/gsmcwhirter/go-util/v8/logging"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/templates"
+)
+
+// eventTemplateData gathers the fields of an event that message templates can refer to
+func eventTemplateData(ctx context.Context, sess *session.Session, gid snowflake.Snowflake, trial storage.Trial) templates.Data {
+	d := templates.Data{
+		Name:          trial.GetName(ctx),
+		Time:          trial.GetTime(ctx),
+		Leader:        trial.GetLeader(ctx),
+		SignupChannel: "#" + trial.GetSignupChannel(ctx),
+	}
+
+	if sessionGuild, ok := sess.Guild(gid); ok {
+		if scID, ok := sessionGuild.ChannelWithName(trial.GetSignupChannel(ctx)); ok {
+			d.SignupChannel = cmdhandler.ChannelMentionString(scID)
+		}
+	}
+
+	roleCounts := trial.GetRoleCounts(ctx)
+	groups := trial.GetRoleGroups(ctx)
+	byName := storage.RoleGroupsByName(ctx, groups)
+
+	roster := trialRoster(ctx, trial)
+	for _, rc := range roleCounts {
+		suNames, ofNames := getTrialRoleSignups(ctx, roster, rc)
+
+		d.Roles = append(d.Roles, templates.Role{
+			Name:     rc.GetRole(ctx),
+			Emoji:    rc.GetEmoji(ctx),
+			Count:    int(storage.RoleCapacity(ctx, rc, byName)),
+			SignedUp: len(suNames),
+			Overflow: len(ofNames),
+		})
+
+		d.SignedUp += len(suNames)
+		d.Overflow += len(ofNames)
+	}
+	d.Slots = int(storage.TotalSlots(ctx, roleCounts, groups))
+
+	return d
+}
+
+// messageTemplate picks the template for a kind of message: the event's own (if trial is not nil),
+// then the guild's, then the default
+func messageTemplate(ctx context.Context, api storage.TemplateAPI, gid snowflake.Snowflake, trial storage.Trial, kind string) (string, error) {
+	if trial != nil {
+		if text := trial.GetTemplate(ctx, kind); text != "" {
+			return text, nil
+		}
+	}
+
+	tmpls, err := api.GuildTemplates(ctx, gid.ToString())
+	if err != nil {
+		return "", err
+	}
+
+	if text, ok := tmpls[kind]; ok {
+		return text, nil
+	}
+
+	return templates.Default(kind)
+}
+
+// renderMessage renders the template for a kind of message about an event; it returns "" when
+// there is no template, in which case the built-in message should be used
+func renderMessage(ctx context.Context, api storage.TemplateAPI, gid snowflake.Snowflake, trial storage.Trial, kind string, data templates.Data) (string, error) {
+	text, err := messageTemplate(ctx, api, gid, trial, kind)
+	if err != nil || text == "" {
+		return "", err
+	}
+
+	return templates.Render(text, data)
+}
+
+// signupConfirmation is the message telling someone they signed up; a broken template falls back
+// to the built-in message, since the signup itself succeeded
+func signupConfirmation(ctx context.Context, logger log.Logger, api storage.TemplateAPI, sess *session.Session, gid snowflake.Snowflake, gsettings storage.GuildSettings, trial storage.Trial, role string, overflow bool) string {
+	key := i18n.SignedUp
+	if overflow {
+		key = i18n.SignedUpOverflow
+	}
+	fallback := i18n.Sprintf(gsettings.Language, key, role, trial.GetName(ctx))
+
+	data := eventTemplateData(ctx, sess, gid, trial)
+	data.Role = role
+	data.IsOverflow = overflow
+
+	msg, err := renderMessage(ctx, api, gid, trial, templates.KindSignup, data)
+	if err != nil {
+		level.Error(logger).Err("could not render signup template", err, "trial_name", trial.GetName(ctx))
+		return fallback
+	}
+
+	if msg == "" {
+		return fallback
+	}
+
+	return msg
+}
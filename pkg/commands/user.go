@@ -26,14 +26,20 @@ func (c *UserCommands) HandleInteraction(ix *cmdhandler.Interaction) (cmdhandler
 	_ = opts
 
 	switch sc {
+	case "calendar":
+		return c.calendarInteraction(ix, opts)
 	case "list":
 		return c.listInteraction(ix, opts)
 	case "myevents":
 		return c.myEventsInteraction(ix, opts)
+	case "reminders":
+		return c.remindersInteraction(ix, opts)
 	case "show":
 		return c.showInteraction(ix, opts)
 	case "signup":
 		return c.signupInteraction(ix, opts)
+	case "stats":
+		return c.statsInteraction(ix, opts)
 	case "withdraw":
 		return c.withdrawInteraction(ix, opts)
 	default:
@@ -72,9 +78,11 @@ func (c *UserCommands) Autocomplete(ix *cmdhandler.Interaction) ([]entity.Applic
 func (c *UserCommands) AttachToCommandHandler(ch *cmdhandler.CommandHandler) {
 	ch.SetHandler("list", cmdhandler.NewMessageHandler(c.listHandler))
 	ch.SetHandler("myevents", cmdhandler.NewMessageHandler(c.myEventsHandler))
+	ch.SetHandler("reminders", cmdhandler.NewMessageHandler(c.remindersHandler))
 	ch.SetHandler("show", cmdhandler.NewMessageHandler(c.showHandler))
 	ch.SetHandler("signup", cmdhandler.NewMessageHandler(c.signupHandler))
 	ch.SetHandler("su", cmdhandler.NewMessageHandler(c.signupHandler))
+	ch.SetHandler("stats", cmdhandler.NewMessageHandler(c.statsHandler))
 	ch.SetHandler("withdraw", cmdhandler.NewMessageHandler(c.withdrawHandler))
 	ch.SetHandler("wd", cmdhandler.NewMessageHandler(c.withdrawHandler))
 }
@@ -85,6 +93,16 @@ func (c *UserCommands) GlobalCommands() []cmdhandler.InteractionCommandHandler {
 
 func (c *UserCommands) GuildCommands(gid snowflake.Snowflake) ([]cmdhandler.InteractionCommandHandler, error) {
 	return []cmdhandler.InteractionCommandHandler{
+		&InteractionCommandHandler{
+			command: entity.ApplicationCommand{
+				Type:              entity.CmdTypeChatInput,
+				Name:              "calendar",
+				Description:       "Get calendar feed links for events",
+				DefaultPermission: true,
+			},
+			handler:      c,
+			autocomplete: c,
+		},
 		&InteractionCommandHandler{
 			command: entity.ApplicationCommand{
 				Type:              entity.CmdTypeChatInput,
@@ -105,6 +123,23 @@ func (c *UserCommands) GuildCommands(gid snowflake.Snowflake) ([]cmdhandler.Inte
 			handler:      c,
 			autocomplete: c,
 		},
+		&InteractionCommandHandler{
+			command: entity.ApplicationCommand{
+				Type:        entity.CmdTypeChatInput,
+				Name:        "reminders",
+				Description: "Show or change whether you receive event reminders",
+				Options: []entity.ApplicationCommandOption{
+					{
+						Type:        entity.OptTypeBoolean,
+						Name:        "enabled",
+						Description: "Whether to receive reminders for events you are signed up for",
+					},
+				},
+				DefaultPermission: true,
+			},
+			handler:      c,
+			autocomplete: c,
+		},
 		&InteractionCommandHandler{
 			command: entity.ApplicationCommand{
 				Type:        entity.CmdTypeChatInput,
@@ -118,6 +153,11 @@ func (c *UserCommands) GuildCommands(gid snowflake.Snowflake) ([]cmdhandler.Inte
 						Required:     true,
 						Autocomplete: true,
 					},
+					{
+						Type:        entity.OptTypeInteger,
+						Name:        "page",
+						Description: "The page of the roster to show (for large events)",
+					},
 				},
 				DefaultPermission: true,
 			},
@@ -140,10 +180,37 @@ func (c *UserCommands) GuildCommands(gid snowflake.Snowflake) ([]cmdhandler.Inte
 					{
 						Type:         entity.OptTypeString,
 						Name:         "role",
-						Description:  "The role to sign up for",
+						Description:  "The role to sign up for, or roles in order of preference (e.g., healer > tank > dps)",
 						Required:     true,
 						Autocomplete: true,
 					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "note",
+						Description: "A short note for the event leads (e.g., your gear or availability)",
+					},
+				},
+				DefaultPermission: true,
+			},
+			handler:      c,
+			autocomplete: c,
+		},
+		&InteractionCommandHandler{
+			command: entity.ApplicationCommand{
+				Type:        entity.CmdTypeChatInput,
+				Name:        "stats",
+				Description: "Show signup and attendance stats for yourself or another member",
+				Options: []entity.ApplicationCommandOption{
+					{
+						Type:        entity.OptTypeUser,
+						Name:        "user",
+						Description: "The member to show stats for (omit for yourself)",
+					},
+					{
+						Type:        entity.OptTypeString,
+						Name:        "days",
+						Description: "How many days back to count (default 90)",
+					},
 				},
 				DefaultPermission: true,
 			},
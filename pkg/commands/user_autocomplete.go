@@ -75,21 +75,33 @@ func (c *UserCommands) autocompleteEventRoles(ix *cmdhandler.Interaction, opts [
 	}
 
 	roles := trial.GetRoleCounts(ctx)
+
+	// for a ranked list of roles, complete the last one and keep the ones before it
+	var prefix string
 	typed := strings.ToLower(focused.ValueString)
+	chosen := map[string]bool{}
+	if idx := strings.LastIndex(typed, ">"); idx >= 0 {
+		for _, role := range parseRolePreferences(typed[:idx]) {
+			chosen[role] = true
+			prefix += role + " > "
+		}
+		typed = typed[idx+1:]
+	}
+	typed = strings.TrimSpace(typed)
 
 	choices := make([]entity.ApplicationCommandOptionChoice, 0, len(roles))
 	for _, rc := range roles {
 		name := rc.GetRole(ctx)
 		nameLower := strings.ToLower(name)
 
-		if !strings.Contains(nameLower, typed) {
+		if chosen[nameLower] || !roleMatches(ctx, rc, typed) {
 			continue
 		}
 
 		choices = append(choices, entity.ApplicationCommandOptionChoice{
 			Type:        entity.OptTypeString,
-			Name:        name,
-			ValueString: nameLower,
+			Name:        prefix + name,
+			ValueString: prefix + nameLower,
 		})
 	}
 
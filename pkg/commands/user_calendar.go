@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+)
+
+// calendarInteraction replies with the calendar feed urls; there is no text version of this
+// command since the urls contain tokens and should not be posted publicly
+func (c *UserCommands) calendarInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "userCommands.calendarInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+	r.SetEphemeral(true)
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling root interaction", "command", "calendar")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(okColor)
+
+	links := c.deps.CalendarLinks()
+	if links == nil {
+		r.Description = "Calendar feeds are not enabled for this bot."
+		return r, nil, nil
+	}
+
+	r.Description = fmt.Sprintf("Subscribe to these in Google Calendar, Outlook, etc. Keep the links private.\n\n**Your events**\n%s\n\n**All open events**\n%s",
+		links.MemberFeedURL(ix.GuildID(), ix.UserID()),
+		links.GuildFeedURL(ix.GuildID()),
+	)
+
+	return r, nil, nil
+}
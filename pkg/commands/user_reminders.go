@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+func (c *UserCommands) remindersInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "userCommands.remindersInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling root interaction", "command", "reminders")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+	r.SetEphemeral(true)
+
+	var enabled, found bool
+	for i := range opts {
+		if opts[i].Name == "enabled" {
+			enabled = opts[i].ValueBool
+			found = true
+		}
+	}
+
+	desc, err := c.reminders(ctx, ix.GuildID(), ix.UserID(), enabled, found)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.Description = desc
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *UserCommands) remindersHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "userCommands.remindersHandler", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	r.SetReplyTo(msg)
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling rootCommand", "command", "reminders")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	var enabled, found bool
+	if len(msg.Contents()) > 0 {
+		switch strings.ToLower(strings.TrimSpace(msg.Contents()[0])) {
+		case "on":
+			enabled, found = true, true
+		case "off":
+			enabled, found = false, true
+		default:
+			return r, i18n.NewError(i18n.ArgsRemindersUsage)
+		}
+	}
+
+	desc, err := c.reminders(ctx, msg.GuildID(), msg.UserID(), enabled, found)
+	if err != nil {
+		return r, err
+	}
+
+	r.Description = desc
+	r.SetColor(okColor)
+
+	return r, nil
+}
+
+// reminders reports (and, if set is true, changes) whether a member receives event reminders
+func (c *UserCommands) reminders(ctx context.Context, gid, uid snowflake.Snowflake, enabled, set bool) (string, error) {
+	ctx, span := c.deps.Census().StartSpan(ctx, "userCommands.reminders", "guild_id", gid.ToString())
+	defer span.End()
+
+	if set {
+		if err := c.deps.MemberAPI().SetRemindersOptOut(ctx, gid.ToString(), uid.ToString(), !enabled); err != nil {
+			return "", errors.Wrap(err, "could not save reminder preference")
+		}
+	} else {
+		optOut, err := c.deps.MemberAPI().RemindersOptOut(ctx, gid.ToString(), uid.ToString())
+		if err != nil {
+			return "", errors.Wrap(err, "could not retrieve reminder preference")
+		}
+		enabled = !optOut
+	}
+
+	if enabled {
+		return "You will receive reminders for events you are signed up for.", nil
+	}
+
+	return "You will not receive event reminders.", nil
+}
@@ -2,12 +2,14 @@ package commands
 
 import (
 	"context"
+	"strconv"
 	"strings"
 
 	"github.com/gsmcwhirter/go-util/v8/deferutil"
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
 
@@ -44,14 +46,20 @@ func (c *UserCommands) showInteraction(ix *cmdhandler.Interaction, opts []entity
 	r.SetColor(errColor)
 
 	var eventName string
+	page := 1
 	for i := range opts {
 		if opts[i].Name == "event_name" {
 			eventName = opts[i].ValueString
 			continue
 		}
+
+		if opts[i].Name == "page" {
+			page = opts[i].ValueInt
+			continue
+		}
 	}
 
-	_, r2, err := c.show(ctx, ix.GuildID(), eventName)
+	_, r2, err := c.show(ctx, i18n.NewPrinter(gsettings.Language), ix.GuildID(), eventName, page)
 	if err != nil {
 		return r, nil, errors.Wrap(err, "could not find trial")
 	}
@@ -97,13 +105,21 @@ func (c *UserCommands) showHandler(msg cmdhandler.Message) (cmdhandler.Response,
 		return r, msg.ContentErr()
 	}
 
-	if len(msg.Contents()) != 1 {
-		return r, errors.New("you must supply exactly 1 argument -- trial name; are you missing quotes?")
+	if len(msg.Contents()) < 1 || len(msg.Contents()) > 2 {
+		return r, i18n.NewError(i18n.ArgsShowUsage)
 	}
 
 	trialName := strings.TrimSpace(msg.Contents()[0])
 
-	trial, r2, err := c.show(ctx, msg.GuildID(), trialName)
+	page := 1
+	if len(msg.Contents()) == 2 {
+		page, err = strconv.Atoi(strings.TrimSpace(msg.Contents()[1]))
+		if err != nil {
+			return r, i18n.NewError(i18n.ArgsPageNumber)
+		}
+	}
+
+	trial, r2, err := c.show(ctx, i18n.NewPrinter(gsettings.Language), msg.GuildID(), trialName, page)
 	if err != nil {
 		return r, errors.Wrap(err, "could not find trial")
 	}
@@ -116,10 +132,15 @@ func (c *UserCommands) showHandler(msg cmdhandler.Message) (cmdhandler.Response,
 	r2.SetReplyTo(msg)
 	r2.SetColor(okColor)
 
-	return r2, nil
+	// live messages are kept up to date with the first page, so later pages are not tracked
+	if page, _ := r2.Page(); page > 1 {
+		return r2, nil
+	}
+
+	return newLiveResponse(r2, storage.LiveMessageShow), nil
 }
 
-func (c *UserCommands) show(ctx context.Context, gid snowflake.Snowflake, eventName string) (storage.Trial, *cmdhandler.EmbedResponse, error) {
+func (c *UserCommands) show(ctx context.Context, p i18n.Printer, gid snowflake.Snowflake, eventName string, page int) (storage.Trial, *eventEmbed, error) {
 	t, err := c.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
 	if err != nil {
 		return nil, nil, err
@@ -131,7 +152,7 @@ func (c *UserCommands) show(ctx context.Context, gid snowflake.Snowflake, eventN
 		return nil, nil, err
 	}
 
-	r2 := formatTrialDisplay(ctx, trial, true)
+	r2 := formatTrialDisplayPage(ctx, p, trial, true, page)
 
 	return trial, r2, nil
 }
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/gsmcwhirter/go-util/v8/deferutil"
 	"github.com/gsmcwhirter/go-util/v8/errors"
@@ -10,8 +11,10 @@ import (
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 	"github.com/hashicorp/go-multierror"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
@@ -19,6 +22,8 @@ import (
 	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
 )
 
+const maxSignupNoteLength = 100
+
 func (c *UserCommands) signupInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
 	ctx, span := c.deps.Census().StartSpan(ix.Context(), "userCommands.signupInteraction", "guild_id", ix.GuildID().ToString())
 	defer span.End()
@@ -45,7 +50,7 @@ func (c *UserCommands) signupInteraction(ix *cmdhandler.Interaction, opts []enti
 
 	r.SetColor(errColor)
 
-	var eventName, role string
+	var eventName, role, note string
 	for i := range opts {
 		if opts[i].Name == "event_name" {
 			eventName = opts[i].ValueString
@@ -56,18 +61,23 @@ func (c *UserCommands) signupInteraction(ix *cmdhandler.Interaction, opts []enti
 			role = opts[i].ValueString
 			continue
 		}
+
+		if opts[i].Name == "note" {
+			note = strings.TrimSpace(opts[i].ValueString)
+			continue
+		}
 	}
 
-	r2, overflow, err := c.signup(ctx, logger, ix, gsettings, false, ix.GuildID(), ix.UserID(), eventName, role)
+	if len(note) > maxSignupNoteLength {
+		return r, nil, errors.WithDetails(i18n.NewError(i18n.SignupNoteTooLong), "max_length", maxSignupNoteLength)
+	}
+
+	r2, confirmation, err := c.signup(ctx, logger, ix, gsettings, false, ix.GuildID(), ix.UserID(), eventName, role, note)
 	if err != nil {
 		return r, nil, errors.Wrap(err, "could not sign up for event")
 	}
 
-	if overflow {
-		r.Description = fmt.Sprintf("Signed up as OVERFLOW for %s in %s\n", role, eventName)
-	} else {
-		r.Description = fmt.Sprintf("Signed up for %s in %s\n", role, eventName)
-	}
+	r.Description = confirmation + "\n"
 
 	r.SetColor(okColor)
 	r.SetEphemeral(true)
@@ -116,20 +126,20 @@ func (c *UserCommands) signupHandler(msg cmdhandler.Message) (cmdhandler.Respons
 	}
 
 	if len(msg.Contents()) < 2 {
-		return r, errors.New("missing role")
+		return r, i18n.NewError(i18n.ArgsMissingRole)
 	}
 
 	if len(msg.Contents()) > 2 && len(msg.Contents())%2 != 0 {
-		return r, errors.New("incorrect number of arguments")
+		return r, i18n.NewError(i18n.ArgsRolePairs)
 	}
 
 	var descStr string
-	var lastResp *cmdhandler.EmbedResponse
+	var lastResp *eventEmbed
 
 	for i := 0; i < len(msg.Contents()); i += 2 {
 		trialName, role := msg.Contents()[i], msg.Contents()[i+1]
 
-		r2, overflow, err2 := c.signup(ctx, logger, msg, gsettings, true, msg.GuildID(), msg.UserID(), trialName, role)
+		r2, confirmation, err2 := c.signup(ctx, logger, msg, gsettings, true, msg.GuildID(), msg.UserID(), trialName, role, "")
 		err = multierror.Append(err, err2)
 		if err2 == msghandler.ErrNoResponse { // bad channel, for instance
 			return r, err2
@@ -139,11 +149,7 @@ func (c *UserCommands) signupHandler(msg cmdhandler.Message) (cmdhandler.Respons
 			continue
 		}
 
-		if overflow {
-			descStr += fmt.Sprintf("Signed up as OVERFLOW for %s in %s\n", role, trialName)
-		} else {
-			descStr += fmt.Sprintf("Signed up for %s in %s\n", role, trialName)
-		}
+		descStr += confirmation + "\n"
 
 		if r2 != nil {
 			lastResp = r2
@@ -172,13 +178,13 @@ func (c *UserCommands) signupHandler(msg cmdhandler.Message) (cmdhandler.Respons
 	return r, err
 }
 
-func (c *UserCommands) signup(ctx context.Context, logger log.Logger, msg msghandler.MessageLike, gsettings storage.GuildSettings, checkChannel bool, gid, uid snowflake.Snowflake, eventName, role string) (r2 *cmdhandler.EmbedResponse, overflow bool, err error) {
+func (c *UserCommands) signup(ctx context.Context, logger log.Logger, msg msghandler.MessageLike, gsettings storage.GuildSettings, checkChannel bool, gid, uid snowflake.Snowflake, eventName, role, note string) (r2 *eventEmbed, confirmation string, err error) {
 	ctx, span := c.deps.Census().StartSpan(ctx, "userCommands.signup", "guild_id", gid.ToString())
 	defer span.End()
 
 	t, err := c.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), true)
 	if err != nil {
-		return nil, false, err
+		return nil, "", err
 	}
 	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
 
@@ -186,7 +192,7 @@ func (c *UserCommands) signup(ctx context.Context, logger log.Logger, msg msghan
 
 	trial, err = t.GetTrial(ctx, eventName)
 	if err != nil {
-		return nil, false, err
+		return nil, "", err
 	}
 
 	signupCidStr := trial.GetSignupChannel(ctx)
@@ -194,21 +200,30 @@ func (c *UserCommands) signup(ctx context.Context, logger log.Logger, msg msghan
 	if checkChannel {
 		if !isSignupChannel(ctx, logger, msg, signupCidStr, gsettings.AdminChannel, gsettings.AdminRoles, c.deps.BotSession(), c.deps.Bot()) {
 			level.Info(logger).Message("command not in signup channel", "signup_channel", trial.GetSignupChannel(ctx))
-			return nil, false, msghandler.ErrNoResponse
+			return nil, "", msghandler.ErrNoResponse
 		}
 	}
 
 	if trial.GetState(ctx) != storage.TrialStateOpen {
-		return nil, false, errors.New("cannot sign up for a closed trial")
+		return nil, "", ErrSignupClosed
+	}
+
+	if err = checkSignupLimit(ctx, t, gsettings, trial, cmdhandler.UserMentionString(uid)); err != nil {
+		return nil, "", err
 	}
 
-	overflow, err = signupUser(ctx, trial, cmdhandler.UserMentionString(uid), role)
+	// role may be a ranked list of roles; from here on, it is the one the user was placed in
+	role, overflow, err := signupUser(ctx, trial, cmdhandler.UserMentionString(uid), role, roleAliases(gsettings), guildMemberRoles(c.deps.Bot(), gid, uid), true)
 	if err != nil {
-		return nil, false, err
+		return nil, "", err
+	}
+
+	if note != "" {
+		trial.SetSignupNote(ctx, cmdhandler.UserMentionString(uid), note)
 	}
 
 	if err = t.SaveTrial(ctx, trial); err != nil {
-		return nil, overflow, errors.Wrap(err, "could not save trial signup")
+		return nil, "", errors.Wrap(err, "could not save trial signup")
 	}
 
 	if overflow {
@@ -218,9 +233,13 @@ func (c *UserCommands) signup(ctx context.Context, logger log.Logger, msg msghan
 	}
 
 	if err = t.Commit(ctx); err != nil {
-		return nil, overflow, errors.Wrap(err, "could not save trial signup")
+		return nil, "", errors.Wrap(err, "could not save trial signup")
 	}
 
+	recordActivity(ctx, logger, c.deps.ActivityAPI(), gid, trial, cmdhandler.UserMentionString(uid), role, storage.ActivitySignup, false)
+	c.deps.Webhooks().Notify(webhookEvent(ctx, gid, webhooks.KindSignup, trial, cmdhandler.UserMentionString(uid), role))
+	c.deps.LiveMessages().Refresh(gid, trial.GetName(ctx))
+
 	if gsettings.ShowAfterSignup == "true" {
 		level.Debug(logger).Message("auto-show after signup", "trial_name", eventName)
 
@@ -232,10 +251,12 @@ func (c *UserCommands) signup(ctx context.Context, logger log.Logger, msg msghan
 				signupCid = scID
 			}
 
-			r2 = formatTrialDisplay(ctx, trial, true)
+			r2 = formatTrialDisplay(ctx, i18n.NewPrinter(gsettings.Language), trial, true)
 			r2.ToChannel = signupCid
 		}
 	}
 
-	return r2, overflow, nil
+	confirmation = signupConfirmation(ctx, logger, c.deps.TemplateAPI(), c.deps.BotSession(), gid, gsettings, trial, role, overflow)
+
+	return r2, confirmation, nil
 }
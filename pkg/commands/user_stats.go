@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/logging"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+)
+
+func (c *UserCommands) statsInteraction(ix *cmdhandler.Interaction, opts []entity.ApplicationCommandInteractionOption) (cmdhandler.Response, []cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(ix.Context(), "userCommands.statsInteraction", "guild_id", ix.GuildID().ToString())
+	defer span.End()
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	logger := logging.WithMessage(ix, c.deps.Logger())
+	level.Info(logger).Message("handling root interaction", "command", "stats")
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), ix.GuildID())
+	if err != nil {
+		return r, nil, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.SetColor(errColor)
+	r.SetEphemeral(true)
+
+	uid := ix.UserID()
+	var daysStr string
+	for i := range opts {
+		switch opts[i].Name {
+		case "user":
+			uid = opts[i].ValueUser
+		case "days":
+			daysStr = opts[i].ValueString
+		}
+	}
+
+	days, err := parseStatsDays(daysStr)
+	if err != nil {
+		return r, nil, err
+	}
+
+	desc, err := c.stats(ctx, i18n.NewPrinter(gsettings.Language), ix.GuildID(), uid, days)
+	if err != nil {
+		return r, nil, err
+	}
+
+	r.Description = desc
+	r.SetColor(okColor)
+
+	return r, nil, nil
+}
+
+func (c *UserCommands) statsHandler(msg cmdhandler.Message) (cmdhandler.Response, error) {
+	ctx, span := c.deps.Census().StartSpan(msg.Context(), "userCommands.statsHandler", "guild_id", msg.GuildID().ToString())
+	defer span.End()
+	msg = cmdhandler.NewWithContext(ctx, msg)
+
+	r := &cmdhandler.SimpleEmbedResponse{}
+
+	r.SetReplyTo(msg)
+
+	logger := logging.WithMessage(msg, c.deps.Logger())
+	level.Info(logger).Message("handling rootCommand", "command", "stats", "args", msg.Contents())
+
+	gsettings, err := storage.GetSettings(ctx, c.deps.GuildAPI(), msg.GuildID())
+	if err != nil {
+		return r, err
+	}
+
+	okColor, err := colorToInt(gsettings.MessageColor)
+	if err != nil {
+		return r, err
+	}
+
+	errColor, err := colorToInt(gsettings.ErrorColor)
+	if err != nil {
+		return r, err
+	}
+
+	r.SetColor(errColor)
+
+	if msg.ContentErr() != nil {
+		return r, msg.ContentErr()
+	}
+
+	uid := msg.UserID()
+	var daysStr string
+	for _, arg := range msg.Contents() {
+		if cmdhandler.IsUserMention(arg) {
+			if uid, err = userFromMention(arg); err != nil {
+				return r, errors.Wrap(err, "could not understand user mention")
+			}
+			continue
+		}
+
+		daysStr = arg
+	}
+
+	days, err := parseStatsDays(daysStr)
+	if err != nil {
+		return r, err
+	}
+
+	desc, err := c.stats(ctx, i18n.NewPrinter(gsettings.Language), msg.GuildID(), uid, days)
+	if err != nil {
+		return r, err
+	}
+
+	r.Description = desc
+	r.SetColor(okColor)
+
+	return r, nil
+}
+
+func (c *UserCommands) stats(ctx context.Context, p i18n.Printer, gid, uid snowflake.Snowflake, days int) (string, error) {
+	ctx, span := c.deps.Census().StartSpan(ctx, "userCommands.stats", "guild_id", gid.ToString())
+	defer span.End()
+
+	member := cmdhandler.UserMentionString(uid)
+
+	stats, err := c.deps.ActivityAPI().MemberStats(ctx, gid.ToString(), attendanceMember(member), statsSince(days))
+	if err != nil {
+		return "", errors.Wrap(err, "could not retrieve member stats")
+	}
+
+	return formatMemberStats(p, member, days, stats), nil
+}
@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v8/deferutil"
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	log "github.com/gsmcwhirter/go-util/v8/logging"
 	"github.com/gsmcwhirter/go-util/v8/logging/level"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/msghandler"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
 
 	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/discordapi/entity"
@@ -60,7 +63,7 @@ func (c *UserCommands) withdrawInteraction(ix *cmdhandler.Interaction, opts []en
 
 	level.Info(logger).Message("withdrew", "trial_name", eventName)
 
-	r.Description = fmt.Sprintf("Withdrew from %s", eventName)
+	r.Description = i18n.Sprintf(gsettings.Language, i18n.Withdrew, eventName)
 	r.SetColor(okColor)
 	r.SetEphemeral(true)
 
@@ -108,7 +111,7 @@ func (c *UserCommands) withdrawHandler(msg cmdhandler.Message) (cmdhandler.Respo
 	}
 
 	if len(msg.Contents()) < 1 {
-		return r, errors.New("missing event name")
+		return r, i18n.NewError(i18n.ArgsMissingEvent)
 	}
 
 	trialName := strings.TrimSpace(msg.Contents()[0])
@@ -119,7 +122,7 @@ func (c *UserCommands) withdrawHandler(msg cmdhandler.Message) (cmdhandler.Respo
 	}
 
 	level.Info(logger).Message("withdrew", "trial_name", trialName)
-	descStr := fmt.Sprintf("Withdrew from %s", trialName)
+	descStr := i18n.Sprintf(gsettings.Language, i18n.Withdrew, trialName)
 
 	if r2 != nil {
 		r2.Description = fmt.Sprintf("%s\n\n%s", descStr, r2.Description)
@@ -135,7 +138,7 @@ func (c *UserCommands) withdrawHandler(msg cmdhandler.Message) (cmdhandler.Respo
 	return r, nil
 }
 
-func (c *UserCommands) withdraw(ctx context.Context, logger log.Logger, msg msghandler.MessageLike, gsettings storage.GuildSettings, checkChannel bool, gid, uid snowflake.Snowflake, eventName string) (r2 *cmdhandler.EmbedResponse, err error) {
+func (c *UserCommands) withdraw(ctx context.Context, logger log.Logger, msg msghandler.MessageLike, gsettings storage.GuildSettings, checkChannel bool, gid, uid snowflake.Snowflake, eventName string) (r2 *eventEmbed, err error) {
 	t, err := c.deps.TrialAPI().NewTransaction(ctx, msg.GuildID().ToString(), true)
 	if err != nil {
 		return nil, err
@@ -157,9 +160,11 @@ func (c *UserCommands) withdraw(ctx context.Context, logger log.Logger, msg msgh
 	}
 
 	if trial.GetState(ctx) != storage.TrialStateOpen {
-		return nil, errors.New("cannot withdraw from a closed trial")
+		return nil, ErrWithdrawClosed
 	}
 
+	role, signedUp := signupRole(ctx, trial, cmdhandler.UserMentionString(msg.UserID()))
+
 	trial.RemoveSignup(ctx, cmdhandler.UserMentionString(msg.UserID()))
 
 	if err = t.SaveTrial(ctx, trial); err != nil {
@@ -170,6 +175,12 @@ func (c *UserCommands) withdraw(ctx context.Context, logger log.Logger, msg msgh
 		return nil, errors.Wrap(err, "could not save trial withdraw")
 	}
 
+	if signedUp {
+		recordActivity(ctx, logger, c.deps.ActivityAPI(), gid, trial, cmdhandler.UserMentionString(msg.UserID()), role, storage.ActivityWithdraw, withdrawIsLate(ctx, trial, time.Now()))
+		c.deps.Webhooks().Notify(webhookEvent(ctx, gid, webhooks.KindWithdraw, trial, cmdhandler.UserMentionString(msg.UserID()), role))
+		c.deps.LiveMessages().Refresh(gid, trial.GetName(ctx))
+	}
+
 	if gsettings.ShowAfterWithdraw == "true" {
 		level.Debug(logger).Message("auto-show after withdraw", "trial_name", eventName)
 
@@ -181,7 +192,7 @@ func (c *UserCommands) withdraw(ctx context.Context, logger log.Logger, msg msgh
 				signupCid = scID
 			}
 
-			r2 = formatTrialDisplay(ctx, trial, true)
+			r2 = formatTrialDisplay(ctx, i18n.NewPrinter(gsettings.Language), trial, true)
 			r2.ToChannel = signupCid
 		}
 	}
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/webhooks"
+)
+
+// webhookEvent describes a roster change for the guild's webhooks; member and role are
+// only set for signups and withdrawals
+func webhookEvent(ctx context.Context, gid snowflake.Snowflake, kind string, trial storage.Trial, member, role string) webhooks.Event {
+	ev := webhooks.Event{
+		Kind:      kind,
+		GuildID:   gid.ToString(),
+		EventName: trial.GetName(ctx),
+		State:     string(trial.GetState(ctx)),
+		Role:      role,
+	}
+
+	if member != "" {
+		ev.Member = attendanceMember(member)
+	}
+
+	return ev
+}
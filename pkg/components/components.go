@@ -0,0 +1,203 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+)
+
+// Discord limits on message components
+const (
+	MaxButtonsPerRow = 5
+	MaxRows          = 5
+	MaxCustomIDLen   = 100
+	MaxLabelLen      = 80
+)
+
+// Component types
+const (
+	TypeActionRow = 1
+	TypeButton    = 2
+)
+
+// Button styles
+const (
+	StylePrimary   = 1
+	StyleSecondary = 2
+	StyleSuccess   = 3
+	StyleDanger    = 4
+)
+
+// Actions encoded in button custom ids
+const (
+	ActionSignup   = "signup"
+	ActionWithdraw = "withdraw"
+	ActionShow     = "show"
+	ActionPage     = "page"
+)
+
+// Emoji is the emoji shown on a button
+type Emoji struct {
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Animated bool   `json:"animated,omitempty"`
+}
+
+// Button is a clickable message component
+type Button struct {
+	Type     int    `json:"type"`
+	Style    int    `json:"style"`
+	Label    string `json:"label"`
+	CustomID string `json:"custom_id"`
+	Emoji    *Emoji `json:"emoji,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// ActionRow is a row of buttons
+type ActionRow struct {
+	Type       int      `json:"type"`
+	Components []Button `json:"components"`
+}
+
+// Click is a button click on a message the bot posted
+type Click interface {
+	UserID() snowflake.Snowflake
+	MessageID() snowflake.Snowflake
+	ChannelID() snowflake.Snowflake
+	GuildID() snowflake.Snowflake
+	CustomID() string
+	Context() context.Context
+}
+
+// Handler responds to button clicks; the responses are sent ephemerally to the clicker
+type Handler interface {
+	HandleClick(Click) (cmdhandler.Response, error)
+}
+
+type click struct {
+	ctx       context.Context
+	userID    snowflake.Snowflake
+	channelID snowflake.Snowflake
+	messageID snowflake.Snowflake
+	guildID   snowflake.Snowflake
+	customID  string
+}
+
+var _ Click = (*click)(nil)
+
+// NewClick creates a new Click
+func NewClick(ctx context.Context, uid, mid, cid, gid snowflake.Snowflake, customID string) Click {
+	return &click{
+		ctx:       ctx,
+		userID:    uid,
+		messageID: mid,
+		channelID: cid,
+		guildID:   gid,
+		customID:  customID,
+	}
+}
+
+func (c *click) UserID() snowflake.Snowflake    { return c.userID }
+func (c *click) MessageID() snowflake.Snowflake { return c.messageID }
+func (c *click) ChannelID() snowflake.Snowflake { return c.channelID }
+func (c *click) GuildID() snowflake.Snowflake   { return c.guildID }
+func (c *click) CustomID() string               { return c.customID }
+func (c *click) Context() context.Context       { return c.ctx }
+
+// CustomID encodes an action and its argument (e.g., the role to sign up for)
+func CustomID(action, arg string) string {
+	if arg == "" {
+		return action
+	}
+
+	return action + ":" + arg
+}
+
+// ParseCustomID splits a custom id created by CustomID
+func ParseCustomID(id string) (action, arg string) {
+	parts := strings.SplitN(id, ":", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+var customEmojiRe = regexp.MustCompile(`^<(a?):([^:]+):(\d+)>$`)
+
+// ParseEmoji converts an emoji as written in a message (unicode or <:name:id>) to a button emoji
+func ParseEmoji(s string) *Emoji {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+
+	if m := customEmojiRe.FindStringSubmatch(s); m != nil {
+		return &Emoji{
+			ID:       m[3],
+			Name:     m[2],
+			Animated: m[1] == "a",
+		}
+	}
+
+	return &Emoji{Name: s}
+}
+
+// Rows lays buttons out in action rows, dropping any that do not fit in a message
+func Rows(buttons []Button) []ActionRow {
+	rows := make([]ActionRow, 0, MaxRows)
+	for i := 0; i < len(buttons) && len(rows) < MaxRows; i += MaxButtonsPerRow {
+		end := i + MaxButtonsPerRow
+		if end > len(buttons) {
+			end = len(buttons)
+		}
+
+		rows = append(rows, ActionRow{
+			Type:       TypeActionRow,
+			Components: buttons[i:end],
+		})
+	}
+
+	return rows
+}
+
+// Attacher adds buttons to posted messages (which the json api client cannot send)
+type Attacher struct {
+	client *discordrest.Client
+}
+
+// NewAttacher creates a new Attacher
+func NewAttacher(client *discordrest.Client) *Attacher {
+	return &Attacher{
+		client: client,
+	}
+}
+
+type componentsEdit struct {
+	Components []ActionRow `json:"components"`
+}
+
+// Attach sets the buttons of a message the bot has posted
+func (a *Attacher) Attach(ctx context.Context, cid, mid snowflake.Snowflake, rows []ActionRow) error {
+	u := fmt.Sprintf("/channels/%s/messages/%s", cid.ToString(), mid.ToString())
+	return a.send(ctx, http.MethodPatch, u, componentsEdit{Components: rows}, "attach components")
+}
+
+// AttachOriginal sets the buttons of an interaction response (which may be ephemeral, and so
+// cannot be edited through the channel)
+func (a *Attacher) AttachOriginal(ctx context.Context, appID snowflake.Snowflake, token string, rows []ActionRow) error {
+	u := fmt.Sprintf("/webhooks/%s/%s/messages/@original", appID.ToString(), token)
+	return a.send(ctx, http.MethodPatch, u, componentsEdit{Components: rows}, "attach components")
+}
+
+func (a *Attacher) send(ctx context.Context, method, path string, payload interface{}, action string) error {
+	return errors.Wrap(a.client.DoJSON(ctx, method, path, payload, nil), "could not "+action)
+}
@@ -0,0 +1,185 @@
+package components
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+)
+
+func TestParseEmoji(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want *Emoji
+	}{
+		{"", nil},
+		{"🛡️", &Emoji{Name: "🛡️"}},
+		{"<:tank:123456>", &Emoji{ID: "123456", Name: "tank"}},
+		{"<a:dance:789>", &Emoji{ID: "789", Name: "dance", Animated: true}},
+	}
+
+	for _, tt := range tests {
+		if got := ParseEmoji(tt.in); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseEmoji(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCustomIDRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	action, arg := ParseCustomID(CustomID(ActionSignup, "dps: ranged"))
+	if action != ActionSignup || arg != "dps: ranged" {
+		t.Errorf("got (%q, %q)", action, arg)
+	}
+
+	action, arg = ParseCustomID(CustomID(ActionShow, ""))
+	if action != ActionShow || arg != "" {
+		t.Errorf("got (%q, %q)", action, arg)
+	}
+}
+
+func TestRows(t *testing.T) {
+	t.Parallel()
+
+	buttons := make([]Button, 27)
+	rows := Rows(buttons)
+	if len(rows) != MaxRows {
+		t.Fatalf("got %d rows, want %d", len(rows), MaxRows)
+	}
+
+	for i, r := range rows {
+		if len(r.Components) != MaxButtonsPerRow {
+			t.Errorf("row %d has %d buttons", i, len(r.Components))
+		}
+	}
+
+	if rows := Rows(buttons[:7]); len(rows) != 2 || len(rows[1].Components) != 2 {
+		t.Errorf("unexpected layout for 7 buttons: %+v", rows)
+	}
+}
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestAttach(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotURL, gotBody string
+	doer := doerFunc(func(r *http.Request) (*http.Response, error) {
+		gotMethod = r.Method
+		gotURL = r.URL.String()
+		b, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(b)
+		return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+	})
+
+	a := NewAttacher(discordrest.NewClient(doer, "https://discord.test/api", http.Header{}, nil, discordrest.Options{}))
+	rows := Rows([]Button{{Type: TypeButton, Style: StylePrimary, Label: "Tank", CustomID: CustomID(ActionSignup, "tank")}})
+	if err := a.Attach(context.Background(), 1, 2, rows); err != nil {
+		t.Fatalf("Attach() error = %v", err)
+	}
+
+	if gotMethod != http.MethodPatch || gotURL != "https://discord.test/api/channels/1/messages/2" {
+		t.Errorf("unexpected request %s %s", gotMethod, gotURL)
+	}
+
+	want := `{"components":[{"type":1,"components":[{"type":2,"style":1,"label":"Tank","custom_id":"signup:tank"}]}]}`
+	if gotBody != want {
+		t.Errorf("body = %s, want %s", gotBody, want)
+	}
+}
+
+func TestNewModal(t *testing.T) {
+	t.Parallel()
+
+	inputs := make([]TextInput, MaxInputs+1)
+	m := NewModal(ActionCreateForm, "Create", inputs...)
+	if len(m.Components) != MaxInputs {
+		t.Fatalf("got %d rows, want %d", len(m.Components), MaxInputs)
+	}
+
+	for i, r := range m.Components {
+		if r.Type != TypeActionRow || len(r.Components) != 1 || r.Components[0].Type != TypeTextInput {
+			t.Errorf("unexpected row %d: %+v", i, r)
+		}
+	}
+}
+
+func TestOpenModal(t *testing.T) {
+	t.Parallel()
+
+	var gotMethod, gotURL, gotBody string
+	doer := doerFunc(func(r *http.Request) (*http.Response, error) {
+		gotMethod = r.Method
+		gotURL = r.URL.String()
+		b, _ := ioutil.ReadAll(r.Body)
+		gotBody = string(b)
+		return &http.Response{StatusCode: 204, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	})
+
+	a := NewAttacher(discordrest.NewClient(doer, "https://discord.test/api", http.Header{}, nil, discordrest.Options{}))
+	m := NewModal("createform", "Create", TextInput{CustomID: "name", Style: InputShort, Label: "Name", Required: true})
+	if err := a.OpenModal(context.Background(), 1, "tok", m); err != nil {
+		t.Fatalf("OpenModal() error = %v", err)
+	}
+
+	if gotMethod != http.MethodPost || gotURL != "https://discord.test/api/interactions/1/tok/callback" {
+		t.Errorf("unexpected request %s %s", gotMethod, gotURL)
+	}
+
+	want := `{"type":9,"data":{"custom_id":"createform","title":"Create","components":[{"type":1,"components":[{"type":4,"custom_id":"name","style":1,"label":"Name","required":true}]}]}}`
+	if gotBody != want {
+		t.Errorf("body = %s, want %s", gotBody, want)
+	}
+}
+
+func TestDrafts(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	d := NewDrafts(time.Minute)
+	d.now = func() time.Time { return now }
+
+	id := d.Put(1, map[string]string{"name": "raid"})
+	if v, ok := d.Get(id, 1); !ok || v["name"] != "raid" {
+		t.Errorf("Get() = %v, %v", v, ok)
+	}
+
+	if _, ok := d.Get(id, 2); ok {
+		t.Error("Get() returned another user's draft")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := d.Get(id, 1); ok {
+		t.Error("Get() returned an expired draft")
+	}
+}
+
+type rawMessage string
+
+func (m rawMessage) MarshalJSON() ([]byte, error) { return []byte(m), nil }
+
+func TestUpdateData(t *testing.T) {
+	t.Parallel()
+
+	data, err := updateData(rawMessage(`{"content":"hi","embed":{"title":"x"},"message_reference":{"message_id":"1"}}`), nil)
+	if err != nil {
+		t.Fatalf("updateData() error = %v", err)
+	}
+
+	b, _ := json.Marshal(data)
+	want := `{"components":[],"content":"hi","embeds":[{"title":"x"}]}`
+	if string(b) != want {
+		t.Errorf("data = %s, want %s", b, want)
+	}
+}
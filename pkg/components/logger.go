@@ -0,0 +1,19 @@
+package components
+
+import (
+	log "github.com/gsmcwhirter/go-util/v8/logging"
+)
+
+// LoggerWithClick wraps a logger with fields from a components.Click
+func LoggerWithClick(c Click, logger log.Logger) log.Logger {
+	logger = log.WithContext(c.Context(), logger)
+	logger = log.With(logger, "user_id", c.UserID().ToString(), "channel_id", c.ChannelID().ToString(), "guild_id", c.GuildID().ToString(), "message_id", c.MessageID().ToString(), "custom_id", c.CustomID())
+	return logger
+}
+
+// LoggerWithSubmission wraps a logger with fields from a components.Submission
+func LoggerWithSubmission(s Submission, logger log.Logger) log.Logger {
+	logger = log.WithContext(s.Context(), logger)
+	logger = log.With(logger, "user_id", s.UserID().ToString(), "channel_id", s.ChannelID().ToString(), "guild_id", s.GuildID().ToString(), "custom_id", s.CustomID())
+	return logger
+}
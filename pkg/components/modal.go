@@ -0,0 +1,260 @@
+package components
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// Discord limits on modals
+const (
+	MaxModalTitleLen = 45
+	MaxInputLabelLen = 45
+	MaxInputs        = 5
+)
+
+// TypeTextInput is the component type of a modal text field
+const TypeTextInput = 4
+
+// Text input styles
+const (
+	InputShort     = 1
+	InputParagraph = 2
+)
+
+// ActionCreateForm is encoded in the custom ids of the event creation modal and its retry button
+const ActionCreateForm = "createform"
+
+// interaction callback types
+const (
+	interactionResponseUpdate = 7
+	interactionResponseModal  = 9
+)
+
+// TextInput is a text field in a modal
+type TextInput struct {
+	Type        int    `json:"type"`
+	CustomID    string `json:"custom_id"`
+	Style       int    `json:"style"`
+	Label       string `json:"label"`
+	Value       string `json:"value,omitempty"`
+	Placeholder string `json:"placeholder,omitempty"`
+	Required    bool   `json:"required"`
+	MaxLength   int    `json:"max_length,omitempty"`
+}
+
+// InputRow is a row holding a single text field
+type InputRow struct {
+	Type       int         `json:"type"`
+	Components []TextInput `json:"components"`
+}
+
+// Modal is a pop-up form
+type Modal struct {
+	CustomID   string     `json:"custom_id"`
+	Title      string     `json:"title"`
+	Components []InputRow `json:"components"`
+}
+
+// NewModal lays out the inputs of a modal, one per row, dropping any that do not fit
+func NewModal(customID, title string, inputs ...TextInput) Modal {
+	m := Modal{
+		CustomID:   customID,
+		Title:      title,
+		Components: make([]InputRow, 0, len(inputs)),
+	}
+
+	for _, in := range inputs {
+		if len(m.Components) == MaxInputs {
+			break
+		}
+
+		in.Type = TypeTextInput
+		m.Components = append(m.Components, InputRow{
+			Type:       TypeActionRow,
+			Components: []TextInput{in},
+		})
+	}
+
+	return m
+}
+
+// Submission is a filled-in modal
+type Submission interface {
+	UserID() snowflake.Snowflake
+	ChannelID() snowflake.Snowflake
+	GuildID() snowflake.Snowflake
+	CustomID() string
+	Values() map[string]string
+	Context() context.Context
+}
+
+// ModalHandler responds to modal submissions; the responses are sent ephemerally to the submitter
+type ModalHandler interface {
+	HandleModal(Submission) (cmdhandler.Response, error)
+}
+
+type submission struct {
+	ctx       context.Context
+	userID    snowflake.Snowflake
+	channelID snowflake.Snowflake
+	guildID   snowflake.Snowflake
+	customID  string
+	values    map[string]string
+}
+
+var _ Submission = (*submission)(nil)
+
+// NewSubmission creates a new Submission; values are keyed by the custom ids of the inputs
+func NewSubmission(ctx context.Context, uid, cid, gid snowflake.Snowflake, customID string, values map[string]string) Submission {
+	return &submission{
+		ctx:       ctx,
+		userID:    uid,
+		channelID: cid,
+		guildID:   gid,
+		customID:  customID,
+		values:    values,
+	}
+}
+
+func (s *submission) UserID() snowflake.Snowflake    { return s.userID }
+func (s *submission) ChannelID() snowflake.Snowflake { return s.channelID }
+func (s *submission) GuildID() snowflake.Snowflake   { return s.guildID }
+func (s *submission) CustomID() string               { return s.customID }
+func (s *submission) Values() map[string]string      { return s.values }
+func (s *submission) Context() context.Context       { return s.ctx }
+
+type modalCallback struct {
+	Type int   `json:"type"`
+	Data Modal `json:"data"`
+}
+
+// OpenModal responds to an interaction by showing a modal
+func (a *Attacher) OpenModal(ctx context.Context, ixID snowflake.Snowflake, token string, m Modal) error {
+	u := fmt.Sprintf("/interactions/%s/%s/callback", ixID.ToString(), token)
+	return a.send(ctx, http.MethodPost, u, modalCallback{Type: interactionResponseModal, Data: m}, "open modal")
+}
+
+type updateCallback struct {
+	Type int                        `json:"type"`
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+// UpdateMessage responds to a component interaction by replacing the message the component is on
+// (the only way to change an ephemeral message)
+func (a *Attacher) UpdateMessage(ctx context.Context, ixID snowflake.Snowflake, token string, msg json.Marshaler, rows []ActionRow) error {
+	data, err := updateData(msg, rows)
+	if err != nil {
+		return err
+	}
+
+	u := fmt.Sprintf("/interactions/%s/%s/callback", ixID.ToString(), token)
+	return a.send(ctx, http.MethodPost, u, updateCallback{Type: interactionResponseUpdate, Data: data}, "update message")
+}
+
+// updateData converts a message as sent to a channel into interaction callback data
+func updateData(msg json.Marshaler, rows []ActionRow) (map[string]json.RawMessage, error) {
+	full, err := json.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal message")
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(full, &fields); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal message")
+	}
+
+	data := map[string]json.RawMessage{}
+	if v, ok := fields["content"]; ok {
+		data["content"] = v
+	}
+
+	switch {
+	case fields["embeds"] != nil:
+		data["embeds"] = fields["embeds"]
+	case fields["embed"] != nil:
+		data["embeds"] = json.RawMessage("[" + string(fields["embed"]) + "]")
+	}
+
+	if rows == nil {
+		rows = []ActionRow{} // clears any buttons the message had
+	}
+
+	if data["components"], err = json.Marshal(rows); err != nil {
+		return nil, errors.Wrap(err, "could not marshal components")
+	}
+
+	return data, nil
+}
+
+// Drafts holds the values of rejected modal submissions so the modal can be re-opened with
+// them filled in (discord does not allow answering a submission with another modal)
+type Drafts struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu     sync.Mutex
+	drafts map[string]draft
+}
+
+type draft struct {
+	userID  snowflake.Snowflake
+	values  map[string]string
+	expires time.Time
+}
+
+// NewDrafts creates a new Drafts store whose entries last for ttl
+func NewDrafts(ttl time.Duration) *Drafts {
+	return &Drafts{
+		ttl:    ttl,
+		now:    time.Now,
+		drafts: map[string]draft{},
+	}
+}
+
+// Put stores the values for a user and returns an id short enough for a custom id
+func (d *Drafts) Put(uid snowflake.Snowflake, values map[string]string) string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := d.now()
+	for k, v := range d.drafts {
+		if now.After(v.expires) {
+			delete(d.drafts, k)
+		}
+	}
+
+	d.drafts[id] = draft{
+		userID:  uid,
+		values:  values,
+		expires: now.Add(d.ttl),
+	}
+
+	return id
+}
+
+// Get retrieves the values stored by the user under id, if they have not expired
+func (d *Drafts) Get(id string, uid snowflake.Snowflake) (map[string]string, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	v, ok := d.drafts[id]
+	if !ok || v.userID != uid || d.now().After(v.expires) {
+		return nil, false
+	}
+
+	return v.values, true
+}
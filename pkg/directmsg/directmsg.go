@@ -0,0 +1,42 @@
+package directmsg
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+)
+
+// Opener opens direct message channels with users
+type Opener struct {
+	client *discordrest.Client
+}
+
+// NewOpener creates a new Opener
+func NewOpener(client *discordrest.Client) *Opener {
+	return &Opener{
+		client: client,
+	}
+}
+
+type createDMRequest struct {
+	RecipientID string `json:"recipient_id"`
+}
+
+type channelResponse struct {
+	ID string `json:"id"`
+}
+
+// OpenChannel returns the id of the direct message channel with a user, creating it if needed
+func (o *Opener) OpenChannel(ctx context.Context, uid snowflake.Snowflake) (snowflake.Snowflake, error) {
+	var ch channelResponse
+	if err := o.client.DoJSON(ctx, http.MethodPost, "/users/@me/channels", createDMRequest{RecipientID: uid.ToString()}, &ch); err != nil {
+		return 0, errors.Wrap(err, "could not open dm channel")
+	}
+
+	cid, err := snowflake.FromString(ch.ID)
+	return cid, errors.Wrap(err, "could not parse dm channel id", "id", ch.ID)
+}
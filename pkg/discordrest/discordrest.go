@@ -0,0 +1,237 @@
+package discordrest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"golang.org/x/time/rate"
+)
+
+var (
+	ErrBadResponse = errors.New("bad response from discord")
+	ErrNotFound    = errors.New("discord resource not found")
+	ErrRateLimited = errors.New("rate limited by discord")
+)
+
+// maxBodyBytes is the most of a response body that is read
+const maxBodyBytes = 1 << 20
+
+// Doer performs an http request
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Options configures the retry behavior of the client
+type Options struct {
+	// MaxRetries is how many times a rate limited request is retried
+	MaxRetries int
+	// MaxRetryDelay is the longest discord may ask us to wait before a request is given up on instead
+	MaxRetryDelay time.Duration
+}
+
+// Client sends the discord api requests that the json api client does not cover (uploads, buttons,
+// modals, member search, ...).
+//
+// Every request waits on the shared rate limiter, and rate limited (429) requests are retried after
+// the delay discord asks for; a global rate limit holds back every request made through the client.
+type Client struct {
+	doer    Doer
+	apiURL  string
+	headers http.Header
+	limiter *rate.Limiter
+	opts    Options
+
+	mu           sync.Mutex
+	blockedUntil time.Time
+}
+
+// NewClient creates a new Client; the headers should include the bot authorization. The limiter may
+// be nil, in which case only discord's own rate limit responses slow requests down.
+func NewClient(doer Doer, apiURL string, headers http.Header, limiter *rate.Limiter, opts Options) *Client {
+	if opts.MaxRetries <= 0 {
+		opts.MaxRetries = 3
+	}
+
+	if opts.MaxRetryDelay <= 0 {
+		opts.MaxRetryDelay = 30 * time.Second
+	}
+
+	return &Client{
+		doer:    doer,
+		apiURL:  apiURL,
+		headers: headers,
+		limiter: limiter,
+		opts:    opts,
+	}
+}
+
+type rateLimitResponse struct {
+	RetryAfter float64 `json:"retry_after"`
+	Global     bool    `json:"global"`
+}
+
+// retryAfter is how long discord wants us to wait after a 429, and whether the limit is global
+func retryAfter(h http.Header, body []byte) (time.Duration, bool) {
+	var rl rateLimitResponse
+	_ = json.Unmarshal(body, &rl) // the headers are enough if the body is not the usual json
+
+	global := rl.Global || h.Get("X-RateLimit-Global") == "true"
+
+	secs := rl.RetryAfter
+	for _, key := range []string{"Retry-After", "X-RateLimit-Reset-After"} {
+		if v, err := strconv.ParseFloat(h.Get(key), 64); err == nil && v > secs {
+			secs = v
+		}
+	}
+
+	if secs <= 0 {
+		return time.Second, global
+	}
+
+	return time.Duration(secs * float64(time.Second)), global
+}
+
+// sleep waits for d, or until the context is canceled
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// wait holds a request back until the rate limits allow it
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	blocked := time.Until(c.blockedUntil)
+	c.mu.Unlock()
+
+	if err := sleep(ctx, blocked); err != nil {
+		return err
+	}
+
+	if c.limiter == nil {
+		return nil
+	}
+
+	return c.limiter.Wait(ctx)
+}
+
+func (c *Client) block(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(c.blockedUntil) {
+		c.blockedUntil = until
+	}
+}
+
+// Do sends a request to an api path (e.g., "/users/@me/channels") and returns the response body.
+// Responses outside 2xx are errors wrapping ErrNotFound (for 404s), ErrRateLimited (when the retries
+// run out) or ErrBadResponse; the path is left out of errors, since interaction paths contain a token.
+func (c *Client) Do(ctx context.Context, method, path, contentType string, body []byte) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if err := c.wait(ctx); err != nil {
+			return nil, errors.Wrap(err, "could not wait for rate limit")
+		}
+
+		var reqBody io.Reader
+		if body != nil {
+			reqBody = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, reqBody)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not create request")
+		}
+
+		for k, vs := range c.headers {
+			for _, v := range vs {
+				req.Header.Add(k, v)
+			}
+		}
+
+		if contentType != "" {
+			req.Header.Set("Content-Type", contentType)
+		}
+
+		resp, err := c.doer.Do(req)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not send request")
+		}
+
+		respBody, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read response")
+		}
+
+		switch {
+		case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+			return respBody, nil
+
+		case resp.StatusCode == http.StatusTooManyRequests:
+			delay, global := retryAfter(resp.Header, respBody)
+			if attempt >= c.opts.MaxRetries || delay > c.opts.MaxRetryDelay {
+				return nil, errors.Wrap(ErrRateLimited, "giving up", "retry_after", delay.String(), "attempts", attempt+1)
+			}
+
+			if global {
+				c.block(delay)
+				continue
+			}
+
+			if err := sleep(ctx, delay); err != nil {
+				return nil, errors.Wrap(err, "could not wait for rate limit")
+			}
+
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, errors.Wrap(ErrNotFound, "not found", "method", method)
+
+		default:
+			return nil, errors.Wrap(ErrBadResponse, "request failed", "method", method, "status", resp.StatusCode, "body", string(respBody))
+		}
+	}
+}
+
+// DoJSON sends payload (if not nil) as json to an api path, and unmarshals the response into out (if not nil)
+func (c *Client) DoJSON(ctx context.Context, method, path string, payload, out interface{}) error {
+	var body []byte
+	var contentType string
+
+	if payload != nil {
+		var err error
+		body, err = json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "could not marshal request")
+		}
+		contentType = "application/json"
+	}
+
+	respBody, err := c.Do(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return errors.Wrap(json.Unmarshal(respBody, out), "could not unmarshal response")
+}
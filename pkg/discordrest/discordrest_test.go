@@ -0,0 +1,183 @@
+package discordrest
+
+import (
+	"context"
+	stderrors "errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) { return f(r) }
+
+type reply struct {
+	status int
+	header http.Header
+	body   string
+}
+
+// replies answers each request with the next reply, recording the requests
+func replies(rs ...reply) (Doer, *[]*http.Request, *[]string) {
+	var reqs []*http.Request
+	var bodies []string
+
+	return doerFunc(func(r *http.Request) (*http.Response, error) {
+		var b []byte
+		if r.Body != nil {
+			b, _ = ioutil.ReadAll(r.Body)
+		}
+		reqs = append(reqs, r)
+		bodies = append(bodies, string(b))
+
+		rep := rs[0]
+		if len(rs) > 1 {
+			rs = rs[1:]
+		}
+
+		h := rep.header
+		if h == nil {
+			h = http.Header{}
+		}
+		return &http.Response{StatusCode: rep.status, Header: h, Body: ioutil.NopCloser(strings.NewReader(rep.body))}, nil
+	}), &reqs, &bodies
+}
+
+func TestDo(t *testing.T) {
+	t.Parallel()
+
+	doer, reqs, bodies := replies(reply{status: 200, body: `{"id":"1"}`})
+	c := NewClient(doer, "https://discord.test/api", http.Header{"Authorization": {"Bot tok"}}, nil, Options{})
+
+	var out struct {
+		ID string `json:"id"`
+	}
+	if err := c.DoJSON(context.Background(), http.MethodPost, "/users/@me/channels", map[string]string{"recipient_id": "2"}, &out); err != nil {
+		t.Fatalf("DoJSON() error = %v", err)
+	}
+
+	if out.ID != "1" {
+		t.Errorf("DoJSON() decoded id %q, want %q", out.ID, "1")
+	}
+
+	r := (*reqs)[0]
+	if r.Method != http.MethodPost || r.URL.String() != "https://discord.test/api/users/@me/channels" {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}
+
+	if r.Header.Get("Authorization") != "Bot tok" || r.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("unexpected headers %v", r.Header)
+	}
+
+	if (*bodies)[0] != `{"recipient_id":"2"}` {
+		t.Errorf("body = %s", (*bodies)[0])
+	}
+}
+
+func TestDo_rateLimited(t *testing.T) {
+	t.Parallel()
+
+	limited := reply{status: 429, header: http.Header{"Retry-After": {"0.01"}}, body: `{"retry_after":0.01,"global":false}`}
+	doer, reqs, bodies := replies(limited, limited, reply{status: 204})
+	c := NewClient(doer, "https://discord.test/api", nil, nil, Options{})
+
+	if _, err := c.Do(context.Background(), http.MethodPatch, "/channels/1/messages/2", "application/json", []byte("{}")); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if len(*reqs) != 3 {
+		t.Fatalf("sent %d requests, want 3", len(*reqs))
+	}
+
+	for i, b := range *bodies {
+		if b != "{}" {
+			t.Errorf("retry %d sent body %q", i, b)
+		}
+	}
+}
+
+func TestDo_globalRateLimit(t *testing.T) {
+	t.Parallel()
+
+	doer, _, _ := replies(reply{status: 429, header: http.Header{"X-RateLimit-Global": {"true"}}, body: `{"retry_after":0.05,"global":true}`}, reply{status: 200})
+	c := NewClient(doer, "https://discord.test/api", nil, nil, Options{})
+
+	start := time.Now()
+	if _, err := c.Do(context.Background(), http.MethodGet, "/a", "", nil); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	// a later request still waits for the global limit to pass
+	c.block(50 * time.Millisecond)
+	if _, err := c.Do(context.Background(), http.MethodGet, "/b", "", nil); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("requests finished after %s; the global rate limit was not waited out", elapsed)
+	}
+}
+
+func TestDo_errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts Options
+		rep  reply
+		want error
+	}{
+		{name: "not found", rep: reply{status: 404}, want: ErrNotFound},
+		{name: "bad request", rep: reply{status: 400, body: `{"code":50035}`}, want: ErrBadResponse},
+		{name: "server error", rep: reply{status: 502}, want: ErrBadResponse},
+		{name: "retries run out", opts: Options{MaxRetries: 1}, rep: reply{status: 429, header: http.Header{"Retry-After": {"0.001"}}}, want: ErrRateLimited},
+		{name: "delay too long", rep: reply{status: 429, header: http.Header{"Retry-After": {"3600"}}}, want: ErrRateLimited},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			doer, _, _ := replies(tt.rep)
+			c := NewClient(doer, "https://discord.test/api", nil, nil, tt.opts)
+
+			if _, err := c.Do(context.Background(), http.MethodGet, "/x", "", nil); !stderrors.Is(err, tt.want) {
+				t.Errorf("Do() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		header     http.Header
+		body       string
+		want       time.Duration
+		wantGlobal bool
+	}{
+		{name: "body", body: `{"retry_after":1.5}`, want: 1500 * time.Millisecond},
+		{name: "header", header: http.Header{"Retry-After": {"2"}}, want: 2 * time.Second},
+		{name: "reset after", header: http.Header{"X-Ratelimit-Reset-After": {"0.25"}}, want: 250 * time.Millisecond},
+		{name: "global", header: http.Header{"X-Ratelimit-Global": {"true"}}, body: `{"retry_after":1}`, want: time.Second, wantGlobal: true},
+		{name: "nothing", body: "<html>", want: time.Second},
+	}
+
+	for _, tt := range tests {
+		h := tt.header
+		if h == nil {
+			h = http.Header{}
+		}
+
+		got, global := retryAfter(h, []byte(tt.body))
+		if got != tt.want || global != tt.wantGlobal {
+			t.Errorf("%s: retryAfter() = %s, %v, want %s, %v", tt.name, got, global, tt.want, tt.wantGlobal)
+		}
+	}
+}
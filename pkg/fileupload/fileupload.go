@@ -0,0 +1,167 @@
+package fileupload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+)
+
+var (
+	ErrNotDiscordCDN = errors.New("not a discord attachment url")
+	ErrTooLarge      = errors.New("file is too large")
+)
+
+// cdnHosts are where discord serves message attachments from
+var cdnHosts = map[string]bool{
+	"cdn.discordapp.com":   true,
+	"media.discordapp.net": true,
+}
+
+// Doer performs an http request
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// File is a file to attach to a message
+type File struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+// Uploader sends messages with file attachments (which the json api client cannot do) and downloads attachments
+type Uploader struct {
+	client *discordrest.Client
+	doer   Doer
+}
+
+// NewUploader creates a new Uploader; uploads go through the api client, and downloads (from the
+// cdn, which needs no authorization) through the doer
+func NewUploader(client *discordrest.Client, doer Doer) *Uploader {
+	return &Uploader{
+		client: client,
+		doer:   doer,
+	}
+}
+
+type attachment struct {
+	ID       int    `json:"id"`
+	Filename string `json:"filename"`
+}
+
+type messagePayload struct {
+	Content     string       `json:"content,omitempty"`
+	Attachments []attachment `json:"attachments"`
+}
+
+// SendToChannel posts a message with attachments to a channel
+func (u *Uploader) SendToChannel(ctx context.Context, cid snowflake.Snowflake, content string, files []File) error {
+	return u.send(ctx, fmt.Sprintf("/channels/%s/messages", cid.ToString()), content, files)
+}
+
+// SendFollowup posts a follow-up message with attachments to an interaction that has already been responded to
+func (u *Uploader) SendFollowup(ctx context.Context, appID snowflake.Snowflake, token, content string, files []File) error {
+	return u.send(ctx, fmt.Sprintf("/webhooks/%s/%s", appID.ToString(), token), content, files)
+}
+
+// multipartBody builds a multipart/form-data body in the shape discord expects for uploads
+func multipartBody(content string, files []File) (*bytes.Buffer, string, error) {
+	payload := messagePayload{
+		Content:     content,
+		Attachments: make([]attachment, 0, len(files)),
+	}
+	for i, f := range files {
+		payload.Attachments = append(payload.Attachments, attachment{ID: i, Filename: f.Name})
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "could not marshal upload payload")
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+
+	if err := w.WriteField("payload_json", string(payloadJSON)); err != nil {
+		return nil, "", errors.Wrap(err, "could not write upload payload")
+	}
+
+	for i, f := range files {
+		h := textproto.MIMEHeader{}
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="files[%d]"; filename=%q`, i, f.Name))
+		h.Set("Content-Type", f.ContentType)
+
+		part, err := w.CreatePart(h)
+		if err != nil {
+			return nil, "", errors.Wrap(err, "could not create upload part", "filename", f.Name)
+		}
+
+		if _, err := part.Write(f.Data); err != nil {
+			return nil, "", errors.Wrap(err, "could not write upload part", "filename", f.Name)
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", errors.Wrap(err, "could not finish upload body")
+	}
+
+	return body, w.FormDataContentType(), nil
+}
+
+func (u *Uploader) send(ctx context.Context, path, content string, files []File) error {
+	body, contentType, err := multipartBody(content, files)
+	if err != nil {
+		return err
+	}
+
+	_, err = u.client.Do(ctx, http.MethodPost, path, contentType, body.Bytes())
+	return errors.Wrap(err, "could not upload files")
+}
+
+// Fetch downloads a message attachment from the discord cdn; other urls are refused so that
+// commands cannot be used to make the bot request arbitrary addresses
+func (u *Uploader) Fetch(ctx context.Context, fileURL string, maxBytes int64) ([]byte, error) {
+	parsed, err := url.Parse(fileURL)
+	if err != nil || parsed.Scheme != "https" || !cdnHosts[parsed.Hostname()] {
+		return nil, errors.WithDetails(ErrNotDiscordCDN, "url", fileURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, parsed.String(), nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create download request")
+	}
+
+	// no bot authorization here; the cdn does not need it
+	resp, err := u.doer.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not download file")
+	}
+	defer resp.Body.Close() //nolint:errcheck // not needed
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, errors.Wrap(discordrest.ErrBadResponse, "could not download file", "status", resp.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read file")
+	}
+
+	if int64(len(data)) > maxBytes {
+		return nil, errors.WithDetails(ErrTooLarge, "max_bytes", maxBytes)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,51 @@
+package fileupload
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"testing"
+)
+
+func TestMultipartBody(t *testing.T) {
+	t.Parallel()
+
+	body, contentType, err := multipartBody("hello", []File{
+		{Name: "roster.csv", ContentType: "text/csv", Data: []byte("a,b\n")},
+	})
+	if err != nil {
+		t.Fatalf("multipartBody() error = %v", err)
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		t.Fatalf("could not parse content type %q: %v", contentType, err)
+	}
+
+	r := multipart.NewReader(body, params["boundary"])
+
+	part, err := r.NextPart()
+	if err != nil {
+		t.Fatalf("could not read payload part: %v", err)
+	}
+
+	var payload messagePayload
+	if err := json.NewDecoder(part).Decode(&payload); err != nil {
+		t.Fatalf("could not decode payload_json: %v", err)
+	}
+
+	if part.FormName() != "payload_json" || payload.Content != "hello" || len(payload.Attachments) != 1 || payload.Attachments[0].Filename != "roster.csv" {
+		t.Errorf("unexpected payload part %q: %+v", part.FormName(), payload)
+	}
+
+	part, err = r.NextPart()
+	if err != nil {
+		t.Fatalf("could not read file part: %v", err)
+	}
+
+	data, _ := ioutil.ReadAll(part)
+	if part.FormName() != "files[0]" || part.FileName() != "roster.csv" || string(data) != "a,b\n" {
+		t.Errorf("unexpected file part %q %q: %q", part.FormName(), part.FileName(), data)
+	}
+}
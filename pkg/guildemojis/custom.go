@@ -0,0 +1,60 @@
+package guildemojis
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	// customEmojiRe matches a custom emoji as written in a message (<:name:id> or <a:name:id>)
+	customEmojiRe = regexp.MustCompile(`^<(a?):(\w+):(\d+)>$`)
+	// bareEmojiRe matches a custom emoji as copied from a reaction (name:id or :name:id)
+	bareEmojiRe = regexp.MustCompile(`^:?(\w+):(\d+)$`)
+)
+
+// Custom is a custom (guild) emoji
+type Custom struct {
+	Name     string
+	ID       string
+	Animated bool
+}
+
+// String is the <:name:id> (or <a:name:id>) form used in messages
+func (e Custom) String() string {
+	if e.Animated {
+		return fmt.Sprintf("<a:%s:%s>", e.Name, e.ID)
+	}
+
+	return fmt.Sprintf("<:%s:%s>", e.Name, e.ID)
+}
+
+// ReactionName is the name:id form the api expects when reacting with the emoji (for animated emoji too)
+func (e Custom) ReactionName() string {
+	return fmt.Sprintf("%s:%s", e.Name, e.ID)
+}
+
+// ParseCustom parses a custom emoji written as <:name:id>, <a:name:id>, name:id or :name:id (spaces are ignored)
+func ParseCustom(s string) (Custom, bool) {
+	s = strings.Join(strings.Fields(s), "")
+
+	if m := customEmojiRe.FindStringSubmatch(s); m != nil {
+		return Custom{Name: m[2], ID: m[3], Animated: m[1] == "a"}, true
+	}
+
+	if m := bareEmojiRe.FindStringSubmatch(s); m != nil {
+		return Custom{Name: m[1], ID: m[2]}, true
+	}
+
+	return Custom{}, false
+}
+
+// ReactionEmoji converts an emoji as configured for a role into the form the api expects for reactions;
+// unicode emoji are only trimmed
+func ReactionEmoji(s string) string {
+	if e, ok := ParseCustom(s); ok {
+		return e.ReactionName()
+	}
+
+	return strings.TrimSpace(s)
+}
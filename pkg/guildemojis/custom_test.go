@@ -0,0 +1,22 @@
+package guildemojis
+
+import "testing"
+
+func TestReactionEmoji(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{" 🛡️ ", "🛡️"},
+		{"<:shield:123>", "shield:123"},
+		{"<a:dance:456>", "dance:456"},
+		{"< a:dance: 456 >", "dance:456"},
+		{":shield:123", "shield:123"},
+		{":shield:", ":shield:"},
+	} {
+		if got := ReactionEmoji(tt.in); got != tt.want {
+			t.Errorf("ReactionEmoji(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
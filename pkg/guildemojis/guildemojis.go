@@ -0,0 +1,48 @@
+package guildemojis
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+)
+
+// Lister looks up the custom emoji of a guild
+type Lister struct {
+	client *discordrest.Client
+}
+
+// NewLister creates a new Lister
+func NewLister(client *discordrest.Client) *Lister {
+	return &Lister{
+		client: client,
+	}
+}
+
+type emojiResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Emojis returns the ids of the custom emoji of a guild
+func (l *Lister) Emojis(ctx context.Context, gid snowflake.Snowflake) (map[snowflake.Snowflake]bool, error) {
+	var emojis []emojiResponse
+	if err := l.client.DoJSON(ctx, http.MethodGet, fmt.Sprintf("/guilds/%s/emojis", gid.ToString()), nil, &emojis); err != nil {
+		return nil, errors.Wrap(err, "could not list guild emojis")
+	}
+
+	ids := make(map[snowflake.Snowflake]bool, len(emojis))
+	for _, e := range emojis {
+		id, err := snowflake.FromString(e.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not parse emoji id", "id", e.ID)
+		}
+		ids[id] = true
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,41 @@
+package guildemojis
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+)
+
+type doerFunc func(*http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestEmojis(t *testing.T) {
+	t.Parallel()
+
+	var path string
+	doer := doerFunc(func(r *http.Request) (*http.Response, error) {
+		path = r.URL.Path
+		body := `[{"id": "123", "name": "shield"}, {"id": "456", "name": "dance", "animated": true}]`
+		return &http.Response{StatusCode: 200, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+	})
+
+	l := NewLister(discordrest.NewClient(doer, "https://discord.test/api", http.Header{}, nil, discordrest.Options{}))
+
+	ids, err := l.Emojis(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Emojis() error = %v", err)
+	}
+
+	if path != "/api/guilds/1/emojis" {
+		t.Errorf("requested %s", path)
+	}
+
+	if len(ids) != 2 || !ids[123] || !ids[456] {
+		t.Errorf("Emojis() = %v, want 123 and 456", ids)
+	}
+}
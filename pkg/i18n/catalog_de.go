@@ -0,0 +1,84 @@
+package i18n
+
+var german = map[Key]string{
+	SignedUp:            "Als %[1]s für %[2]s angemeldet",
+	SignedUpOverflow:    "Als %[1]s für %[2]s auf der Warteliste (OVERFLOW) angemeldet",
+	Withdrew:            "Von %s abgemeldet",
+	NotSignedUp:         "Du bist nicht für %s angemeldet",
+	SignupClosed:        "Anmeldung für ein geschlossenes Event nicht möglich",
+	WithdrawClosed:      "Abmeldung von einem geschlossenen Event nicht möglich",
+	SignupNoteTooLong:   "Die Anmeldenotiz ist zu lang",
+	UnknownRole:         "Unbekannte Rolle",
+	UnknownRoleSuggest:  "Unbekannte Rolle '%s' (meintest du '%s'?)",
+	RoleRequirements:    "Für die Anmeldung als %s wird eine dieser Rollen benötigt: %s",
+	SignupLimit:         "Du kannst nur für %d offene Events gleichzeitig angemeldet sein und bist bereits angemeldet für: %s",
+	SignupLimitCategory: "Du kannst nur für %d offene %s-Events gleichzeitig angemeldet sein und bist bereits angemeldet für: %s",
+	UnknownEventMessage: "Es konnte nicht ermittelt werden, zu welchem Event diese Nachricht gehört",
+
+	EventTime:      "Wann: %s",
+	RoleEmpty:      "(leer)",
+	RoleOverflow:   "Warteliste %s",
+	Page:           "Seite %d/%d",
+	PagePrevious:   "Zurück",
+	PageNext:       "Weiter",
+	StateOpen:      "offen",
+	StateClosed:    "geschlossen",
+	ButtonWithdraw: "Abmelden",
+	ButtonShow:     "Aufstellung anzeigen",
+
+	AnnounceTitle:          "Die Anmeldung für %s ist offen",
+	AnnounceRoles:          "Gesuchte Rollen",
+	AnnounceSignupChannel:  "Anmeldekanal",
+	AnnounceFilled:         "(%d angemeldet)",
+	AnnounceFilledOverflow: "(%d angemeldet; %d auf der Warteliste)",
+	SignupsNowOpen:         "Die Anmeldung für %s ist jetzt offen!",
+	SignupsNowClosed:       "Die Anmeldung für %s ist jetzt geschlossen.",
+	GroupingNow:            "Jetzt wird für %s gruppiert!",
+	ReminderMember:         "Erinnerung: **%s** beginnt %s. Du bist als %s angemeldet.",
+	ReminderRoster:         "Erinnerung: **%s** beginnt %s.",
+
+	ArgsMissingRole:    "Rolle fehlt",
+	ArgsRolePairs:      "Falsche Anzahl an Argumenten",
+	ArgsMissingEvent:   "Eventname fehlt",
+	ArgsShowUsage:      "Gib einen Eventnamen und optional eine Seitenzahl an; fehlen Anführungszeichen?",
+	ArgsPageNumber:     "Die Seite muss eine Zahl sein",
+	ArgsRemindersUsage: "Verwendung: reminders [on|off]",
+	ArgsStatsDays:      "Die Anzahl der Tage muss eine positive Zahl sein, nicht '%s'",
+
+	AttendanceTitle:        "Anwesenheit für %s (%s)",
+	AttendanceNone:         "Keine Anwesenheit für %s (%s) erfasst",
+	AttendanceAttended:     "Anwesend",
+	AttendanceLate:         "Verspätet",
+	AttendanceNoShow:       "Nicht erschienen",
+	AttendanceMissingEvent: "Eventname fehlt",
+	AttendanceMentionFirst: "Erwähnungen müssen auf einen Status folgen (z. B. late=@user)",
+	AttendanceNotMention:   "Keine Benutzererwähnung: %s",
+	AttendanceNotSignedUp:  "Anwesenheit konnte nicht erfasst werden; nicht für das Event angemeldet: %s",
+	StatsTitle:             "Statistik für %s in den letzten %d Tagen",
+	StatsNone:              "Keine Aktivität von %s in den letzten %d Tagen",
+	StatsNoRole:            "(keine Rolle)",
+	StatsCounts:            "%d Anmeldungen, %d Abmeldungen (%d kurzfristig), %d anwesend, %d verspätet, %d nicht erschienen",
+	StatsTotal:             "**Gesamt**: %s; Zuverlässigkeit %d%%",
+	LeaderboardTitle:       "Zuverlässigkeit in den letzten %d Tagen",
+	LeaderboardTitleRole:   "Zuverlässigkeit als %s in den letzten %d Tagen",
+	LeaderboardEmpty:       "(noch keine Anmeldungen)",
+	ImportMissingArgs:      "Eventname und Datei-URL fehlen",
+	ImportUsage:            "Verwendung: import EVENT DATEI-URL [dryrun]",
+	ImportFailed:           "Es wurde nichts in %s importiert; korrigiere diese Zeilen und versuche es erneut:",
+	ImportDryRun:           "Alle %d Zeilen für %s sind gültig. Führe den Import ohne dry run erneut aus, um sie zu übernehmen.",
+	ImportDone:             "%d Anmeldungen in %s importiert.",
+	ImportMainGroup:        "**Hauptgruppe:** %s",
+	ImportOverflow:         "**Warteliste:** %s",
+	ImportLineError:        "Zeile %d: %s",
+	ImportRowError:         "Zeile %d (%s): %s",
+	ImportColumns:          "Zeile %d: 2 Spalten erwartet (Benutzer, Rolle), %d gefunden",
+	ImportSameUser:         "derselbe Benutzer wie in Zeile %d",
+	ImportNotMember:        "kein Mitglied dieses Servers",
+
+	GroupsSizeMin:      "Die Größe muss mindestens 1 sein",
+	GroupsSummary:      "%d Gruppe(n) mit bis zu %d für %s\nPro Gruppe: %s",
+	GroupsUnassigned:   "%d Anmeldung(en) passten in keine Gruppe",
+	GroupsTitle:        "Gruppe %d (%d/%d)",
+	GroupsNotGrouped:   "Nicht gruppiert",
+	GroupsOverflowName: "%s (Warteliste)",
+}
@@ -0,0 +1,84 @@
+package i18n
+
+var english = map[Key]string{
+	SignedUp:            "Signed up for %s in %s",
+	SignedUpOverflow:    "Signed up as OVERFLOW for %s in %s",
+	Withdrew:            "Withdrew from %s",
+	NotSignedUp:         "You are not signed up for %s",
+	SignupClosed:        "cannot sign up for a closed event",
+	WithdrawClosed:      "cannot withdraw from a closed event",
+	SignupNoteTooLong:   "signup note is too long",
+	UnknownRole:         "unknown role",
+	UnknownRoleSuggest:  "unknown role '%s' (did you mean '%s'?)",
+	RoleRequirements:    "signing up as %s requires one of these roles: %s",
+	SignupLimit:         "you may only be signed up for %d open events at once, and are already signed up for: %s",
+	SignupLimitCategory: "you may only be signed up for %d open %s events at once, and are already signed up for: %s",
+	UnknownEventMessage: "could not tell which event this message is for",
+
+	EventTime:      "When: %s",
+	RoleEmpty:      "(empty)",
+	RoleOverflow:   "Overflow %s",
+	Page:           "page %d/%d",
+	PagePrevious:   "Previous",
+	PageNext:       "Next",
+	StateOpen:      "open",
+	StateClosed:    "closed",
+	ButtonWithdraw: "Withdraw",
+	ButtonShow:     "Show roster",
+
+	AnnounceTitle:          "Signups are open for %s",
+	AnnounceRoles:          "Roles Requested",
+	AnnounceSignupChannel:  "Signup Channel",
+	AnnounceFilled:         "(%d signed up)",
+	AnnounceFilledOverflow: "(%d signed up; %d overflow)",
+	SignupsNowOpen:         "Signups are now open for %s!",
+	SignupsNowClosed:       "Signups are now closed for %s.",
+	GroupingNow:            "Grouping now for %s!",
+	ReminderMember:         "Reminder: **%s** starts %s. You are signed up as %s.",
+	ReminderRoster:         "Reminder: **%s** starts %s.",
+
+	ArgsMissingRole:    "missing role",
+	ArgsRolePairs:      "incorrect number of arguments",
+	ArgsMissingEvent:   "missing event name",
+	ArgsShowUsage:      "you must supply a trial name and optionally a page number; are you missing quotes?",
+	ArgsPageNumber:     "the page must be a number",
+	ArgsRemindersUsage: "usage: reminders [on|off]",
+	ArgsStatsDays:      "days must be a positive number, not '%s'",
+
+	AttendanceTitle:        "Attendance for %s (%s)",
+	AttendanceNone:         "No attendance recorded for %s (%s)",
+	AttendanceAttended:     "Attended",
+	AttendanceLate:         "Late",
+	AttendanceNoShow:       "No-Show",
+	AttendanceMissingEvent: "need event name",
+	AttendanceMentionFirst: "user mentions must follow a status (e.g., late=@user)",
+	AttendanceNotMention:   "not a user mention: %s",
+	AttendanceNotSignedUp:  "could not take attendance; not signed up for the event: %s",
+	StatsTitle:             "Stats for %s over the last %d days",
+	StatsNone:              "No activity for %s in the last %d days",
+	StatsNoRole:            "(no role)",
+	StatsCounts:            "%d signups, %d withdrawals (%d late), %d attended, %d late, %d no-shows",
+	StatsTotal:             "**Total**: %s; reliability %d%%",
+	LeaderboardTitle:       "Reliability over the last %d days",
+	LeaderboardTitleRole:   "Reliability as %s over the last %d days",
+	LeaderboardEmpty:       "(no signups yet)",
+	ImportMissingArgs:      "need event name and file url",
+	ImportUsage:            "usage: import EVENT FILE-URL [dryrun]",
+	ImportFailed:           "Nothing was imported into %s; fix these rows and try again:",
+	ImportDryRun:           "All %d rows are valid for %s. Run the import again without dry run to apply them.",
+	ImportDone:             "Imported %d signups into %s.",
+	ImportMainGroup:        "**Main Group:** %s",
+	ImportOverflow:         "**Overflow:** %s",
+	ImportLineError:        "line %d: %s",
+	ImportRowError:         "line %d (%s): %s",
+	ImportColumns:          "line %d: expected 2 columns (user, role), got %d",
+	ImportSameUser:         "same user as line %d",
+	ImportNotMember:        "not a member of this server",
+
+	GroupsSizeMin:      "size must be at least 1",
+	GroupsSummary:      "%d group(s) of up to %d for %s\nPer group: %s",
+	GroupsUnassigned:   "%d signup(s) did not fit in a group",
+	GroupsTitle:        "Group %d (%d/%d)",
+	GroupsNotGrouped:   "Not grouped",
+	GroupsOverflowName: "%s (overflow)",
+}
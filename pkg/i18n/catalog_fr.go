@@ -0,0 +1,84 @@
+package i18n
+
+var french = map[Key]string{
+	SignedUp:            "Inscrit(e) en tant que %s pour %s",
+	SignedUpOverflow:    "Inscrit(e) en liste d'attente (OVERFLOW) en tant que %s pour %s",
+	Withdrew:            "Désinscrit(e) de %s",
+	NotSignedUp:         "Vous n'êtes pas inscrit(e) à %s",
+	SignupClosed:        "impossible de s'inscrire à un événement fermé",
+	WithdrawClosed:      "impossible de se désinscrire d'un événement fermé",
+	SignupNoteTooLong:   "la note d'inscription est trop longue",
+	UnknownRole:         "rôle inconnu",
+	UnknownRoleSuggest:  "rôle inconnu '%s' (vouliez-vous dire '%s' ?)",
+	RoleRequirements:    "l'inscription en tant que %s nécessite l'un de ces rôles : %s",
+	SignupLimit:         "vous ne pouvez être inscrit(e) qu'à %d événements ouverts à la fois, et vous êtes déjà inscrit(e) à : %s",
+	SignupLimitCategory: "vous ne pouvez être inscrit(e) qu'à %d événements %s ouverts à la fois, et vous êtes déjà inscrit(e) à : %s",
+	UnknownEventMessage: "impossible de déterminer à quel événement correspond ce message",
+
+	EventTime:      "Quand : %s",
+	RoleEmpty:      "(vide)",
+	RoleOverflow:   "Liste d'attente %s",
+	Page:           "page %d/%d",
+	PagePrevious:   "Précédent",
+	PageNext:       "Suivant",
+	StateOpen:      "ouvert",
+	StateClosed:    "fermé",
+	ButtonWithdraw: "Se désinscrire",
+	ButtonShow:     "Voir la liste",
+
+	AnnounceTitle:          "Les inscriptions sont ouvertes pour %s",
+	AnnounceRoles:          "Rôles recherchés",
+	AnnounceSignupChannel:  "Salon d'inscription",
+	AnnounceFilled:         "(%d inscrit(s))",
+	AnnounceFilledOverflow: "(%d inscrit(s) ; %d en liste d'attente)",
+	SignupsNowOpen:         "Les inscriptions sont maintenant ouvertes pour %s !",
+	SignupsNowClosed:       "Les inscriptions sont maintenant fermées pour %s.",
+	GroupingNow:            "Formation des groupes pour %s !",
+	ReminderMember:         "Rappel : **%s** commence %s. Vous êtes inscrit(e) en tant que %s.",
+	ReminderRoster:         "Rappel : **%s** commence %s.",
+
+	ArgsMissingRole:    "rôle manquant",
+	ArgsRolePairs:      "nombre d'arguments incorrect",
+	ArgsMissingEvent:   "nom de l'événement manquant",
+	ArgsShowUsage:      "vous devez indiquer un nom d'événement et éventuellement un numéro de page ; manque-t-il des guillemets ?",
+	ArgsPageNumber:     "la page doit être un nombre",
+	ArgsRemindersUsage: "utilisation : reminders [on|off]",
+	ArgsStatsDays:      "le nombre de jours doit être un nombre positif, pas '%s'",
+
+	AttendanceTitle:        "Présences pour %s (%s)",
+	AttendanceNone:         "Aucune présence enregistrée pour %s (%s)",
+	AttendanceAttended:     "Présent(e)",
+	AttendanceLate:         "En retard",
+	AttendanceNoShow:       "Absent(e)",
+	AttendanceMissingEvent: "nom de l'événement manquant",
+	AttendanceMentionFirst: "les mentions doivent suivre un statut (par ex. late=@user)",
+	AttendanceNotMention:   "pas une mention d'utilisateur : %s",
+	AttendanceNotSignedUp:  "impossible d'enregistrer les présences ; pas inscrit(e) à l'événement : %s",
+	StatsTitle:             "Statistiques de %s sur les %d derniers jours",
+	StatsNone:              "Aucune activité de %s sur les %d derniers jours",
+	StatsNoRole:            "(aucun rôle)",
+	StatsCounts:            "%d inscriptions, %d désinscriptions (%d tardives), %d présences, %d retards, %d absences",
+	StatsTotal:             "**Total** : %s ; fiabilité %d %%",
+	LeaderboardTitle:       "Fiabilité sur les %d derniers jours",
+	LeaderboardTitleRole:   "Fiabilité en tant que %s sur les %d derniers jours",
+	LeaderboardEmpty:       "(aucune inscription pour l'instant)",
+	ImportMissingArgs:      "nom de l'événement et URL du fichier manquants",
+	ImportUsage:            "utilisation : import EVENT URL-FICHIER [dryrun]",
+	ImportFailed:           "Rien n'a été importé dans %s ; corrigez ces lignes et réessayez :",
+	ImportDryRun:           "Les %d lignes sont valides pour %s. Relancez l'import sans dry run pour les appliquer.",
+	ImportDone:             "%d inscriptions importées dans %s.",
+	ImportMainGroup:        "**Groupe principal :** %s",
+	ImportOverflow:         "**Liste d'attente :** %s",
+	ImportLineError:        "ligne %d : %s",
+	ImportRowError:         "ligne %d (%s) : %s",
+	ImportColumns:          "ligne %d : 2 colonnes attendues (utilisateur, rôle), %d trouvées",
+	ImportSameUser:         "même utilisateur qu'à la ligne %d",
+	ImportNotMember:        "n'est pas membre de ce serveur",
+
+	GroupsSizeMin:      "la taille doit être d'au moins 1",
+	GroupsSummary:      "%d groupe(s) de %d maximum pour %s\nPar groupe : %s",
+	GroupsUnassigned:   "%d inscription(s) n'ont pu être placées dans aucun groupe",
+	GroupsTitle:        "Groupe %d (%d/%d)",
+	GroupsNotGrouped:   "Sans groupe",
+	GroupsOverflowName: "%s (liste d'attente)",
+}
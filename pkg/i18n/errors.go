@@ -0,0 +1,66 @@
+package i18n
+
+// Error is an error whose message can be shown to users in their guild's language; its
+// Error() text is in English, for logs
+type Error struct {
+	key   Key
+	args  []interface{}
+	cause error
+}
+
+// NewError creates an error with a translatable message
+func NewError(key Key, args ...interface{}) error {
+	return &Error{
+		key:  key,
+		args: args,
+	}
+}
+
+// WrapError gives cause a translatable message, keeping cause available for comparisons
+func WrapError(cause error, key Key, args ...interface{}) error {
+	if cause == nil {
+		return nil
+	}
+
+	return &Error{
+		key:   key,
+		args:  args,
+		cause: cause,
+	}
+}
+
+func (e *Error) Error() string {
+	msg := Sprintf(Default, e.key, e.args...)
+	if e.cause != nil {
+		msg += ": " + e.cause.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns the wrapped error, if any
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Localize formats the message in the given language
+func (e *Error) Localize(lang string) string {
+	return Sprintf(lang, e.key, e.args...)
+}
+
+// Find returns the outermost translatable error in an error chain
+func Find(err error) (*Error, bool) {
+	for err != nil {
+		if e, ok := err.(*Error); ok {
+			return e, true
+		}
+
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return nil, false
+		}
+		err = u.Unwrap()
+	}
+
+	return nil, false
+}
@@ -0,0 +1,103 @@
+// Package i18n holds the catalog of user-facing bot messages and their translations
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported languages
+const (
+	English = "en"
+	German  = "de"
+	French  = "fr"
+)
+
+// Default is the language used for guilds that have not chosen one, and for messages
+// missing from a catalog
+const Default = English
+
+// Key identifies a message in the catalogs
+type Key string
+
+var catalogs = map[string]map[Key]string{
+	English: english,
+	German:  german,
+	French:  french,
+}
+
+// Languages lists the supported language codes
+func Languages() []string {
+	langs := make([]string, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+
+	return langs
+}
+
+// Normalize converts a language setting to a supported language code, if possible;
+// an empty setting means the default language
+func Normalize(lang string) (string, bool) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	if lang == "" {
+		return Default, true
+	}
+
+	_, ok := catalogs[lang]
+	return lang, ok
+}
+
+// Sprintf formats the message for key in the given language, falling back to English
+// when the language or the message translation is missing
+func Sprintf(lang string, key Key, args ...interface{}) string {
+	return sprintf(catalogs, lang, key, args...)
+}
+
+func sprintf(cats map[string]map[Key]string, lang string, key Key, args ...interface{}) string {
+	msg, ok := cats[lang][key]
+	if !ok {
+		msg, ok = cats[Default][key]
+	}
+
+	if !ok {
+		return string(key)
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
+
+// Printer formats catalog messages in a single language
+type Printer struct {
+	lang string
+}
+
+// NewPrinter creates a Printer for a guild's language setting
+func NewPrinter(lang string) Printer {
+	if l, ok := Normalize(lang); ok {
+		return Printer{lang: l}
+	}
+
+	return Printer{lang: Default}
+}
+
+// Language is the language code the Printer formats messages in
+func (p Printer) Language() string {
+	return p.lang
+}
+
+// Sprintf formats the message for key
+func (p Printer) Sprintf(key Key, args ...interface{}) string {
+	return Sprintf(p.lang, key, args...)
+}
+
+// Error formats err for users, translating it if it is (or wraps) a translatable error
+func (p Printer) Error(err error) string {
+	if e, ok := Find(err); ok {
+		return e.Localize(p.lang)
+	}
+
+	return err.Error()
+}
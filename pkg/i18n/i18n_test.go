@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var verbRe = regexp.MustCompile(`%(\[\d+\])?[a-z]`)
+
+// sampleArgs builds arguments matching the verbs of the English message
+func sampleArgs(msg string) []interface{} {
+	var args []interface{}
+	for _, v := range verbRe.FindAllString(msg, -1) {
+		if strings.HasSuffix(v, "d") {
+			args = append(args, 1)
+		} else {
+			args = append(args, "x")
+		}
+	}
+
+	return args
+}
+
+func TestCatalogs(t *testing.T) {
+	t.Parallel()
+
+	for lang, catalog := range catalogs {
+		for key, msg := range catalog {
+			en, ok := english[key]
+			if !ok {
+				t.Errorf("%s: key %q is not in the English catalog", lang, key)
+				continue
+			}
+
+			if got := fmt.Sprintf(msg, sampleArgs(en)...); strings.Contains(got, "%!") {
+				t.Errorf("%s: message %q does not take the same arguments as %q: %s", lang, key, en, got)
+			}
+		}
+	}
+}
+
+func TestSprintfFallback(t *testing.T) {
+	t.Parallel()
+
+	if got := Sprintf(German, Withdrew, "Raid"); got != "Von Raid abgemeldet" {
+		t.Errorf("got %q", got)
+	}
+
+	if got := Sprintf("xx", Withdrew, "Raid"); got != "Withdrew from Raid" {
+		t.Errorf("unknown language: got %q", got)
+	}
+
+	if got := Sprintf(French, Key("missing"), "Raid"); got != "missing" {
+		t.Errorf("unknown key: got %q", got)
+	}
+
+	cats := map[string]map[Key]string{
+		English: {Withdrew: "Withdrew from %s", NotSignedUp: "You are not signed up for %s"},
+		German:  {Withdrew: "Von %s abgemeldet"},
+	}
+	if got := sprintf(cats, German, NotSignedUp, "Raid"); got != "You are not signed up for Raid" {
+		t.Errorf("missing translation: got %q", got)
+	}
+}
+
+func TestNewPrinter(t *testing.T) {
+	t.Parallel()
+
+	if p := NewPrinter(" DE "); p.Language() != German {
+		t.Errorf("got %q", p.Language())
+	}
+
+	if p := NewPrinter(""); p.Language() != English {
+		t.Errorf("got %q", p.Language())
+	}
+
+	if p := NewPrinter("klingon"); p.Language() != English {
+		t.Errorf("got %q", p.Language())
+	}
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	sentinel := errors.New("sentinel")
+	err := fmt.Errorf("outer: %w", WrapError(sentinel, UnknownRole))
+
+	e, ok := Find(err)
+	if !ok {
+		t.Fatal("translatable error not found")
+	}
+
+	if got := e.Localize(French); got != "rôle inconnu" {
+		t.Errorf("got %q", got)
+	}
+
+	if !errors.Is(err, sentinel) {
+		t.Error("wrapped error lost its cause")
+	}
+
+	if got := e.Error(); got != "unknown role: sentinel" {
+		t.Errorf("got %q", got)
+	}
+
+	if _, ok := Find(sentinel); ok {
+		t.Error("found a translatable error where there was none")
+	}
+}
+
+func TestPrinterError(t *testing.T) {
+	t.Parallel()
+
+	p := NewPrinter(German)
+
+	if got := p.Error(fmt.Errorf("outer: %w", NewError(UnknownRole))); got != "Unbekannte Rolle" {
+		t.Errorf("got %q", got)
+	}
+
+	if got := p.Error(errors.New("plain")); got != "plain" {
+		t.Errorf("got %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+package i18n
+
+// Signup and withdraw responses
+const (
+	SignedUp            Key = "signed_up"
+	SignedUpOverflow    Key = "signed_up_overflow"
+	Withdrew            Key = "withdrew"
+	NotSignedUp         Key = "not_signed_up"
+	SignupClosed        Key = "signup_closed"
+	WithdrawClosed      Key = "withdraw_closed"
+	SignupNoteTooLong   Key = "signup_note_too_long"
+	UnknownRole         Key = "unknown_role"
+	UnknownRoleSuggest  Key = "unknown_role_suggest"
+	RoleRequirements    Key = "role_requirements"
+	SignupLimit         Key = "signup_limit"
+	SignupLimitCategory Key = "signup_limit_category"
+	UnknownEventMessage Key = "unknown_event_message"
+)
+
+// Event rosters
+const (
+	EventTime      Key = "event_time"
+	RoleEmpty      Key = "role_empty"
+	RoleOverflow   Key = "role_overflow"
+	Page           Key = "page"
+	PagePrevious   Key = "page_previous"
+	PageNext       Key = "page_next"
+	StateOpen      Key = "state_open"
+	StateClosed    Key = "state_closed"
+	ButtonWithdraw Key = "button_withdraw"
+	ButtonShow     Key = "button_show"
+)
+
+// Announcements and reminders
+const (
+	AnnounceTitle          Key = "announce_title"
+	AnnounceRoles          Key = "announce_roles"
+	AnnounceSignupChannel  Key = "announce_signup_channel"
+	AnnounceFilled         Key = "announce_filled"
+	AnnounceFilledOverflow Key = "announce_filled_overflow"
+	SignupsNowOpen         Key = "signups_now_open"
+	SignupsNowClosed       Key = "signups_now_closed"
+	GroupingNow            Key = "grouping_now"
+	ReminderMember         Key = "reminder_member"
+	ReminderRoster         Key = "reminder_roster"
+)
+
+// Command arguments
+const (
+	ArgsMissingRole    Key = "args_missing_role"
+	ArgsRolePairs      Key = "args_role_pairs"
+	ArgsMissingEvent   Key = "args_missing_event"
+	ArgsShowUsage      Key = "args_show_usage"
+	ArgsPageNumber     Key = "args_page_number"
+	ArgsRemindersUsage Key = "args_reminders_usage"
+	ArgsStatsDays      Key = "args_stats_days"
+)
+
+// Attendance, stats and imports
+const (
+	AttendanceTitle        Key = "attendance_title"
+	AttendanceNone         Key = "attendance_none"
+	AttendanceAttended     Key = "attendance_attended"
+	AttendanceLate         Key = "attendance_late"
+	AttendanceNoShow       Key = "attendance_no_show"
+	AttendanceMissingEvent Key = "attendance_missing_event"
+	AttendanceMentionFirst Key = "attendance_mention_first"
+	AttendanceNotMention   Key = "attendance_not_mention"
+	AttendanceNotSignedUp  Key = "attendance_not_signed_up"
+	StatsTitle             Key = "stats_title"
+	StatsNone              Key = "stats_none"
+	StatsNoRole            Key = "stats_no_role"
+	StatsCounts            Key = "stats_counts"
+	StatsTotal             Key = "stats_total"
+	LeaderboardTitle       Key = "leaderboard_title"
+	LeaderboardTitleRole   Key = "leaderboard_title_role"
+	LeaderboardEmpty       Key = "leaderboard_empty"
+	ImportMissingArgs      Key = "import_missing_args"
+	ImportUsage            Key = "import_usage"
+	ImportFailed           Key = "import_failed"
+	ImportDryRun           Key = "import_dry_run"
+	ImportDone             Key = "import_done"
+	ImportMainGroup        Key = "import_main_group"
+	ImportOverflow         Key = "import_overflow"
+	ImportLineError        Key = "import_line_error"
+	ImportRowError         Key = "import_row_error"
+	ImportColumns          Key = "import_columns"
+	ImportSameUser         Key = "import_same_user"
+	ImportNotMember        Key = "import_not_member"
+)
+
+// Groups
+const (
+	GroupsSizeMin      Key = "groups_size_min"
+	GroupsSummary      Key = "groups_summary"
+	GroupsUnassigned   Key = "groups_unassigned"
+	GroupsTitle        Key = "groups_title"
+	GroupsNotGrouped   Key = "groups_not_grouped"
+	GroupsOverflowName Key = "groups_overflow_name"
+)
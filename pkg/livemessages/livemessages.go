@@ -0,0 +1,262 @@
+package livemessages
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/cmdhandler"
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"golang.org/x/time/rate"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type Logger = interface {
+	Log(keyvals ...interface{}) error
+	Message(string, ...interface{})
+	Err(string, error, ...interface{})
+	Printf(string, ...interface{})
+}
+
+type dependencies interface {
+	Logger() Logger
+	LiveMessageAPI() storage.LiveMessageAPI
+	MessageRateLimiter() *rate.Limiter
+	Census() *telemetry.Census
+}
+
+// Renderer produces the current contents of a live message for an event
+type Renderer interface {
+	Render(ctx context.Context, gid snowflake.Snowflake, eventName, kind string) (cmdhandler.Response, error)
+}
+
+var (
+	ErrNoEmbed = errors.New("message has no embed")
+	ErrTooLong = errors.New("roster no longer fits in one message")
+)
+
+// errMessageGone is returned when the tracked message has been deleted
+var errMessageGone = errors.New("message no longer exists")
+
+// Options configures the update behavior
+type Options struct {
+	Debounce    time.Duration
+	MaxPerEvent int
+	QueueSize   int
+}
+
+// eventKey identifies an event; names are compared case-insensitively, as in the events table
+type eventKey struct {
+	guildID   snowflake.Snowflake
+	eventName string
+}
+
+// Updater keeps announcement and show messages in sync with their event roster.
+//
+// Refresh never blocks: updates for the same event are debounced into a single round of edits,
+// and every edit waits on the shared message rate limiter.
+type Updater struct {
+	deps     dependencies
+	renderer Renderer
+	client   *discordrest.Client
+	opts     Options
+	updates  chan eventKey
+
+	mu      sync.Mutex
+	pending map[eventKey]bool
+}
+
+// NewUpdater creates a new Updater
+func NewUpdater(deps dependencies, renderer Renderer, client *discordrest.Client, opts Options) *Updater {
+	if opts.Debounce <= 0 {
+		opts.Debounce = 5 * time.Second
+	}
+
+	if opts.MaxPerEvent <= 0 {
+		opts.MaxPerEvent = 5
+	}
+
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 256
+	}
+
+	return &Updater{
+		deps:     deps,
+		renderer: renderer,
+		client:   client,
+		opts:     opts,
+		updates:  make(chan eventKey, opts.QueueSize),
+		pending:  map[eventKey]bool{},
+	}
+}
+
+// Track remembers a posted message that shows an event so that later roster changes are edited into it
+func (u *Updater) Track(ctx context.Context, gid, cid, mid snowflake.Snowflake, eventName, kind string) error {
+	return u.deps.LiveMessageAPI().AddLiveMessage(ctx, storage.LiveMessage{
+		MessageID: mid.ToString(),
+		ChannelID: cid.ToString(),
+		GuildID:   gid.ToString(),
+		EventName: strings.ToLower(eventName),
+		Kind:      kind,
+	}, u.opts.MaxPerEvent)
+}
+
+// Refresh schedules the live messages of an event to be re-rendered
+func (u *Updater) Refresh(gid snowflake.Snowflake, eventName string) {
+	k := eventKey{guildID: gid, eventName: strings.ToLower(eventName)}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.pending[k] {
+		return
+	}
+	u.pending[k] = true
+
+	time.AfterFunc(u.opts.Debounce, func() {
+		u.mu.Lock()
+		delete(u.pending, k)
+		u.mu.Unlock()
+
+		select {
+		case u.updates <- k:
+		default:
+			level.Error(u.deps.Logger()).Message("live message queue full; dropping update", "guild_id", k.guildID.ToString(), "event_name", k.eventName)
+		}
+	})
+}
+
+// Start applies scheduled updates until the context is canceled
+func (u *Updater) Start(ctx context.Context) error {
+	level.Info(u.deps.Logger()).Message("starting live message updater")
+
+	for {
+		select {
+		case <-ctx.Done():
+			level.Info(u.deps.Logger()).Message("stopping live message updater")
+			return nil
+		case k := <-u.updates:
+			u.update(ctx, k)
+		}
+	}
+}
+
+// update edits every tracked message of an event with a fresh rendering
+func (u *Updater) update(ctx context.Context, k eventKey) {
+	ctx, span := u.deps.Census().StartSpan(ctx, "livemessages.update", "guild_id", k.guildID.ToString())
+	defer span.End()
+
+	logger := u.deps.Logger()
+
+	msgs, err := u.deps.LiveMessageAPI().ListLiveMessages(ctx, k.guildID.ToString(), k.eventName)
+	if err != nil {
+		level.Error(logger).Err("could not list live messages", err, "guild_id", k.guildID.ToString(), "event_name", k.eventName)
+		return
+	}
+
+	payloads := map[string][]byte{}
+	for _, m := range msgs {
+		body, ok := payloads[m.Kind]
+		if !ok {
+			body, err = u.render(ctx, k, m.Kind)
+			if err == storage.ErrTrialNotExist {
+				level.Info(logger).Message("event is gone; forgetting live messages", "guild_id", k.guildID.ToString(), "event_name", k.eventName)
+				if err = u.deps.LiveMessageAPI().RemoveEventLiveMessages(ctx, k.guildID.ToString(), k.eventName); err != nil {
+					level.Error(logger).Err("could not remove live messages", err, "guild_id", k.guildID.ToString(), "event_name", k.eventName)
+				}
+				return
+			}
+
+			if err != nil {
+				level.Error(logger).Err("could not render live message", err, "guild_id", k.guildID.ToString(), "event_name", k.eventName, "kind", m.Kind)
+			}
+
+			payloads[m.Kind] = body
+		}
+
+		if body == nil {
+			continue
+		}
+
+		if err = u.deps.MessageRateLimiter().Wait(ctx); err != nil {
+			level.Error(logger).Err("error waiting for ratelimiting", err)
+			return
+		}
+
+		err = u.edit(ctx, m.ChannelID, m.MessageID, body)
+		if err == errMessageGone {
+			level.Info(logger).Message("live message was deleted; forgetting it", "message_id", m.MessageID)
+			if err = u.deps.LiveMessageAPI().RemoveLiveMessage(ctx, m.MessageID); err != nil {
+				level.Error(logger).Err("could not remove live message", err, "message_id", m.MessageID)
+			}
+			continue
+		}
+
+		if err != nil {
+			level.Error(logger).Err("could not edit live message", err, "channel_id", m.ChannelID, "message_id", m.MessageID)
+		}
+	}
+}
+
+func (u *Updater) render(ctx context.Context, k eventKey, kind string) ([]byte, error) {
+	resp, err := u.renderer.Render(ctx, k.guildID, k.eventName, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	// an edit cannot turn one message into several, so a roster that has outgrown
+	// its message is left as it was
+	parts := resp.Split()
+	if len(parts) != 1 {
+		return nil, ErrTooLong
+	}
+
+	return editPayload(parts[0].ToMessage())
+}
+
+// editPayload keeps only the embeds of a message; the content (with its mentions) and any
+// reply reference of the original message are left alone
+func editPayload(msg interface{}) ([]byte, error) {
+	full, err := json.Marshal(msg)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal message")
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(full, &fields); err != nil {
+		return nil, errors.Wrap(err, "could not unmarshal message")
+	}
+
+	edit := map[string]json.RawMessage{}
+	for _, key := range []string{"embed", "embeds"} {
+		if v, ok := fields[key]; ok {
+			edit[key] = v
+		}
+	}
+
+	if len(edit) == 0 {
+		return nil, ErrNoEmbed
+	}
+
+	body, err := json.Marshal(edit)
+	return body, errors.Wrap(err, "could not marshal message edit")
+}
+
+func (u *Updater) edit(ctx context.Context, cid, mid string, body []byte) error {
+	_, err := u.client.Do(ctx, http.MethodPatch, fmt.Sprintf("/channels/%s/messages/%s", cid, mid), "application/json", body)
+	if stderrors.Is(err, discordrest.ErrNotFound) {
+		return errMessageGone
+	}
+
+	return errors.Wrap(err, "could not edit message")
+}
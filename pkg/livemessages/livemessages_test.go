@@ -0,0 +1,94 @@
+package livemessages
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEditPayload(t *testing.T) {
+	t.Parallel()
+
+	type message struct {
+		Content string                   `json:"content"`
+		Embeds  []map[string]interface{} `json:"embeds,omitempty"`
+		Ref     map[string]string        `json:"message_reference,omitempty"`
+	}
+
+	tests := []struct {
+		name    string
+		msg     interface{}
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "keeps only embeds",
+			msg: message{
+				Content: "@everyone come sign up",
+				Embeds:  []map[string]interface{}{{"title": "Raid"}},
+				Ref:     map[string]string{"message_id": "1"},
+			},
+			want: `{"embeds":[{"title":"Raid"}]}`,
+		},
+		{
+			name:    "no embed",
+			msg:     message{Content: "hello"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := editPayload(tt.msg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("editPayload() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("editPayload() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshDebounces(t *testing.T) {
+	t.Parallel()
+
+	u := NewUpdater(nil, nil, nil, Options{Debounce: 20 * time.Millisecond})
+
+	for i := 0; i < 5; i++ {
+		u.Refresh(1, "Raid")
+	}
+	u.Refresh(2, "Raid")
+
+	seen := map[eventKey]int{}
+	timeout := time.After(200 * time.Millisecond)
+	for len(seen) < 2 {
+		select {
+		case k := <-u.updates:
+			seen[k]++
+		case <-timeout:
+			t.Fatalf("timed out waiting for updates; got %v", seen)
+		}
+	}
+
+	select {
+	case k := <-u.updates:
+		t.Fatalf("unexpected extra update for %v", k)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if seen[eventKey{guildID: 1, eventName: "raid"}] != 1 {
+		t.Errorf("expected one update for guild 1, got %d", seen[eventKey{guildID: 1, eventName: "raid"}])
+	}
+
+	// once the update has been sent, a new change schedules another one
+	u.Refresh(1, "Raid")
+	select {
+	case <-u.updates:
+	case <-time.After(200 * time.Millisecond):
+		t.Fatal("timed out waiting for second update")
+	}
+}
@@ -0,0 +1,83 @@
+package membersearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/discordrest"
+)
+
+var (
+	ErrMemberNotFound  = errors.New("no server member with that name")
+	ErrAmbiguousMember = errors.New("more than one server member has that name")
+)
+
+// Searcher finds guild members by name
+type Searcher struct {
+	client *discordrest.Client
+}
+
+// NewSearcher creates a new Searcher
+func NewSearcher(client *discordrest.Client) *Searcher {
+	return &Searcher{
+		client: client,
+	}
+}
+
+type memberResponse struct {
+	Nick string `json:"nick"`
+	User struct {
+		ID         string `json:"id"`
+		Username   string `json:"username"`
+		GlobalName string `json:"global_name"`
+	} `json:"user"`
+}
+
+// matchMember picks the single member whose username, display name or nickname is exactly name (ignoring case);
+// discord's search is a prefix match, so it can return members that only start with name
+func matchMember(members []memberResponse, name string) (string, error) {
+	var found string
+
+	for _, m := range members {
+		if !strings.EqualFold(m.User.Username, name) && !strings.EqualFold(m.User.GlobalName, name) && !strings.EqualFold(m.Nick, name) {
+			continue
+		}
+
+		if found != "" && found != m.User.ID {
+			return "", errors.WithDetails(ErrAmbiguousMember, "name", name)
+		}
+		found = m.User.ID
+	}
+
+	if found == "" {
+		return "", errors.WithDetails(ErrMemberNotFound, "name", name)
+	}
+
+	return found, nil
+}
+
+// FindByName returns the user id of the guild member with a given username or nickname
+func (s *Searcher) FindByName(ctx context.Context, gid snowflake.Snowflake, name string) (snowflake.Snowflake, error) {
+	q := url.Values{}
+	q.Set("query", name)
+	q.Set("limit", "25")
+
+	var members []memberResponse
+	if err := s.client.DoJSON(ctx, http.MethodGet, fmt.Sprintf("/guilds/%s/members/search?%s", gid.ToString(), q.Encode()), nil, &members); err != nil {
+		return 0, errors.Wrap(err, "could not search members")
+	}
+
+	id, err := matchMember(members, name)
+	if err != nil {
+		return 0, err
+	}
+
+	uid, err := snowflake.FromString(id)
+	return uid, errors.Wrap(err, "could not parse member id", "id", id)
+}
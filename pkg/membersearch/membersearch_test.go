@@ -0,0 +1,45 @@
+package membersearch
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMatchMember(t *testing.T) {
+	t.Parallel()
+
+	var members []memberResponse
+	err := json.Unmarshal([]byte(`[
+		{"nick": "Tanky", "user": {"id": "1", "username": "tank_main"}},
+		{"user": {"id": "2", "username": "tank_main2", "global_name": "Healy"}},
+		{"nick": "healy", "user": {"id": "3", "username": "other"}}
+	]`), &members)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{name: "tank_main", want: "1"},
+		{name: "TANKY", want: "1"},
+		{name: "tank", wantErr: ErrMemberNotFound},
+		{name: "healy", wantErr: ErrAmbiguousMember},
+	}
+
+	for _, tt := range tests {
+		got, err := matchMember(members, tt.name)
+		if tt.wantErr != nil {
+			if err == nil {
+				t.Errorf("matchMember(%q) error = nil, want %v", tt.name, tt.wantErr)
+			}
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Errorf("matchMember(%q) = %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
@@ -22,6 +22,11 @@ import (
 	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
 	"github.com/gsmcwhirter/discord-bot-lib/v23/wsapi"
 
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/components"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/fileupload"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/guildemojis"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/livemessages"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/permissions"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/reactions"
 	"github.com/gsmcwhirter/discord-signup-bot/pkg/stats"
@@ -32,6 +37,14 @@ import (
 // not have permission to perform the requested action
 var ErrUnauthorized = errors.New("unauthorized")
 
+// ErrBadComponent is the error returned when a component interaction is missing information
+var ErrBadComponent = errors.New("malformed component interaction")
+
+// interactionModalSubmit is the interaction type of modal submissions (unknown to the bot library)
+const interactionModalSubmit = 5
+
+const messageFlagEphemeral = 1 << 6
+
 // ErrNoResponse is the error a command handler should return
 // if the bot should not produce a response
 var ErrNoResponse = errors.New("no response")
@@ -39,12 +52,16 @@ var ErrNoResponse = errors.New("no response")
 type dependencies interface {
 	Logger() Logger
 	GuildAPI() storage.GuildAPI
+	EventMessageAPI() storage.EventMessageAPI
 	InteractionDispatcher() *cmdhandler.InteractionDispatcher
 	CommandHandler() *cmdhandler.CommandHandler
 	ConfigHandler() *cmdhandler.CommandHandler
 	DebugHandler() *cmdhandler.CommandHandler
 	AdminHandler() *cmdhandler.CommandHandler
 	ReactionHandler() reactions.Handler
+	ComponentHandler() components.Handler
+	ComponentAttacher() *components.Attacher
+	ModalHandler() components.ModalHandler
 	MessageRateLimiter() *rate.Limiter
 	ReactionsRateLimiter() *rate.Limiter
 	BotSession() *session.Session
@@ -54,11 +71,49 @@ type dependencies interface {
 	InteractionSendAllowed() bool
 	PermissionsManager() *permissions.Manager
 	GuildCommandGenerator() func(snowflake.Snowflake) ([]cmdhandler.InteractionCommandHandler, error)
+	Uploader() *fileupload.Uploader
+	LiveMessages() *livemessages.Updater
+}
+
+// AttachmentResponse is implemented by responses that carry files; the files are sent in a
+// separate message right after the response itself
+type AttachmentResponse interface {
+	Attachments() []fileupload.File
+}
+
+// EventResponse is implemented by responses that display an event; the sent messages are indexed
+// so that reactions to them can be mapped back to the event
+type EventResponse interface {
+	EventName() string
+}
+
+// ComponentResponse is implemented by responses that carry buttons; they are attached to the
+// last message the response is sent as
+type ComponentResponse interface {
+	Components() []components.ActionRow
+}
+
+// ModalResponse is implemented by interaction responses that open a form instead of sending a message
+type ModalResponse interface {
+	Modal() components.Modal
+}
+
+// PageResponse is implemented by responses that show one page of a longer display; when a page
+// is requested from an ephemeral message, that message is replaced instead of a new one being sent
+type PageResponse interface {
+	Page() (page, pages int)
+}
+
+// LiveResponse is implemented by responses that show an event; the sent message is tracked
+// and edited as the event roster changes
+type LiveResponse interface {
+	LiveEvent() (eventName, kind string)
 }
 
 // Handlers is the interface for a Handlers dependency that registers itself with a discrord bot
 type Handlers interface {
 	ConnectToBot(*bot.DiscordBot)
+	Send(ctx context.Context, resp cmdhandler.Response, cid, gid snowflake.Snowflake)
 }
 
 type handlers struct {
@@ -116,6 +171,18 @@ func (h *handlers) ConnectToBot(b *bot.DiscordBot) {
 	b.Dispatcher().AddHandler("GUILD_ROLE_DELETE", h.handleGuildRoleDelete)
 }
 
+// Send delivers a response that was not triggered by a message (e.g., from a scheduled job)
+func (h *handlers) Send(ctx context.Context, resp cmdhandler.Response, cid, gid snowflake.Snowflake) {
+	if h.bot == nil {
+		return
+	}
+
+	ctx = request.WithGuildID(ctx, gid)
+	logger := logging.WithContext(ctx, h.deps.Logger())
+
+	h.handleResponse(ctx, logger, resp, cid, gid, "", h.deps.SendAllowed(), nil)
+}
+
 func (h *handlers) channelGuild(cid snowflake.Snowflake) (gid snowflake.Snowflake) {
 	gid, _ = h.deps.BotSession().GuildOfChannel(cid)
 	return
@@ -141,6 +208,21 @@ func (h *handlers) guildCommandIndicator(ctx context.Context, gid snowflake.Snow
 	return s.ControlSequence
 }
 
+// localizeError replaces a translatable error with its text in the guild's language
+func (h *handlers) localizeError(ctx context.Context, gid snowflake.Snowflake, err error) error {
+	e, ok := i18n.Find(err)
+	if !ok || gid == 0 {
+		return err
+	}
+
+	s, serr := storage.GetSettings(ctx, h.deps.GuildAPI(), gid)
+	if serr != nil {
+		return err
+	}
+
+	return errors.New(e.Localize(s.Language))
+}
+
 func (h *handlers) attemptConfigAndAdminHandlers(msg cmdhandler.Message, cmdIndicator, content string) (cmdhandler.Response, error) {
 	ctx, span := h.deps.Census().StartSpan(msg.Context(), "handlers.attemptConfigAndAdminHandlers", "guild_id", msg.GuildID().ToString())
 	defer span.End()
@@ -198,7 +280,7 @@ func (h *handlers) handleResponse(ctx context.Context, logger Logger, resp cmdha
 
 	if err != nil {
 		level.Error(logger).Err("error handling command", err, "contents", content)
-		resp.IncludeError(err)
+		resp.IncludeError(h.localizeError(ctx, gid, err))
 	}
 
 	if resp.HasErrors() && resp.GetColor() == 0 {
@@ -220,7 +302,7 @@ func (h *handlers) handleResponse(ctx context.Context, logger Logger, resp cmdha
 
 	level.Info(logger).Message("sending message split", "split_count", len(splitResp))
 
-	for _, res := range splitResp {
+	for i, res := range splitResp {
 		if !allowSend {
 			level.Info(logger).Message("message send disabled", "message_to_send", fmt.Sprintf("%#v", res.ToMessage()), "reactions", res.MessageReactions())
 			continue
@@ -242,6 +324,29 @@ func (h *handlers) handleResponse(ctx context.Context, logger Logger, resp cmdha
 			return
 		}
 
+		if er, ok := resp.(EventResponse); ok && !resp.HasErrors() {
+			h.indexEventMessage(ctx, logger, gid, sendTo, sentMsg.IDSnowflake, er.EventName())
+		}
+
+		if cr, ok := resp.(ComponentResponse); ok && !resp.HasErrors() && i == len(splitResp)-1 && len(cr.Components()) > 0 {
+			if err = h.deps.MessageRateLimiter().Wait(ctx); err != nil {
+				level.Error(logger).Err("error waiting for ratelimiting", err)
+				return
+			}
+
+			if err = h.deps.ComponentAttacher().Attach(ctx, sendTo, sentMsg.IDSnowflake, cr.Components()); err != nil {
+				level.Error(logger).Err("could not attach components", err)
+			}
+		}
+
+		// only single messages are tracked, since an edit cannot re-split a roster
+		if lr, ok := resp.(LiveResponse); ok && !resp.HasErrors() && len(splitResp) == 1 {
+			eventName, kind := lr.LiveEvent()
+			if err := h.deps.LiveMessages().Track(ctx, gid, sendTo, sentMsg.IDSnowflake, eventName, kind); err != nil {
+				level.Error(logger).Err("could not track live message", err, "event_name", eventName, "kind", kind)
+			}
+		}
+
 		reacts := res.MessageReactions()
 		for _, reaction := range reacts {
 			err = h.deps.ReactionsRateLimiter().Wait(ctx)
@@ -254,19 +359,40 @@ func (h *handlers) handleResponse(ctx context.Context, logger Logger, resp cmdha
 				ar.Incr(1)
 			}
 
-			resp, err := h.bot.API().CreateReaction(ctx, sendTo, sentMsg.IDSnowflake, reaction)
+			resp, err := h.bot.API().CreateReaction(ctx, sendTo, sentMsg.IDSnowflake, guildemojis.ReactionEmoji(reaction))
 			if err != nil {
 				status := 0
 				if resp != nil {
 					status = resp.StatusCode
 				}
 
-				level.Error(logger).Err("could not add reaction", err, "status_code", status)
+				level.Error(logger).Err("could not add reaction", err, "status_code", status, "emoji", reaction)
 			}
 		}
 	}
 
 	level.Info(logger).Message("successfully sent message(s) to channel", "channel_id", sendTo.ToString(), "message_ct", len(splitResp))
+
+	if ar, ok := resp.(AttachmentResponse); ok && allowSend && !resp.HasErrors() && len(ar.Attachments()) > 0 {
+		if err = h.deps.MessageRateLimiter().Wait(ctx); err != nil {
+			level.Error(logger).Err("error waiting for ratelimiting", err)
+			return
+		}
+
+		if err = h.deps.Uploader().SendToChannel(ctx, sendTo, "", ar.Attachments()); err != nil {
+			level.Error(logger).Err("could not send attachments", err)
+			return
+		}
+
+		level.Info(logger).Message("successfully sent attachments to channel", "channel_id", sendTo.ToString(), "file_ct", len(ar.Attachments()))
+	}
+}
+
+// indexEventMessage remembers which event a sent message displays
+func (h *handlers) indexEventMessage(ctx context.Context, logger Logger, gid, cid, mid snowflake.Snowflake, eventName string) {
+	if err := h.deps.EventMessageAPI().IndexEventMessage(ctx, gid.ToString(), cid.ToString(), mid.ToString(), eventName); err != nil {
+		level.Error(logger).Err("could not index event message", err, "event_name", eventName, "message_id", mid.ToString())
+	}
 }
 
 func (h *handlers) handleInteractionResponse(ctx context.Context, logger Logger, resp cmdhandler.Response, ix *cmdhandler.Interaction, extras []cmdhandler.Response, err error) {
@@ -280,7 +406,7 @@ func (h *handlers) handleInteractionResponse(ctx context.Context, logger Logger,
 
 	if err != nil {
 		level.Error(logger).Err("error handling interaction", err)
-		resp.IncludeError(err)
+		resp.IncludeError(h.localizeError(ctx, ix.GuildID(), err))
 		resp.SetEphemeral(true)
 	}
 
@@ -292,6 +418,18 @@ func (h *handlers) handleInteractionResponse(ctx context.Context, logger Logger,
 		resp.SetColor(h.successColor)
 	}
 
+	if mr, ok := resp.(ModalResponse); ok && !resp.HasErrors() {
+		if !h.deps.InteractionSendAllowed() {
+			level.Info(logger).Message("interaction response send disabled", "modal_to_send", fmt.Sprintf("%#v", mr.Modal()))
+			return
+		}
+
+		if err = h.deps.ComponentAttacher().OpenModal(ctx, ix.IDSnowflake, ix.Token, mr.Modal()); err != nil {
+			level.Error(logger).Err("could not open modal", err)
+		}
+		return
+	}
+
 	level.Info(logger).Message("sending interaction response", "resp", fmt.Sprintf("%+v", resp))
 
 	splitResp := resp.Split()
@@ -321,14 +459,35 @@ func (h *handlers) handleInteractionResponse(ctx context.Context, logger Logger,
 		}
 	}
 
+	if cr, ok := resp.(ComponentResponse); ok && h.deps.InteractionSendAllowed() && !resp.HasErrors() && len(splitResp) == 1 && len(cr.Components()) > 0 {
+		if err = h.deps.MessageRateLimiter().Wait(ctx); err != nil {
+			level.Error(logger).Err("error waiting for ratelimiting", err)
+			return
+		}
+
+		// the response may be ephemeral, so it is edited through the interaction rather than the channel
+		if err = h.deps.ComponentAttacher().AttachOriginal(ctx, ix.ApplicationIDSnowflake, ix.Token, cr.Components()); err != nil {
+			level.Error(logger).Err("could not attach components to interaction response", err)
+		}
+	}
+
+	// event messages are indexed whether or not they get reactions, so that buttons and reactions
+	// on them never need the footer lookup
+	er, isEvent := resp.(EventResponse)
+	isEvent = isEvent && !resp.HasErrors()
+
 	reacts := resp.MessageReactions()
-	if len(reacts) > 0 {
+	if h.deps.InteractionSendAllowed() && (len(reacts) > 0 || isEvent) {
 		sentMsg, err := h.bot.API().GetInteractionResponse(ctx, ix.ApplicationIDSnowflake, ix.Token)
 		if err != nil {
-			level.Error(logger).Err("could not retrieve message information prior to adding reactions", err)
+			level.Error(logger).Err("could not retrieve interaction response message", err)
 			return
 		}
 
+		if isEvent {
+			h.indexEventMessage(ctx, logger, ix.GuildID(), ix.ChannelIDSnowflake, sentMsg.IDSnowflake, er.EventName())
+		}
+
 		for _, reaction := range reacts {
 			err = h.deps.ReactionsRateLimiter().Wait(ctx)
 			if err != nil {
@@ -340,20 +499,35 @@ func (h *handlers) handleInteractionResponse(ctx context.Context, logger Logger,
 				ar.Incr(1)
 			}
 
-			resp, err := h.bot.API().CreateReaction(ctx, ix.ChannelIDSnowflake, sentMsg.IDSnowflake, reaction)
+			resp, err := h.bot.API().CreateReaction(ctx, ix.ChannelIDSnowflake, sentMsg.IDSnowflake, guildemojis.ReactionEmoji(reaction))
 			if err != nil {
 				status := 0
 				if resp != nil {
 					status = resp.StatusCode
 				}
 
-				level.Error(logger).Err("could not add reaction", err, "status_code", status)
+				level.Error(logger).Err("could not add reaction", err, "status_code", status, "emoji", reaction)
 			}
 		}
 	}
 
 	level.Info(logger).Message("successfully sent message(s) to interaction response", "interaction_id", ix.IDSnowflake, "message_ct", len(splitResp))
 
+	if ar, ok := resp.(AttachmentResponse); ok && h.deps.InteractionSendAllowed() && !resp.HasErrors() && len(ar.Attachments()) > 0 {
+		if err = h.deps.MessageRateLimiter().Wait(ctx); err != nil {
+			level.Error(logger).Err("error waiting for ratelimiting", err)
+			return
+		}
+
+		// the interaction has its response already, so files go in a follow-up message
+		if err = h.deps.Uploader().SendFollowup(ctx, ix.ApplicationIDSnowflake, ix.Token, "", ar.Attachments()); err != nil {
+			level.Error(logger).Err("could not send interaction attachments", err)
+			return
+		}
+
+		level.Info(logger).Message("successfully sent attachments to interaction", "interaction_id", ix.IDSnowflake, "file_ct", len(ar.Attachments()))
+	}
+
 	for _, resp := range extras {
 		cid := resp.Channel()
 		if cid == 0 {
@@ -586,6 +760,10 @@ func (h *handlers) handleInteraction(p bot.Payload, req wsapi.WSMessage, respCha
 		return h.handleInteractionCommand(msg)
 	case entity.InteractionAutocomplete:
 		return h.handleInteractionAutocomplete(msg)
+	case entity.InteractionMessageComponent:
+		return h.handleInteractionComponent(msg, p.Contents())
+	case interactionModalSubmit:
+		return h.handleInteractionModal(msg, p.Contents())
 	default:
 		level.Info(logger).Message("interaction was not a known type")
 		return 0
@@ -617,6 +795,246 @@ func (h *handlers) handleInteractionCommand(ix *cmdhandler.Interaction) snowflak
 	return ix.GuildID()
 }
 
+func (h *handlers) handleInteractionComponent(ix *cmdhandler.Interaction, contents map[string]etfapi.Element) snowflake.Snowflake {
+	ctx, span := h.deps.Census().StartSpan(ix.Context(), "handlers.handleInteractionComponent")
+	defer span.End()
+
+	select {
+	case <-ctx.Done():
+		return 0
+	default:
+	}
+
+	if ar, ok := h.deps.StatsHub().Get("interactions_component"); ok {
+		ar.Incr(1)
+	}
+
+	logger := logging.WithMessage(ix, h.deps.Logger())
+
+	customID, mid, ephemeral, err := componentFromElementMap(contents)
+	if err != nil {
+		level.Error(logger).Err("error inflating component interaction", err)
+		return 0
+	}
+
+	click := components.NewClick(ctx, ix.UserID(), mid, ix.ChannelID(), ix.GuildID(), customID)
+	resp, err := h.deps.ComponentHandler().HandleClick(click)
+	if resp != nil {
+		resp.SetEphemeral(true)
+	}
+
+	if _, ok := resp.(PageResponse); ok && ephemeral && err == nil {
+		h.handleInteractionUpdate(ctx, logger, resp, ix)
+		return ix.GuildID()
+	}
+
+	h.handleInteractionResponse(ctx, logger, resp, ix, nil, err)
+
+	return ix.GuildID()
+}
+
+// handleInteractionUpdate replaces the message a component was clicked on with the response
+func (h *handlers) handleInteractionUpdate(ctx context.Context, logger Logger, resp cmdhandler.Response, ix *cmdhandler.Interaction) {
+	if resp.GetColor() == 0 {
+		resp.SetColor(h.successColor)
+	}
+
+	splitResp := resp.Split()
+	if len(splitResp) != 1 {
+		level.Error(logger).Message("update response does not fit in one message", "split_count", len(splitResp))
+		return
+	}
+
+	if !h.deps.InteractionSendAllowed() {
+		level.Info(logger).Message("interaction response send disabled", "message_to_send", fmt.Sprintf("%#v", splitResp[0].ToMessage()))
+		return
+	}
+
+	var rows []components.ActionRow
+	if cr, ok := resp.(ComponentResponse); ok {
+		rows = cr.Components()
+	}
+
+	if err := h.deps.MessageRateLimiter().Wait(ctx); err != nil {
+		level.Error(logger).Err("error waiting for ratelimiting", err)
+		return
+	}
+
+	if err := h.deps.ComponentAttacher().UpdateMessage(ctx, ix.IDSnowflake, ix.Token, splitResp[0].ToMessage(), rows); err != nil {
+		level.Error(logger).Err("could not update interaction message", err)
+		return
+	}
+
+	level.Info(logger).Message("successfully updated interaction message", "interaction_id", ix.IDSnowflake)
+}
+
+// componentFromElementMap pulls the clicked button and its message (and whether that message is
+// ephemeral) out of a component interaction
+func componentFromElementMap(contents map[string]etfapi.Element) (string, snowflake.Snowflake, bool, error) {
+	e, ok := contents["data"]
+	if !ok {
+		return "", 0, false, ErrBadComponent
+	}
+
+	data, err := e.ToMap()
+	if err != nil {
+		return "", 0, false, errors.Wrap(err, "could not inflate component data")
+	}
+
+	e, ok = data["custom_id"]
+	if !ok {
+		return "", 0, false, errors.Wrap(ErrBadComponent, "missing custom_id")
+	}
+
+	customID, err := e.ToString()
+	if err != nil {
+		return "", 0, false, errors.Wrap(err, "could not inflate custom_id")
+	}
+
+	e, ok = contents["message"]
+	if !ok {
+		return "", 0, false, errors.Wrap(ErrBadComponent, "missing message")
+	}
+
+	msg, err := e.ToMap()
+	if err != nil {
+		return "", 0, false, errors.Wrap(err, "could not inflate component message")
+	}
+
+	e, ok = msg["id"]
+	if !ok {
+		return "", 0, false, errors.Wrap(ErrBadComponent, "missing message id")
+	}
+
+	mid, err := etfapi.SnowflakeFromElement(e)
+	if err != nil {
+		return "", 0, false, errors.Wrap(err, "could not inflate message id")
+	}
+
+	var ephemeral bool
+	if e, ok = msg["flags"]; ok {
+		flags, err := e.ToInt64()
+		if err != nil {
+			return "", 0, false, errors.Wrap(err, "could not inflate message flags")
+		}
+		ephemeral = flags&messageFlagEphemeral != 0
+	}
+
+	return customID, mid, ephemeral, nil
+}
+
+func (h *handlers) handleInteractionModal(ix *cmdhandler.Interaction, contents map[string]etfapi.Element) snowflake.Snowflake {
+	ctx, span := h.deps.Census().StartSpan(ix.Context(), "handlers.handleInteractionModal")
+	defer span.End()
+
+	select {
+	case <-ctx.Done():
+		return 0
+	default:
+	}
+
+	if ar, ok := h.deps.StatsHub().Get("interactions_modal"); ok {
+		ar.Incr(1)
+	}
+
+	logger := logging.WithMessage(ix, h.deps.Logger())
+
+	customID, values, err := modalFromElementMap(contents)
+	if err != nil {
+		level.Error(logger).Err("error inflating modal submission", err)
+		return 0
+	}
+
+	sub := components.NewSubmission(ctx, ix.UserID(), ix.ChannelID(), ix.GuildID(), customID, values)
+	resp, err := h.deps.ModalHandler().HandleModal(sub)
+	if resp != nil {
+		resp.SetEphemeral(true)
+	}
+
+	h.handleInteractionResponse(ctx, logger, resp, ix, nil, err)
+
+	return ix.GuildID()
+}
+
+// modalFromElementMap pulls the form id and the submitted values (by input id) out of a modal submission
+func modalFromElementMap(contents map[string]etfapi.Element) (string, map[string]string, error) {
+	e, ok := contents["data"]
+	if !ok {
+		return "", nil, ErrBadComponent
+	}
+
+	data, err := e.ToMap()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not inflate modal data")
+	}
+
+	e, ok = data["custom_id"]
+	if !ok {
+		return "", nil, errors.Wrap(ErrBadComponent, "missing custom_id")
+	}
+
+	customID, err := e.ToString()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not inflate custom_id")
+	}
+
+	e, ok = data["components"]
+	if !ok {
+		return "", nil, errors.Wrap(ErrBadComponent, "missing components")
+	}
+
+	rows, err := e.ToList()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "could not inflate modal rows")
+	}
+
+	values := map[string]string{}
+	for _, row := range rows {
+		rowMap, err := row.ToMap()
+		if err != nil {
+			return "", nil, errors.Wrap(err, "could not inflate modal row")
+		}
+
+		e, ok = rowMap["components"]
+		if !ok {
+			continue
+		}
+
+		inputs, err := e.ToList()
+		if err != nil {
+			return "", nil, errors.Wrap(err, "could not inflate modal inputs")
+		}
+
+		for _, input := range inputs {
+			inputMap, err := input.ToMap()
+			if err != nil {
+				return "", nil, errors.Wrap(err, "could not inflate modal input")
+			}
+
+			idElem, ok := inputMap["custom_id"]
+			if !ok {
+				continue
+			}
+
+			id, err := idElem.ToString()
+			if err != nil {
+				return "", nil, errors.Wrap(err, "could not inflate input custom_id")
+			}
+
+			var value string
+			if valElem, ok := inputMap["value"]; ok {
+				if value, err = valElem.ToString(); err != nil {
+					return "", nil, errors.Wrap(err, "could not inflate input value", "custom_id", id)
+				}
+			}
+
+			values[id] = value
+		}
+	}
+
+	return customID, values, nil
+}
+
 func (h *handlers) handleInteractionAutocomplete(ix *cmdhandler.Interaction) snowflake.Snowflake {
 	ctx, span := h.deps.Census().StartSpan(ix.Context(), "handlers.handleInteractionAutocomplete")
 	defer span.End()
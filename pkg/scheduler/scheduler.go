@@ -0,0 +1,163 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type Logger = interface {
+	Log(keyvals ...interface{}) error
+	Message(string, ...interface{})
+	Err(string, error, ...interface{})
+	Printf(string, ...interface{})
+}
+
+type dependencies interface {
+	Logger() Logger
+	JobAPI() storage.JobAPI
+	Census() *telemetry.Census
+}
+
+// Handler runs a single claimed job
+type Handler func(ctx context.Context, job storage.Job) error
+
+var ErrDuplicateKind = errors.New("duplicate job kind")
+
+// Options configures the polling behavior of the scheduler
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// Scheduler polls for due jobs and dispatches them to the handler registered for their kind.
+//
+// Jobs are claimed (and the claim committed) before they run, so a job runs at most once;
+// a job that was running when the bot stopped is marked abandoned rather than retried.
+type Scheduler struct {
+	deps     dependencies
+	opts     Options
+	lock     sync.RWMutex
+	handlers map[string]Handler
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(deps dependencies, opts Options) *Scheduler {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 30 * time.Second
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 20
+	}
+
+	return &Scheduler{
+		deps:     deps,
+		opts:     opts,
+		handlers: map[string]Handler{},
+	}
+}
+
+// Register sets the handler for a kind of job
+func (s *Scheduler) Register(kind string, h Handler) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.handlers[kind]; ok {
+		return errors.Wrap(ErrDuplicateKind, "could not register job handler", "kind", kind)
+	}
+
+	s.handlers[kind] = h
+
+	return nil
+}
+
+func (s *Scheduler) handler(kind string) (Handler, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	h, ok := s.handlers[kind]
+	return h, ok
+}
+
+// Start runs the scheduler until the context is canceled
+func (s *Scheduler) Start(ctx context.Context) error {
+	logger := s.deps.Logger()
+
+	// anything claimed before we started cannot be running in this process
+	n, err := s.deps.JobAPI().AbandonStaleJobs(ctx, time.Now())
+	if err != nil {
+		level.Error(logger).Err("could not abandon stale jobs", err)
+	} else if n > 0 {
+		level.Info(logger).Message("abandoned stale jobs", "count", n)
+	}
+
+	level.Info(logger).Message("starting scheduler", "poll_interval", s.opts.PollInterval.String())
+
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			level.Info(logger).Message("stopping scheduler")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runDue(ctx context.Context) {
+	ctx, span := s.deps.Census().StartSpan(ctx, "scheduler.runDue")
+	defer span.End()
+
+	logger := s.deps.Logger()
+
+	for {
+		jobs, err := s.deps.JobAPI().ClaimDueJobs(ctx, time.Now(), s.opts.BatchSize)
+		if err != nil {
+			level.Error(logger).Err("could not claim due jobs", err)
+			return
+		}
+
+		for i := range jobs {
+			s.runJob(ctx, jobs[i])
+		}
+
+		if len(jobs) < s.opts.BatchSize || ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (s *Scheduler) runJob(ctx context.Context, job storage.Job) {
+	ctx, span := s.deps.Census().StartSpan(ctx, "scheduler.runJob", "guild_id", job.GuildID, "kind", job.Kind)
+	defer span.End()
+
+	logger := s.deps.Logger()
+
+	status := storage.JobStatusDone
+
+	h, ok := s.handler(job.Kind)
+	if !ok {
+		level.Error(logger).Message("no handler for job kind", "job_id", job.ID, "kind", job.Kind)
+		status = storage.JobStatusFailed
+	} else if err := h(ctx, job); err != nil {
+		level.Error(logger).Err("scheduled job failed", err, "job_id", job.ID, "kind", job.Kind, "guild_id", job.GuildID, "event_name", job.EventName)
+		status = storage.JobStatusFailed
+	} else {
+		level.Info(logger).Message("scheduled job done", "job_id", job.ID, "kind", job.Kind, "guild_id", job.GuildID, "event_name", job.EventName)
+	}
+
+	if err := s.deps.JobAPI().FinishJob(ctx, job.ID, status); err != nil {
+		level.Error(logger).Err("could not record job status", err, "job_id", job.ID, "status", string(status))
+	}
+}
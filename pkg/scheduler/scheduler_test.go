@@ -0,0 +1,239 @@
+package scheduler
+
+import (
+	"context"
+	stderrors "errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Log(...interface{}) error { return nil }
+
+// memJobAPI is an in-memory storage.JobAPI with the same claim rules as the postgres one
+type memJobAPI struct {
+	mu        sync.Mutex
+	jobs      map[int64]*storage.Job
+	claimedAt map[int64]time.Time
+	nextID    int64
+	claims    int
+}
+
+func newMemJobAPI() *memJobAPI {
+	return &memJobAPI{jobs: map[int64]*storage.Job{}, claimedAt: map[int64]time.Time{}}
+}
+
+func (m *memJobAPI) AddJob(ctx context.Context, job storage.Job) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextID++
+	job.ID = m.nextID
+	job.Status = storage.JobStatusPending
+	m.jobs[job.ID] = &job
+
+	return job.ID, nil
+}
+
+func (m *memJobAPI) ClaimDueJobs(ctx context.Context, now time.Time, limit int) ([]storage.Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.claims++
+
+	due := []*storage.Job{}
+	for _, job := range m.jobs {
+		if job.Status == storage.JobStatusPending && !job.RunAt.After(now) {
+			due = append(due, job)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool { return due[i].RunAt.Before(due[j].RunAt) })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]storage.Job, 0, len(due))
+	for _, job := range due {
+		job.Status = storage.JobStatusRunning
+		m.claimedAt[job.ID] = time.Now()
+		claimed = append(claimed, *job)
+	}
+
+	return claimed, nil
+}
+
+func (m *memJobAPI) FinishJob(ctx context.Context, id int64, status storage.JobStatus) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if job, ok := m.jobs[id]; ok && job.Status == storage.JobStatusRunning {
+		job.Status = status
+	}
+
+	return nil
+}
+
+func (m *memJobAPI) CancelEventJobs(ctx context.Context, guildID, eventName string, kinds ...string) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+func (m *memJobAPI) AbandonStaleJobs(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var n int64
+	for id, job := range m.jobs {
+		if job.Status == storage.JobStatusRunning && m.claimedAt[id].Before(claimedBefore) {
+			job.Status = storage.JobStatusAbandoned
+			n++
+		}
+	}
+
+	return n, nil
+}
+
+func (m *memJobAPI) status(id int64) storage.JobStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.jobs[id].Status
+}
+
+type testDeps struct {
+	jobs *memJobAPI
+}
+
+func (d testDeps) Logger() Logger            { return logging.NewFrom(nopLogger{}) }
+func (d testDeps) JobAPI() storage.JobAPI    { return d.jobs }
+func (d testDeps) Census() *telemetry.Census { return &telemetry.Census{} }
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	s := NewScheduler(testDeps{jobs: newMemJobAPI()}, Options{})
+	noop := func(context.Context, storage.Job) error { return nil }
+
+	if err := s.Register("open", noop); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+
+	if err := s.Register("open", noop); !stderrors.Is(err, ErrDuplicateKind) {
+		t.Errorf("Register() error = %v, want %v", err, ErrDuplicateKind)
+	}
+}
+
+func TestRunDue(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	api := newMemJobAPI()
+	s := NewScheduler(testDeps{jobs: api}, Options{BatchSize: 2})
+
+	var mu sync.Mutex
+	ran := map[int64]int{}
+	record := func(_ context.Context, job storage.Job) error {
+		mu.Lock()
+		defer mu.Unlock()
+		ran[job.ID]++
+		return nil
+	}
+
+	if err := s.Register("open", record); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Register("broken", func(ctx context.Context, job storage.Job) error {
+		_ = record(ctx, job)
+		return errors.New("handler failed")
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	var due []int64
+	for i := 0; i < 5; i++ {
+		id, _ := api.AddJob(ctx, storage.Job{GuildID: "1", EventName: "raid", Kind: "open", RunAt: now.Add(-time.Duration(i+1) * time.Minute)})
+		due = append(due, id)
+	}
+	failing, _ := api.AddJob(ctx, storage.Job{GuildID: "1", EventName: "raid", Kind: "broken", RunAt: now.Add(-time.Minute)})
+	unknown, _ := api.AddJob(ctx, storage.Job{GuildID: "1", EventName: "raid", Kind: "mystery", RunAt: now.Add(-time.Minute)})
+	future, _ := api.AddJob(ctx, storage.Job{GuildID: "1", EventName: "raid", Kind: "open", RunAt: now.Add(time.Hour)})
+
+	s.runDue(ctx)
+
+	// 7 due jobs in batches of 2 take 4 claims
+	if api.claims != 4 {
+		t.Errorf("claimed %d batches, want 4", api.claims)
+	}
+
+	for _, id := range due {
+		if ran[id] != 1 || api.status(id) != storage.JobStatusDone {
+			t.Errorf("job %d ran %d times and is %s; want once and %s", id, ran[id], api.status(id), storage.JobStatusDone)
+		}
+	}
+
+	if ran[failing] != 1 || api.status(failing) != storage.JobStatusFailed {
+		t.Errorf("failing job ran %d times and is %s; want once and %s", ran[failing], api.status(failing), storage.JobStatusFailed)
+	}
+
+	if api.status(unknown) != storage.JobStatusFailed {
+		t.Errorf("job without a handler is %s, want %s", api.status(unknown), storage.JobStatusFailed)
+	}
+
+	if ran[future] != 0 || api.status(future) != storage.JobStatusPending {
+		t.Errorf("job that is not due ran %d times and is %s", ran[future], api.status(future))
+	}
+
+	// nothing runs twice
+	s.runDue(ctx)
+	for id, n := range ran {
+		if n != 1 {
+			t.Errorf("job %d ran %d times", id, n)
+		}
+	}
+}
+
+func TestStartAbandonsStaleJobs(t *testing.T) {
+	t.Parallel()
+
+	api := newMemJobAPI()
+	s := NewScheduler(testDeps{jobs: api}, Options{PollInterval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stale, _ := api.AddJob(ctx, storage.Job{GuildID: "1", EventName: "raid", Kind: "open", RunAt: time.Now().Add(-time.Hour)})
+	if _, err := api.ClaimDueJobs(ctx, time.Now(), 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// claimed by an earlier process and never finished
+	time.Sleep(time.Millisecond)
+
+	done := make(chan error)
+	go func() { done <- s.Start(ctx) }()
+
+	// the stale job is abandoned rather than run again
+	deadline := time.After(time.Second)
+	for api.status(stale) != storage.JobStatusAbandoned {
+		select {
+		case <-deadline:
+			t.Fatalf("stale job is %s, want %s", api.status(stale), storage.JobStatusAbandoned)
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+}
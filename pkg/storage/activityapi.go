@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// ActivityKind is the kind of a recorded member action
+type ActivityKind string
+
+// Activity Kind Constants
+const (
+	ActivitySignup   ActivityKind = "signup"
+	ActivityWithdraw ActivityKind = "withdraw"
+)
+
+// MemberActivity is a single signup or withdrawal by a member
+type MemberActivity struct {
+	GuildID   string
+	Member    string
+	EventName string
+	Role      string
+	Kind      ActivityKind
+	Late      bool
+}
+
+// ReliabilityStats are the counts of a member's activity and attendance (for one role, or overall)
+type ReliabilityStats struct {
+	Member          string
+	Role            string
+	Signups         int
+	Withdrawals     int
+	LateWithdrawals int
+	Attended        int
+	Late            int
+	NoShows         int
+}
+
+// Add accumulates the counts of another set of stats into this one
+func (s *ReliabilityStats) Add(o ReliabilityStats) {
+	s.Signups += o.Signups
+	s.Withdrawals += o.Withdrawals
+	s.LateWithdrawals += o.LateWithdrawals
+	s.Attended += o.Attended
+	s.Late += o.Late
+	s.NoShows += o.NoShows
+}
+
+// Reliability is the fraction of signups that were not followed by a late withdrawal or a no-show
+func (s ReliabilityStats) Reliability() float64 {
+	if s.Signups <= 0 {
+		return 0
+	}
+
+	r := 1 - float64(s.LateWithdrawals+s.NoShows)/float64(s.Signups)
+	if r < 0 {
+		return 0
+	}
+
+	return r
+}
+
+// ActivityAPI is the api for recording member activity and reporting reliability statistics
+type ActivityAPI interface {
+	RecordActivity(ctx context.Context, a MemberActivity) error
+
+	// MemberStats returns the stats for one member since the given time, one entry per role
+	MemberStats(ctx context.Context, guildID, member string, since time.Time) ([]ReliabilityStats, error)
+
+	// GuildStats returns the stats for every member since the given time, one entry per member and role
+	GuildStats(ctx context.Context, guildID string, since time.Time) ([]ReliabilityStats, error)
+}
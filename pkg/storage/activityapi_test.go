@@ -0,0 +1,44 @@
+package storage
+
+import "testing"
+
+func TestReliabilityStats_Reliability(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		stats ReliabilityStats
+		want  float64
+	}{
+		{
+			name:  "no signups",
+			stats: ReliabilityStats{},
+			want:  0,
+		},
+		{
+			name:  "perfect",
+			stats: ReliabilityStats{Signups: 4, Attended: 4},
+			want:  1,
+		},
+		{
+			name:  "late withdraw and no-show",
+			stats: ReliabilityStats{Signups: 4, Withdrawals: 2, LateWithdrawals: 1, NoShows: 1},
+			want:  0.5,
+		},
+		{
+			name:  "never below zero",
+			stats: ReliabilityStats{Signups: 1, LateWithdrawals: 1, NoShows: 1},
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.stats.Reliability(); got != tt.want {
+				t.Errorf("ReliabilityStats.Reliability() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
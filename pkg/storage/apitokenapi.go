@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// ErrTokenNotExist is the error returned if an api token does not exist
+var ErrTokenNotExist = errors.New("api token does not exist")
+
+// APIToken describes an api token; the token itself is only known when it is created
+type APIToken struct {
+	ID         int64
+	GuildID    string
+	Label      string
+	CreatedBy  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// NewAPIToken generates a new random api token
+func NewAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "could not generate api token")
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// HashAPIToken is how api tokens are stored and looked up
+func HashAPIToken(token string) string {
+	h := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(h[:])
+}
+
+// APITokenAPI is the api for managing per-guild api tokens
+type APITokenAPI interface {
+	// AddToken stores a new token for a guild, returning its id
+	AddToken(ctx context.Context, guildID, token, label, createdBy string) (int64, error)
+	ListTokens(ctx context.Context, guildID string) ([]APIToken, error)
+	RevokeToken(ctx context.Context, guildID string, id int64) error
+
+	// GuildForToken returns the guild a token belongs to (and records that it was used)
+	GuildForToken(ctx context.Context, token string) (string, error)
+}
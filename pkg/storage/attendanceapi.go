@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// AttendanceStatus represents whether a member showed up for an event
+type AttendanceStatus string
+
+// Attendance Status Constants
+const (
+	AttendanceAttended AttendanceStatus = "attended"
+	AttendanceLate     AttendanceStatus = "late"
+	AttendanceNoShow   AttendanceStatus = "no_show"
+)
+
+// ErrBadAttendanceStatus is the error returned for an unknown attendance status
+var ErrBadAttendanceStatus = errors.New("unknown attendance status (use attended, late, or noshow)")
+
+// ParseAttendanceStatus converts a user-provided status into an AttendanceStatus
+func ParseAttendanceStatus(s string) (AttendanceStatus, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "attended", "present":
+		return AttendanceAttended, nil
+	case "late":
+		return AttendanceLate, nil
+	case "no_show", "noshow", "no-show", "absent":
+		return AttendanceNoShow, nil
+	default:
+		return "", errors.Wrap(ErrBadAttendanceStatus, "could not parse attendance status", "status", s)
+	}
+}
+
+// AttendanceRecord is the attendance of one member at one occurrence of an event
+type AttendanceRecord struct {
+	GuildID    string
+	EventName  string
+	Occurrence string
+	Member     string
+	Role       string
+	Status     AttendanceStatus
+	RecordedBy string
+	RecordedAt time.Time
+}
+
+// AttendanceAPI is the api for managing attendance records
+//
+// Records are kept apart from the event itself, so clearing, withdrawing from, or
+// deleting an event does not remove them.
+type AttendanceAPI interface {
+	// RecordAttendance saves the given records, replacing any earlier record for the same member and occurrence
+	RecordAttendance(ctx context.Context, records []AttendanceRecord) error
+
+	// EventAttendance returns the records for one occurrence of an event
+	EventAttendance(ctx context.Context, guildID, eventName, occurrence string) ([]AttendanceRecord, error)
+
+	// MemberAttendance returns all of the records for a member
+	MemberAttendance(ctx context.Context, guildID, member string) ([]AttendanceRecord, error)
+}
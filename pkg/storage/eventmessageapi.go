@@ -0,0 +1,17 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// ErrEventMessageNotExist is the error returned if a message is not known to display an event
+var ErrEventMessageNotExist = errors.New("message is not indexed")
+
+// EventMessageAPI is the api for the index of posted messages that display an event
+type EventMessageAPI interface {
+	IndexEventMessage(ctx context.Context, guildID, channelID, messageID, eventName string) error
+	EventForMessage(ctx context.Context, channelID, messageID string) (string, error)
+	RemoveEventMessages(ctx context.Context, guildID, eventName string) error
+}
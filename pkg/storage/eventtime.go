@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+var discordTimestampRegexp = regexp.MustCompile(`<t:(-?\d+)(?::[tTdDfFR])?>`)
+
+// eventTimeLayouts are tried in order; layouts without a zone are interpreted as UTC
+var eventTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04 -0700",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// ParseEventTime attempts to understand a free-form event time string. A discord timestamp
+// (<t:UNIX> or <t:UNIX:STYLE>) may appear anywhere in the string; otherwise the whole
+// (trimmed) string must match one of the supported layouts.
+func ParseEventTime(s string) (time.Time, bool) {
+	if m := discordTimestampRegexp.FindStringSubmatch(s); m != nil {
+		ts, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+
+		return time.Unix(ts, 0).UTC(), true
+	}
+
+	s = strings.TrimSpace(s)
+	for _, layout := range eventTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// ReminderOffsetsOff disables reminders for an event even if the guild has default offsets
+const ReminderOffsetsOff = "off"
+
+// ParseReminderOffsets parses a comma-separated list of durations before an event
+// (e.g., "1d,2h,30m"), returning them longest first
+func ParseReminderOffsets(s string) ([]time.Duration, error) {
+	parts := strings.Split(s, ",")
+	offsets := make([]time.Duration, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" || part == ReminderOffsetsOff {
+			continue
+		}
+
+		var d time.Duration
+		if strings.HasSuffix(part, "d") {
+			days, err := strconv.Atoi(strings.TrimSuffix(part, "d"))
+			if err != nil {
+				return nil, errors.Wrap(err, "could not parse reminder offset", "offset", part)
+			}
+			d = time.Duration(days) * 24 * time.Hour
+		} else {
+			var err error
+			d, err = time.ParseDuration(part)
+			if err != nil {
+				return nil, errors.Wrap(err, "could not parse reminder offset", "offset", part)
+			}
+		}
+
+		if d <= 0 {
+			return nil, errors.New("reminder offsets must be positive")
+		}
+
+		offsets = append(offsets, d)
+	}
+
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] > offsets[j] })
+
+	return offsets, nil
+}
+
+// FormatEventTime renders a time as a discord timestamp that displays in each user's timezone
+func FormatEventTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return "<t:" + strconv.FormatInt(t.Unix(), 10) + ":F>"
+}
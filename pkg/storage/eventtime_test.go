@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseEventTime(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		arg    string
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "discord timestamp",
+			arg:    "<t:1640995200>",
+			want:   time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "discord timestamp with style and text",
+			arg:    "Saturday <t:1640995200:F> (be early!)",
+			want:   time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "rfc3339",
+			arg:    "2022-01-01T02:00:00+02:00",
+			want:   time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "date time with offset",
+			arg:    "2022-01-01 00:00 -0500",
+			want:   time.Date(2022, 1, 1, 5, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "date time utc",
+			arg:    " 2022-01-01 20:30 ",
+			want:   time.Date(2022, 1, 1, 20, 30, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "free text",
+			arg:    "saturday at 8pm server time",
+			wantOk: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := ParseEventTime(tt.arg)
+			if ok != tt.wantOk {
+				t.Errorf("ParseEventTime() ok = %v, want %v", ok, tt.wantOk)
+				return
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseEventTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReminderOffsets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		arg     string
+		want    []time.Duration
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			arg:  "",
+			want: []time.Duration{},
+		},
+		{
+			name: "off",
+			arg:  "off",
+			want: []time.Duration{},
+		},
+		{
+			name: "mixed units sorted",
+			arg:  "30m, 1d,2h",
+			want: []time.Duration{24 * time.Hour, 2 * time.Hour, 30 * time.Minute},
+		},
+		{
+			name:    "bad unit",
+			arg:     "2 weeks",
+			wantErr: true,
+		},
+		{
+			name:    "negative",
+			arg:     "-1h",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseReminderOffsets(tt.arg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseReminderOffsets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseReminderOffsets() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
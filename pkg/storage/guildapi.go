@@ -3,10 +3,13 @@ package storage
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 // ErrBadSetting is the error returned if an unknown setting is accessed
@@ -27,8 +30,28 @@ type GuildSettings struct {
 	AdminRoles            []string
 	MessageColor          string
 	ErrorColor            string
+
+	// SignupLimit caps the number of open events a user may hold a main roster spot in ("0" for no limit)
+	SignupLimit            string
+	SignupLimitPerCategory string
+
+	// ReminderOffsets is the default list of reminder times before an event (e.g., "1d,30m")
+	ReminderOffsets  string
+	ReminderDelivery string
+
+	// Language is the code of the language bot responses are given in (e.g., "en")
+	Language string
+
+	// RoleAliases are other names for roles across all events (e.g., "dd=dps, damage=dps")
+	RoleAliases string
 }
 
+// Reminder delivery methods
+const (
+	ReminderDeliveryChannel = "channel"
+	ReminderDeliveryDM      = "dm"
+)
+
 // PrettyString returns a multi-line string describing the settings
 func (s *GuildSettings) PrettyString(ctx context.Context) string {
 	_, span := s.census.StartSpan(ctx, "GuildSettings.PrettyString")
@@ -53,9 +76,15 @@ GuildSettings:
 	- HideReactionsShow: '%[11]s',
 	- MessageColor: '%[12]s',
 	- ErrorColor: '%[13]s',
+	- SignupLimit: '%[14]s',
+	- SignupLimitPerCategory: '%[15]s',
+	- ReminderOffsets: '%[16]s',
+	- ReminderDelivery: '%[17]s',
+	- Language: '%[18]s',
+	- RoleAliases: '%[19]s',
 	- AdminRoles: '%[9]s',
 
-	`, "```", s.ControlSequence, s.AnnounceChannel, s.SignupChannel, s.AdminChannel, s.AnnounceTo, s.ShowAfterSignup, s.ShowAfterWithdraw, strings.Join(adminRoles, ", "), s.HideReactionsAnnounce, s.HideReactionsShow, s.MessageColor, s.ErrorColor)
+	`, "```", s.ControlSequence, s.AnnounceChannel, s.SignupChannel, s.AdminChannel, s.AnnounceTo, s.ShowAfterSignup, s.ShowAfterWithdraw, strings.Join(adminRoles, ", "), s.HideReactionsAnnounce, s.HideReactionsShow, s.MessageColor, s.ErrorColor, s.SignupLimit, s.SignupLimitPerCategory, s.ReminderOffsets, s.ReminderDelivery, s.Language, s.RoleAliases)
 }
 
 // GetSettingString gets the value of a setting
@@ -88,6 +117,18 @@ func (s *GuildSettings) GetSettingString(ctx context.Context, name string) (stri
 		return s.MessageColor, nil
 	case "errorcolor":
 		return s.ErrorColor, nil
+	case "signuplimit":
+		return s.SignupLimit, nil
+	case "signuplimitpercategory":
+		return s.SignupLimitPerCategory, nil
+	case "reminderoffsets":
+		return s.ReminderOffsets, nil
+	case "reminderdelivery":
+		return s.ReminderDelivery, nil
+	case "language":
+		return s.Language, nil
+	case "rolealiases":
+		return s.RoleAliases, nil
 	default:
 		return "", ErrBadSetting
 	}
@@ -166,6 +207,52 @@ func (s *GuildSettings) SetSettingString(ctx context.Context, name, val string)
 	case "errorcolor":
 		s.ErrorColor = val
 		return nil
+	case "signuplimit":
+		if val == "" {
+			val = "0"
+		}
+		v, err := strconv.Atoi(val)
+		if err != nil || v < 0 {
+			return errors.New("could not set SignupLimit: must be a non-negative number")
+		}
+		s.SignupLimit = strconv.Itoa(v)
+		return nil
+	case "signuplimitpercategory":
+		v, err := normalizeTrueFalseString(val)
+		if err != nil {
+			return errors.Wrap(err, "could not set SignupLimitPerCategory")
+		}
+		s.SignupLimitPerCategory = v
+		return nil
+	case "reminderoffsets":
+		if _, err := ParseReminderOffsets(val); err != nil {
+			return errors.Wrap(err, "could not set ReminderOffsets")
+		}
+		s.ReminderOffsets = strings.TrimSpace(val)
+		return nil
+	case "reminderdelivery":
+		switch v := strings.ToLower(strings.TrimSpace(val)); v {
+		case "", ReminderDeliveryChannel:
+			s.ReminderDelivery = ReminderDeliveryChannel
+		case ReminderDeliveryDM:
+			s.ReminderDelivery = ReminderDeliveryDM
+		default:
+			return errors.New("could not set ReminderDelivery: must be 'channel' or 'dm'")
+		}
+		return nil
+	case "language":
+		v, ok := i18n.Normalize(val)
+		if !ok {
+			return errors.New("could not set Language: must be one of " + strings.Join(i18n.Languages(), ", "))
+		}
+		s.Language = v
+		return nil
+	case "rolealiases":
+		if _, err := ParseRoleAliases(val); err != nil {
+			return errors.Wrap(err, "could not set RoleAliases")
+		}
+		s.RoleAliases = strings.TrimSpace(val)
+		return nil
 	default:
 		return ErrBadSetting
 	}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// JobStatus represents the state of a scheduled job
+type JobStatus string
+
+// Job Status Constants
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusDone      JobStatus = "done"
+	JobStatusFailed    JobStatus = "failed"
+	JobStatusCanceled  JobStatus = "canceled"
+	JobStatusAbandoned JobStatus = "abandoned"
+)
+
+// Job is a persisted unit of time-based work
+type Job struct {
+	ID        int64
+	GuildID   string
+	EventName string
+	Kind      string
+	RunAt     time.Time
+	Status    JobStatus
+	Payload   string
+}
+
+// JobAPI is the api for managing scheduled jobs
+type JobAPI interface {
+	// AddJob persists a new pending job and returns its id
+	AddJob(ctx context.Context, job Job) (int64, error)
+
+	// ClaimDueJobs marks up to limit pending jobs that are due as running and returns them;
+	// the claim is committed before returning, so a job is never handed out twice
+	ClaimDueJobs(ctx context.Context, now time.Time, limit int) ([]Job, error)
+
+	// FinishJob records the final status of a claimed job
+	FinishJob(ctx context.Context, id int64, status JobStatus) error
+
+	// CancelEventJobs cancels the pending jobs for an event (optionally only those of the given kinds)
+	CancelEventJobs(ctx context.Context, guildID, eventName string, kinds ...string) (int64, error)
+
+	// AbandonStaleJobs marks jobs that were claimed before the given time but never finished as abandoned
+	AbandonStaleJobs(ctx context.Context, claimedBefore time.Time) (int64, error)
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// Kinds of live messages
+const (
+	LiveMessageAnnounce = "announce"
+	LiveMessageShow     = "show"
+)
+
+// LiveMessage is a posted event embed that is kept up to date as the roster changes
+type LiveMessage struct {
+	MessageID string
+	ChannelID string
+	GuildID   string
+	EventName string
+	Kind      string
+	CreatedAt time.Time
+}
+
+// LiveMessageAPI is the api for tracking the messages that show an event roster
+type LiveMessageAPI interface {
+	// AddLiveMessage records a message, forgetting all but the newest keep messages for its event
+	AddLiveMessage(ctx context.Context, msg LiveMessage, keep int) error
+	ListLiveMessages(ctx context.Context, guildID, eventName string) ([]LiveMessage, error)
+	RemoveLiveMessage(ctx context.Context, messageID string) error
+	RemoveEventLiveMessages(ctx context.Context, guildID, eventName string) error
+}
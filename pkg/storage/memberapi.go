@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"context"
+)
+
+// MemberAPI is the api for managing per-member preferences within a guild
+type MemberAPI interface {
+	RemindersOptOut(ctx context.Context, guildID, memberID string) (bool, error)
+	SetRemindersOptOut(ctx context.Context, guildID, memberID string, optOut bool) error
+
+	// RemindersOptedOut returns the subset of the given members who have opted out of reminders
+	RemindersOptedOut(ctx context.Context, guildID string, memberIDs []string) (map[string]bool, error)
+}
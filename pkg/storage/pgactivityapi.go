@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgActivityAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgActivityAPI constructs a postgres-backed ActivityAPI
+func NewPgActivityAPI(db *pgxpool.Pool, c *telemetry.Census) (ActivityAPI, error) {
+	b := pgActivityAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgActivityAPI) RecordActivity(ctx context.Context, a MemberActivity) error {
+	ctx, span := p.census.StartSpan(ctx, "pgActivityAPI.RecordActivity")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	INSERT INTO member_activity (guild_id, member, event_name, member_role, activity_kind, late)
+	VALUES ($1, $2, $3, $4, $5, $6)`, a.GuildID, a.Member, strings.ToLower(a.EventName), a.Role, string(a.Kind), a.Late)
+
+	return errors.Wrap(err, "could not record member activity", "kind", string(a.Kind))
+}
+
+// statsQuery combines member activity and attendance records into per-member, per-role counts
+const statsQuery = `
+	SELECT member, member_role,
+		COUNT(*) FILTER (WHERE kind = 'signup'),
+		COUNT(*) FILTER (WHERE kind = 'withdraw'),
+		COUNT(*) FILTER (WHERE kind = 'withdraw' AND late),
+		COUNT(*) FILTER (WHERE kind = 'attended'),
+		COUNT(*) FILTER (WHERE kind = 'late'),
+		COUNT(*) FILTER (WHERE kind = 'no_show')
+	FROM (
+		SELECT member, member_role, activity_kind AS kind, late
+		FROM member_activity
+		WHERE guild_id = $1 AND created_at >= $2
+		UNION ALL
+		SELECT member, member_role, attendance_status AS kind, 'f' AS late
+		FROM attendance
+		WHERE guild_id = $1 AND created_at >= $2
+	) AS a`
+
+func (p *pgActivityAPI) MemberStats(ctx context.Context, guildID, member string, since time.Time) ([]ReliabilityStats, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgActivityAPI.MemberStats")
+	defer span.End()
+
+	return p.queryStats(ctx, statsQuery+`
+	WHERE member = $3
+	GROUP BY member, member_role
+	ORDER BY member_role`, guildID, since, member)
+}
+
+func (p *pgActivityAPI) GuildStats(ctx context.Context, guildID string, since time.Time) ([]ReliabilityStats, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgActivityAPI.GuildStats")
+	defer span.End()
+
+	return p.queryStats(ctx, statsQuery+`
+	GROUP BY member, member_role
+	ORDER BY member, member_role`, guildID, since)
+}
+
+func (p *pgActivityAPI) queryStats(ctx context.Context, query string, args ...interface{}) ([]ReliabilityStats, error) {
+	rs, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve member stats")
+	}
+	defer rs.Close()
+
+	var stats []ReliabilityStats
+	for rs.Next() {
+		var s ReliabilityStats
+		if err := rs.Scan(&s.Member, &s.Role, &s.Signups, &s.Withdrawals, &s.LateWithdrawals, &s.Attended, &s.Late, &s.NoShows); err != nil {
+			return nil, errors.Wrap(err, "could not scan member stats")
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, errors.Wrap(rs.Err(), "could not retrieve member stats")
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgAPITokenAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgAPITokenAPI constructs a postgres-backed APITokenAPI
+func NewPgAPITokenAPI(db *pgxpool.Pool, c *telemetry.Census) (APITokenAPI, error) {
+	b := pgAPITokenAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgAPITokenAPI) AddToken(ctx context.Context, guildID, token, label, createdBy string) (int64, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgAPITokenAPI.AddToken")
+	defer span.End()
+
+	var id int64
+
+	r := p.db.QueryRow(ctx, `
+	INSERT INTO api_tokens (guild_id, token_hash, label, created_by)
+	VALUES ($1, $2, $3, $4)
+	RETURNING token_id`, guildID, HashAPIToken(token), label, createdBy)
+
+	if err := r.Scan(&id); err != nil {
+		return 0, errors.Wrap(err, "could not insert api token")
+	}
+
+	return id, nil
+}
+
+func (p *pgAPITokenAPI) ListTokens(ctx context.Context, guildID string) ([]APIToken, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgAPITokenAPI.ListTokens")
+	defer span.End()
+
+	rs, err := p.db.Query(ctx, `
+	SELECT token_id, guild_id, label, created_by, created_at, last_used_at
+	FROM api_tokens
+	WHERE guild_id = $1
+	ORDER BY token_id`, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve api tokens")
+	}
+	defer rs.Close()
+
+	var tokens []APIToken
+	for rs.Next() {
+		var tok APIToken
+		var lastUsed sql.NullTime
+		if err := rs.Scan(&tok.ID, &tok.GuildID, &tok.Label, &tok.CreatedBy, &tok.CreatedAt, &lastUsed); err != nil {
+			return nil, errors.Wrap(err, "could not scan api token")
+		}
+
+		tok.GuildID = strings.TrimSpace(tok.GuildID)
+		if lastUsed.Valid {
+			tok.LastUsedAt = lastUsed.Time
+		}
+		tokens = append(tokens, tok)
+	}
+
+	return tokens, errors.Wrap(rs.Err(), "could not retrieve api tokens")
+}
+
+func (p *pgAPITokenAPI) RevokeToken(ctx context.Context, guildID string, id int64) error {
+	ctx, span := p.census.StartSpan(ctx, "pgAPITokenAPI.RevokeToken")
+	defer span.End()
+
+	res, err := p.db.Exec(ctx, `
+	DELETE FROM api_tokens
+	WHERE guild_id = $1 AND token_id = $2`, guildID, id)
+	if err != nil {
+		return errors.Wrap(err, "could not revoke api token", "token_id", id)
+	}
+
+	if res.RowsAffected() == 0 {
+		return ErrTokenNotExist
+	}
+
+	return nil
+}
+
+func (p *pgAPITokenAPI) GuildForToken(ctx context.Context, token string) (string, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgAPITokenAPI.GuildForToken")
+	defer span.End()
+
+	var guildID string
+
+	r := p.db.QueryRow(ctx, `
+	UPDATE api_tokens
+	SET last_used_at = NOW()
+	WHERE token_hash = $1
+	RETURNING guild_id`, HashAPIToken(token))
+
+	if err := r.Scan(&guildID); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", ErrTokenNotExist
+		}
+		return "", errors.Wrap(err, "could not look up api token")
+	}
+
+	return strings.TrimSpace(guildID), nil
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgAttendanceAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgAttendanceAPI constructs a postgres-backed AttendanceAPI
+func NewPgAttendanceAPI(db *pgxpool.Pool, c *telemetry.Census) (AttendanceAPI, error) {
+	b := pgAttendanceAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgAttendanceAPI) RecordAttendance(ctx context.Context, records []AttendanceRecord) error {
+	ctx, span := p.census.StartSpan(ctx, "pgAttendanceAPI.RecordAttendance")
+	defer span.End()
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return err
+	}
+	defer deferutil.CheckDefer(func() error {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			return err
+		}
+		return nil
+	})
+
+	for _, rec := range records {
+		_, err := tx.Exec(ctx, `
+		INSERT INTO attendance (guild_id, event_name, occurrence, member, member_role, attendance_status, recorded_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (guild_id, event_name, occurrence, member) DO UPDATE
+		SET
+			member_role = EXCLUDED.member_role,
+			attendance_status = EXCLUDED.attendance_status,
+			recorded_by = EXCLUDED.recorded_by
+		`, rec.GuildID, strings.ToLower(rec.EventName), rec.Occurrence, rec.Member, rec.Role, string(rec.Status), rec.RecordedBy)
+		if err != nil {
+			return errors.Wrap(err, "could not upsert attendance", "member", rec.Member)
+		}
+	}
+
+	return errors.Wrap(tx.Commit(ctx), "could not save attendance")
+}
+
+func (p *pgAttendanceAPI) EventAttendance(ctx context.Context, guildID, eventName, occurrence string) ([]AttendanceRecord, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgAttendanceAPI.EventAttendance")
+	defer span.End()
+
+	return p.queryAttendance(ctx, `
+	SELECT guild_id, event_name, occurrence, member, member_role, attendance_status, recorded_by, updated_at
+	FROM attendance
+	WHERE guild_id = $1 AND event_name = $2 AND occurrence = $3
+	ORDER BY member_role, member`, guildID, strings.ToLower(eventName), occurrence)
+}
+
+func (p *pgAttendanceAPI) MemberAttendance(ctx context.Context, guildID, member string) ([]AttendanceRecord, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgAttendanceAPI.MemberAttendance")
+	defer span.End()
+
+	return p.queryAttendance(ctx, `
+	SELECT guild_id, event_name, occurrence, member, member_role, attendance_status, recorded_by, updated_at
+	FROM attendance
+	WHERE guild_id = $1 AND member = $2
+	ORDER BY created_at`, guildID, member)
+}
+
+func (p *pgAttendanceAPI) queryAttendance(ctx context.Context, query string, args ...interface{}) ([]AttendanceRecord, error) {
+	rs, err := p.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve attendance")
+	}
+	defer rs.Close()
+
+	var records []AttendanceRecord
+	for rs.Next() {
+		var rec AttendanceRecord
+		var status string
+		if err := rs.Scan(&rec.GuildID, &rec.EventName, &rec.Occurrence, &rec.Member, &rec.Role, &status, &rec.RecordedBy, &rec.RecordedAt); err != nil {
+			return nil, errors.Wrap(err, "could not scan attendance")
+		}
+
+		rec.GuildID = strings.TrimSpace(rec.GuildID)
+		rec.Status = AttendanceStatus(status)
+		records = append(records, rec)
+	}
+
+	return records, errors.Wrap(rs.Err(), "could not retrieve attendance")
+}
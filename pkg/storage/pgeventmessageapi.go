@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgEventMessageAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgEventMessageAPI constructs a postgres-backed EventMessageAPI
+func NewPgEventMessageAPI(db *pgxpool.Pool, c *telemetry.Census) (EventMessageAPI, error) {
+	b := pgEventMessageAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgEventMessageAPI) IndexEventMessage(ctx context.Context, guildID, channelID, messageID, eventName string) error {
+	ctx, span := p.census.StartSpan(ctx, "pgEventMessageAPI.IndexEventMessage")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	INSERT INTO event_messages (channel_id, message_id, guild_id, event_name)
+	VALUES ($1, $2, $3, $4)
+	ON CONFLICT (channel_id, message_id) DO UPDATE SET event_name = EXCLUDED.event_name`, channelID, messageID, guildID, eventName)
+
+	return errors.Wrap(err, "could not index event message")
+}
+
+func (p *pgEventMessageAPI) EventForMessage(ctx context.Context, channelID, messageID string) (string, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgEventMessageAPI.EventForMessage")
+	defer span.End()
+
+	var eventName string
+
+	r := p.db.QueryRow(ctx, `
+	SELECT event_name
+	FROM event_messages
+	WHERE channel_id = $1 AND message_id = $2`, channelID, messageID)
+
+	if err := r.Scan(&eventName); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", ErrEventMessageNotExist
+		}
+		return "", errors.Wrap(err, "could not retrieve event message")
+	}
+
+	return eventName, nil
+}
+
+func (p *pgEventMessageAPI) RemoveEventMessages(ctx context.Context, guildID, eventName string) error {
+	ctx, span := p.census.StartSpan(ctx, "pgEventMessageAPI.RemoveEventMessages")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	DELETE FROM event_messages
+	WHERE guild_id = $1 AND LOWER(event_name) = LOWER($2)`, guildID, eventName)
+
+	return errors.Wrap(err, "could not remove event messages", "event_name", eventName)
+}
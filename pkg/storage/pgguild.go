@@ -2,8 +2,11 @@ package storage
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/i18n"
 )
 
 type guildData struct {
@@ -23,6 +26,13 @@ type guildData struct {
 	ShowNotes             bool
 	OpenAdminAccess       bool
 
+	SignupLimit            int
+	SignupLimitPerCategory bool
+	ReminderOffsets        string
+	ReminderDelivery       string
+	Language               string
+	RoleAliases            string
+
 	AdminRoles []string
 }
 
@@ -55,6 +65,21 @@ func (g *pgGuild) GetSettings(ctx context.Context) GuildSettings {
 		AdminRoles:      g.data.AdminRoles,
 		MessageColor:    g.data.MessageColor,
 		ErrorColor:      g.data.ErrorColor,
+		SignupLimit:     strconv.Itoa(g.data.SignupLimit),
+		ReminderOffsets: g.data.ReminderOffsets,
+		RoleAliases:     g.data.RoleAliases,
+	}
+
+	if g.data.ReminderDelivery == "" {
+		s.ReminderDelivery = ReminderDeliveryChannel
+	} else {
+		s.ReminderDelivery = g.data.ReminderDelivery
+	}
+
+	if g.data.Language == "" {
+		s.Language = i18n.Default
+	} else {
+		s.Language = g.data.Language
 	}
 
 	if g.data.ShowAfterSignup {
@@ -81,6 +106,12 @@ func (g *pgGuild) GetSettings(ctx context.Context) GuildSettings {
 		s.HideReactionsShow = "false"
 	}
 
+	if g.data.SignupLimitPerCategory {
+		s.SignupLimitPerCategory = "true"
+	} else {
+		s.SignupLimitPerCategory = "false"
+	}
+
 	return s
 }
 
@@ -99,4 +130,17 @@ func (g *pgGuild) SetSettings(ctx context.Context, s GuildSettings) {
 
 	g.data.ShowAfterSignup = s.ShowAfterSignup == "true"
 	g.data.ShowAfterWithdraw = s.ShowAfterWithdraw == "true"
+
+	g.data.SignupLimit, _ = strconv.Atoi(s.SignupLimit) // an empty or invalid limit means no limit
+	g.data.SignupLimitPerCategory = s.SignupLimitPerCategory == "true"
+	g.data.ReminderOffsets = s.ReminderOffsets
+	g.data.ReminderDelivery = s.ReminderDelivery
+	if g.data.ReminderDelivery == "" {
+		g.data.ReminderDelivery = ReminderDeliveryChannel
+	}
+	g.data.Language = s.Language
+	if g.data.Language == "" {
+		g.data.Language = i18n.Default
+	}
+	g.data.RoleAliases = s.RoleAliases
 }
@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/gsmcwhirter/go-util/v8/errors"
@@ -119,7 +120,10 @@ func (p *PgGuildAPITx) GetGuildPg(ctx context.Context, name string) (Guild, erro
 		   admin_channel, announce_to,
 		   show_after_signup, show_after_withdraw,
 		   hide_reactions_announce, hide_reactions_show,
-		   message_color, error_color
+		   message_color, error_color,
+		   signup_limit, signup_limit_per_category,
+		   reminder_offsets, reminder_delivery,
+		   language, role_aliases
 	FROM guild_settings WHERE guild_id = $1`, name)
 
 	if err := r.Scan(
@@ -129,6 +133,9 @@ func (p *PgGuildAPITx) GetGuildPg(ctx context.Context, name string) (Guild, erro
 		&pGuild.ShowAfterSignup, &pGuild.ShowAfterWithdraw,
 		&pGuild.HideReactionsAnnounce, &pGuild.HideReactionsShow,
 		&pGuild.MessageColor, &pGuild.ErrorColor,
+		&pGuild.SignupLimit, &pGuild.SignupLimitPerCategory,
+		&pGuild.ReminderOffsets, &pGuild.ReminderDelivery,
+		&pGuild.Language, &pGuild.RoleAliases,
 	); err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, ErrGuildNotExist
@@ -209,9 +216,14 @@ func (p *PgGuildAPITx) SaveGuild(ctx context.Context, guild Guild) error {
 	gid := guild.GetName(ctx)
 	gs := guild.GetSettings(ctx)
 
-	_, err := p.tx.Exec(ctx, `
-	INSERT INTO guild_settings (guild_id, command_indicator, announce_channel, signup_channel, admin_channel, announce_to, show_after_signup, show_after_withdraw, hide_reactions_announce, hide_reactions_show, message_color, error_color)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	signupLimit, err := strconv.Atoi(gs.SignupLimit)
+	if err != nil {
+		return errors.Wrap(err, "could not parse signup limit", "signup_limit", gs.SignupLimit)
+	}
+
+	_, err = p.tx.Exec(ctx, `
+	INSERT INTO guild_settings (guild_id, command_indicator, announce_channel, signup_channel, admin_channel, announce_to, show_after_signup, show_after_withdraw, hide_reactions_announce, hide_reactions_show, message_color, error_color, signup_limit, signup_limit_per_category, reminder_offsets, reminder_delivery, language, role_aliases)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 	ON CONFLICT (guild_id) DO UPDATE
 	SET 
 		command_indicator = EXCLUDED.command_indicator,
@@ -224,8 +236,14 @@ func (p *PgGuildAPITx) SaveGuild(ctx context.Context, guild Guild) error {
 		hide_reactions_announce = EXCLUDED.hide_reactions_announce,
 		hide_reactions_show = EXCLUDED.hide_reactions_show,
 		message_color = EXCLUDED.message_color,
-		error_color = EXCLUDED.error_color
-	`, gid, gs.ControlSequence, gs.AnnounceChannel, gs.SignupChannel, gs.AdminChannel, gs.AnnounceTo, gs.ShowAfterSignup, gs.ShowAfterWithdraw, gs.HideReactionsAnnounce, gs.HideReactionsShow, gs.MessageColor, gs.ErrorColor)
+		error_color = EXCLUDED.error_color,
+		signup_limit = EXCLUDED.signup_limit,
+		signup_limit_per_category = EXCLUDED.signup_limit_per_category,
+		reminder_offsets = EXCLUDED.reminder_offsets,
+		reminder_delivery = EXCLUDED.reminder_delivery,
+		language = EXCLUDED.language,
+		role_aliases = EXCLUDED.role_aliases
+	`, gid, gs.ControlSequence, gs.AnnounceChannel, gs.SignupChannel, gs.AdminChannel, gs.AnnounceTo, gs.ShowAfterSignup, gs.ShowAfterWithdraw, gs.HideReactionsAnnounce, gs.HideReactionsShow, gs.MessageColor, gs.ErrorColor, signupLimit, gs.SignupLimitPerCategory, gs.ReminderOffsets, gs.ReminderDelivery, gs.Language, gs.RoleAliases)
 	if err != nil {
 		return errors.Wrap(err, "could not upsert guild_settings")
 	}
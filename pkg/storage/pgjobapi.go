@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgJobAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgJobAPI constructs a postgres-backed JobAPI
+func NewPgJobAPI(db *pgxpool.Pool, c *telemetry.Census) (JobAPI, error) {
+	b := pgJobAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgJobAPI) AddJob(ctx context.Context, job Job) (int64, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgJobAPI.AddJob")
+	defer span.End()
+
+	var id int64
+
+	r := p.db.QueryRow(ctx, `
+	INSERT INTO scheduled_jobs (guild_id, event_name, job_kind, run_at, job_status, payload)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING job_id`, job.GuildID, strings.ToLower(job.EventName), job.Kind, job.RunAt, string(JobStatusPending), job.Payload)
+
+	if err := r.Scan(&id); err != nil {
+		return 0, errors.Wrap(err, "could not insert scheduled job", "kind", job.Kind)
+	}
+
+	return id, nil
+}
+
+func (p *pgJobAPI) ClaimDueJobs(ctx context.Context, now time.Time, limit int) ([]Job, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgJobAPI.ClaimDueJobs")
+	defer span.End()
+
+	tx, err := p.db.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.ReadCommitted,
+		AccessMode: pgx.ReadWrite,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer deferutil.CheckDefer(func() error {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			return err
+		}
+		return nil
+	})
+
+	rs, err := tx.Query(ctx, `
+	UPDATE scheduled_jobs
+	SET job_status = $1
+	WHERE job_id IN (
+		SELECT job_id
+		FROM scheduled_jobs
+		WHERE job_status = $2 AND run_at <= $3
+		ORDER BY run_at
+		LIMIT $4
+		FOR UPDATE SKIP LOCKED
+	)
+	RETURNING job_id, guild_id, event_name, job_kind, run_at, job_status, payload`, string(JobStatusRunning), string(JobStatusPending), now, limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not claim scheduled jobs")
+	}
+	defer rs.Close()
+
+	jobs := make([]Job, 0, limit)
+	for rs.Next() {
+		var job Job
+		var status string
+		if err := rs.Scan(&job.ID, &job.GuildID, &job.EventName, &job.Kind, &job.RunAt, &status, &job.Payload); err != nil {
+			return nil, errors.Wrap(err, "could not scan scheduled job")
+		}
+
+		job.GuildID = strings.TrimSpace(job.GuildID)
+		job.Status = JobStatus(status)
+		jobs = append(jobs, job)
+	}
+
+	if err := rs.Err(); err != nil {
+		return nil, errors.Wrap(err, "could not claim scheduled jobs")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "could not claim scheduled jobs")
+	}
+
+	return jobs, nil
+}
+
+func (p *pgJobAPI) FinishJob(ctx context.Context, id int64, status JobStatus) error {
+	ctx, span := p.census.StartSpan(ctx, "pgJobAPI.FinishJob")
+	defer span.End()
+
+	res, err := p.db.Exec(ctx, `
+	UPDATE scheduled_jobs
+	SET job_status = $1
+	WHERE job_id = $2 AND job_status = $3`, string(status), id, string(JobStatusRunning))
+	if err != nil {
+		return errors.Wrap(err, "could not finish scheduled job", "job_id", id)
+	}
+
+	if res.RowsAffected() > 1 {
+		return ErrTooManyRows
+	}
+
+	return nil
+}
+
+func (p *pgJobAPI) CancelEventJobs(ctx context.Context, guildID, eventName string, kinds ...string) (int64, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgJobAPI.CancelEventJobs")
+	defer span.End()
+
+	args := []interface{}{string(JobStatusCanceled), guildID, strings.ToLower(eventName), string(JobStatusPending)}
+	query := `
+	UPDATE scheduled_jobs
+	SET job_status = $1
+	WHERE guild_id = $2 AND event_name = $3 AND job_status = $4`
+
+	if len(kinds) > 0 {
+		query += fmt.Sprintf(" AND job_kind IN (%s)", genPlaceholders("%s", ", ", len(args)+1, len(kinds)))
+		for _, kind := range kinds {
+			args = append(args, kind)
+		}
+	}
+
+	res, err := p.db.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not cancel scheduled jobs", "event_name", eventName)
+	}
+
+	return res.RowsAffected(), nil
+}
+
+func (p *pgJobAPI) AbandonStaleJobs(ctx context.Context, claimedBefore time.Time) (int64, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgJobAPI.AbandonStaleJobs")
+	defer span.End()
+
+	res, err := p.db.Exec(ctx, `
+	UPDATE scheduled_jobs
+	SET job_status = $1
+	WHERE job_status = $2 AND updated_at < $3`, string(JobStatusAbandoned), string(JobStatusRunning), claimedBefore)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not abandon stale scheduled jobs")
+	}
+
+	return res.RowsAffected(), nil
+}
@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// testDatabaseEnv names the postgres connection string used by the database tests; they are
+// skipped when it is not set
+const testDatabaseEnv = "SIGNUP_BOT_TEST_PG"
+
+// testJobAPI connects to the test database, with the scheduled_jobs table created in a schema of its own
+func testJobAPI(t *testing.T) (JobAPI, *pgxpool.Pool) {
+	t.Helper()
+
+	dsn := os.Getenv(testDatabaseEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	ctx := context.Background()
+	schema := fmt.Sprintf("test_jobs_%d", time.Now().UnixNano())
+
+	admin, err := pgxpool.Connect(ctx, dsn)
+	if err != nil {
+		t.Fatalf("could not connect to the test database: %v", err)
+	}
+	defer admin.Close()
+
+	if _, err = admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("could not create schema: %v", err)
+	}
+
+	conf, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("could not parse %s: %v", testDatabaseEnv, err)
+	}
+	conf.ConnConfig.RuntimeParams["search_path"] = schema
+
+	db, err := pgxpool.ConnectConfig(ctx, conf)
+	if err != nil {
+		t.Fatalf("could not connect to the test database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+
+		admin, err := pgxpool.Connect(ctx, dsn)
+		if err != nil {
+			return
+		}
+		defer admin.Close()
+
+		_, _ = admin.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	if _, err = db.Exec(ctx, `
+	CREATE FUNCTION update_updated_at_column() RETURNS TRIGGER AS $$
+	BEGIN
+		NEW.updated_at = now();
+		RETURN NEW;
+	END;
+	$$ language 'plpgsql'`); err != nil {
+		t.Fatalf("could not create trigger function (as in migration 002): %v", err)
+	}
+
+	migration, err := ioutil.ReadFile("../../migrations/009_scheduled_jobs.sql")
+	if err != nil {
+		t.Fatalf("could not read migration: %v", err)
+	}
+
+	up := strings.SplitN(string(migration), "---- create above / drop below ----", 2)[0]
+	if _, err = db.Exec(ctx, up); err != nil {
+		t.Fatalf("could not create scheduled_jobs: %v", err)
+	}
+
+	api, err := NewPgJobAPI(db, &telemetry.Census{})
+	if err != nil {
+		t.Fatalf("NewPgJobAPI() error = %v", err)
+	}
+
+	return api, db
+}
+
+func jobStatus(t *testing.T, db *pgxpool.Pool, id int64) JobStatus {
+	t.Helper()
+
+	var status string
+	if err := db.QueryRow(context.Background(), `SELECT job_status FROM scheduled_jobs WHERE job_id = $1`, id).Scan(&status); err != nil {
+		t.Fatalf("could not get status of job %d: %v", id, err)
+	}
+
+	return JobStatus(status)
+}
+
+func addTestJob(t *testing.T, api JobAPI, job Job) int64 {
+	t.Helper()
+
+	id, err := api.AddJob(context.Background(), job)
+	if err != nil {
+		t.Fatalf("AddJob() error = %v", err)
+	}
+
+	return id
+}
+
+func TestPgJobAPI_ClaimDueJobs(t *testing.T) {
+	api, db := testJobAPI(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	later := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "reminder", RunAt: now.Add(-time.Minute)})
+	first := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "open", RunAt: now.Add(-time.Hour)})
+	future := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "close", RunAt: now.Add(time.Hour)})
+
+	jobs, err := api.ClaimDueJobs(ctx, now, 1)
+	if err != nil {
+		t.Fatalf("ClaimDueJobs() error = %v", err)
+	}
+
+	if len(jobs) != 1 || jobs[0].ID != first || jobs[0].Status != JobStatusRunning || jobs[0].GuildID != "1" || jobs[0].EventName != "raid" {
+		t.Fatalf("ClaimDueJobs() = %+v, want only job %d, running", jobs, first)
+	}
+
+	jobs, err = api.ClaimDueJobs(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("ClaimDueJobs() error = %v", err)
+	}
+
+	if len(jobs) != 1 || jobs[0].ID != later {
+		t.Fatalf("ClaimDueJobs() = %+v, want only job %d; a claimed job was handed out again", jobs, later)
+	}
+
+	if got := jobStatus(t, db, future); got != JobStatusPending {
+		t.Errorf("job that is not due is %s, want %s", got, JobStatusPending)
+	}
+
+	if err = api.FinishJob(ctx, first, JobStatusDone); err != nil {
+		t.Fatalf("FinishJob() error = %v", err)
+	}
+
+	if got := jobStatus(t, db, first); got != JobStatusDone {
+		t.Errorf("finished job is %s, want %s", got, JobStatusDone)
+	}
+}
+
+func TestPgJobAPI_reschedule(t *testing.T) {
+	api, db := testJobAPI(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	open := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "open", RunAt: now.Add(time.Hour)})
+	reminder := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "reminder", RunAt: now.Add(2 * time.Hour)})
+	other := addTestJob(t, api, Job{GuildID: "1", EventName: "Dungeon", Kind: "open", RunAt: now.Add(time.Hour)})
+	otherGuild := addTestJob(t, api, Job{GuildID: "2", EventName: "Raid", Kind: "open", RunAt: now.Add(time.Hour)})
+	running := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "close", RunAt: now.Add(-time.Minute)})
+
+	if _, err := api.ClaimDueJobs(ctx, now, 10); err != nil {
+		t.Fatalf("ClaimDueJobs() error = %v", err)
+	}
+
+	n, err := api.CancelEventJobs(ctx, "1", "RAID", "reminder")
+	if err != nil {
+		t.Fatalf("CancelEventJobs() error = %v", err)
+	}
+
+	if n != 1 || jobStatus(t, db, reminder) != JobStatusCanceled || jobStatus(t, db, open) != JobStatusPending {
+		t.Fatalf("CancelEventJobs(reminder) canceled %d jobs, want only job %d", n, reminder)
+	}
+
+	// rescheduling cancels everything pending for the event, then adds the new jobs
+	n, err = api.CancelEventJobs(ctx, "1", "raid")
+	if err != nil {
+		t.Fatalf("CancelEventJobs() error = %v", err)
+	}
+
+	if n != 1 || jobStatus(t, db, open) != JobStatusCanceled {
+		t.Errorf("CancelEventJobs() canceled %d jobs, want only job %d", n, open)
+	}
+
+	for id, want := range map[int64]JobStatus{other: JobStatusPending, otherGuild: JobStatusPending, running: JobStatusRunning} {
+		if got := jobStatus(t, db, id); got != want {
+			t.Errorf("job %d is %s, want %s", id, got, want)
+		}
+	}
+
+	moved := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "open", RunAt: now.Add(-time.Second)})
+
+	jobs, err := api.ClaimDueJobs(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("ClaimDueJobs() error = %v", err)
+	}
+
+	if len(jobs) != 1 || jobs[0].ID != moved {
+		t.Errorf("ClaimDueJobs() = %+v, want only the rescheduled job %d", jobs, moved)
+	}
+}
+
+func TestPgJobAPI_AbandonStaleJobs(t *testing.T) {
+	api, db := testJobAPI(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	stale := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "open", RunAt: now.Add(-time.Hour)})
+	pending := addTestJob(t, api, Job{GuildID: "1", EventName: "Raid", Kind: "close", RunAt: now.Add(time.Hour)})
+
+	if _, err := api.ClaimDueJobs(ctx, now, 10); err != nil {
+		t.Fatalf("ClaimDueJobs() error = %v", err)
+	}
+
+	n, err := api.AbandonStaleJobs(ctx, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("AbandonStaleJobs() error = %v", err)
+	}
+
+	if n != 0 {
+		t.Fatalf("AbandonStaleJobs() abandoned %d jobs claimed after the cutoff", n)
+	}
+
+	n, err = api.AbandonStaleJobs(ctx, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("AbandonStaleJobs() error = %v", err)
+	}
+
+	if n != 1 || jobStatus(t, db, stale) != JobStatusAbandoned || jobStatus(t, db, pending) != JobStatusPending {
+		t.Fatalf("AbandonStaleJobs() abandoned %d jobs, want only job %d", n, stale)
+	}
+
+	// a handler finishing late does not bring an abandoned job back
+	if err = api.FinishJob(ctx, stale, JobStatusDone); err != nil {
+		t.Fatalf("FinishJob() error = %v", err)
+	}
+
+	if got := jobStatus(t, db, stale); got != JobStatusAbandoned {
+		t.Errorf("abandoned job is %s after finishing, want %s", got, JobStatusAbandoned)
+	}
+}
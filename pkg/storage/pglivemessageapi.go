@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgLiveMessageAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgLiveMessageAPI constructs a postgres-backed LiveMessageAPI
+func NewPgLiveMessageAPI(db *pgxpool.Pool, c *telemetry.Census) (LiveMessageAPI, error) {
+	b := pgLiveMessageAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgLiveMessageAPI) AddLiveMessage(ctx context.Context, msg LiveMessage, keep int) error {
+	ctx, span := p.census.StartSpan(ctx, "pgLiveMessageAPI.AddLiveMessage")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	INSERT INTO live_messages (message_id, channel_id, guild_id, event_name, kind)
+	VALUES ($1, $2, $3, $4, $5)
+	ON CONFLICT (message_id) DO NOTHING`, msg.MessageID, msg.ChannelID, msg.GuildID, msg.EventName, msg.Kind)
+	if err != nil {
+		return errors.Wrap(err, "could not insert live message")
+	}
+
+	_, err = p.db.Exec(ctx, `
+	DELETE FROM live_messages
+	WHERE guild_id = $1 AND event_name = $2 AND message_id NOT IN (
+		SELECT message_id
+		FROM live_messages
+		WHERE guild_id = $1 AND event_name = $2
+		ORDER BY created_at DESC, message_id DESC
+		LIMIT $3
+	)`, msg.GuildID, msg.EventName, keep)
+
+	return errors.Wrap(err, "could not prune live messages")
+}
+
+func (p *pgLiveMessageAPI) ListLiveMessages(ctx context.Context, guildID, eventName string) ([]LiveMessage, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgLiveMessageAPI.ListLiveMessages")
+	defer span.End()
+
+	rs, err := p.db.Query(ctx, `
+	SELECT message_id, channel_id, guild_id, event_name, kind, created_at
+	FROM live_messages
+	WHERE guild_id = $1 AND event_name = $2
+	ORDER BY created_at DESC`, guildID, eventName)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve live messages")
+	}
+	defer rs.Close()
+
+	var msgs []LiveMessage
+	for rs.Next() {
+		var m LiveMessage
+		if err := rs.Scan(&m.MessageID, &m.ChannelID, &m.GuildID, &m.EventName, &m.Kind, &m.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "could not scan live message")
+		}
+
+		m.MessageID = strings.TrimSpace(m.MessageID)
+		m.ChannelID = strings.TrimSpace(m.ChannelID)
+		m.GuildID = strings.TrimSpace(m.GuildID)
+		msgs = append(msgs, m)
+	}
+
+	return msgs, errors.Wrap(rs.Err(), "could not retrieve live messages")
+}
+
+func (p *pgLiveMessageAPI) RemoveLiveMessage(ctx context.Context, messageID string) error {
+	ctx, span := p.census.StartSpan(ctx, "pgLiveMessageAPI.RemoveLiveMessage")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	DELETE FROM live_messages
+	WHERE message_id = $1`, messageID)
+
+	return errors.Wrap(err, "could not remove live message", "message_id", messageID)
+}
+
+func (p *pgLiveMessageAPI) RemoveEventLiveMessages(ctx context.Context, guildID, eventName string) error {
+	ctx, span := p.census.StartSpan(ctx, "pgLiveMessageAPI.RemoveEventLiveMessages")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	DELETE FROM live_messages
+	WHERE guild_id = $1 AND event_name = $2`, guildID, eventName)
+
+	return errors.Wrap(err, "could not remove live messages", "event_name", eventName)
+}
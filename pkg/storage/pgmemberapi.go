@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgMemberAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgMemberAPI constructs a postgres-backed MemberAPI
+func NewPgMemberAPI(db *pgxpool.Pool, c *telemetry.Census) (MemberAPI, error) {
+	b := pgMemberAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgMemberAPI) RemindersOptOut(ctx context.Context, guildID, memberID string) (bool, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgMemberAPI.RemindersOptOut")
+	defer span.End()
+
+	var optOut bool
+
+	r := p.db.QueryRow(ctx, `
+	SELECT reminders_opt_out
+	FROM member_preferences
+	WHERE guild_id = $1 AND member_id = $2`, guildID, memberID)
+
+	if err := r.Scan(&optOut); err != nil {
+		if err == pgx.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "could not retrieve member preferences")
+	}
+
+	return optOut, nil
+}
+
+func (p *pgMemberAPI) SetRemindersOptOut(ctx context.Context, guildID, memberID string, optOut bool) error {
+	ctx, span := p.census.StartSpan(ctx, "pgMemberAPI.SetRemindersOptOut")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	INSERT INTO member_preferences (guild_id, member_id, reminders_opt_out)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (guild_id, member_id) DO UPDATE
+	SET
+		reminders_opt_out = EXCLUDED.reminders_opt_out
+	`, guildID, memberID, optOut)
+
+	return errors.Wrap(err, "could not upsert member preferences")
+}
+
+func (p *pgMemberAPI) RemindersOptedOut(ctx context.Context, guildID string, memberIDs []string) (map[string]bool, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgMemberAPI.RemindersOptedOut")
+	defer span.End()
+
+	optedOut := map[string]bool{}
+	if len(memberIDs) == 0 {
+		return optedOut, nil
+	}
+
+	args := make([]interface{}, len(memberIDs)+1)
+	args[0] = guildID
+	for i, v := range memberIDs {
+		args[i+1] = v
+	}
+
+	rs, err := p.db.Query(ctx, fmt.Sprintf(`
+	SELECT member_id
+	FROM member_preferences
+	WHERE guild_id = $1 AND reminders_opt_out
+	AND member_id IN (%s)`, genPlaceholders("%s", ", ", 2, len(memberIDs))), args...)
+	if err != nil && err != pgx.ErrNoRows {
+		return nil, errors.Wrap(err, "could not retrieve member preferences")
+	}
+	defer rs.Close()
+
+	var mid string
+	for rs.Next() {
+		if err := rs.Scan(&mid); err != nil {
+			return nil, errors.Wrap(err, "could not scan member id")
+		}
+
+		optedOut[mid] = true
+	}
+
+	return optedOut, nil
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgTemplateAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgTemplateAPI constructs a postgres-backed TemplateAPI
+func NewPgTemplateAPI(db *pgxpool.Pool, c *telemetry.Census) (TemplateAPI, error) {
+	b := pgTemplateAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgTemplateAPI) GuildTemplates(ctx context.Context, guildID string) (map[string]string, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgTemplateAPI.GuildTemplates")
+	defer span.End()
+
+	rs, err := p.db.Query(ctx, `
+	SELECT kind, template
+	FROM message_templates
+	WHERE guild_id = $1`, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve message templates")
+	}
+	defer rs.Close()
+
+	tmpls := map[string]string{}
+	for rs.Next() {
+		var kind, text string
+		if err := rs.Scan(&kind, &text); err != nil {
+			return nil, errors.Wrap(err, "could not scan message template")
+		}
+
+		tmpls[strings.TrimSpace(kind)] = text
+	}
+
+	return tmpls, errors.Wrap(rs.Err(), "could not retrieve message templates")
+}
+
+func (p *pgTemplateAPI) SetGuildTemplate(ctx context.Context, guildID, kind, text string) error {
+	ctx, span := p.census.StartSpan(ctx, "pgTemplateAPI.SetGuildTemplate")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	INSERT INTO message_templates (guild_id, kind, template)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (guild_id, kind) DO UPDATE SET template = EXCLUDED.template, updated_at = NOW()`, guildID, kind, text)
+
+	return errors.Wrap(err, "could not save message template", "kind", kind)
+}
+
+func (p *pgTemplateAPI) RemoveGuildTemplate(ctx context.Context, guildID, kind string) error {
+	ctx, span := p.census.StartSpan(ctx, "pgTemplateAPI.RemoveGuildTemplate")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	DELETE FROM message_templates
+	WHERE guild_id = $1 AND kind = $2`, guildID, kind)
+
+	return errors.Wrap(err, "could not remove message template", "kind", kind)
+}
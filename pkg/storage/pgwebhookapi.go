@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type pgWebhookAPI struct {
+	db     *pgxpool.Pool
+	census *telemetry.Census
+}
+
+// NewPgWebhookAPI constructs a postgres-backed WebhookAPI
+func NewPgWebhookAPI(db *pgxpool.Pool, c *telemetry.Census) (WebhookAPI, error) {
+	b := pgWebhookAPI{
+		db:     db,
+		census: c,
+	}
+
+	return &b, nil
+}
+
+func (p *pgWebhookAPI) AddWebhook(ctx context.Context, guildID, url, createdBy string) (int64, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgWebhookAPI.AddWebhook")
+	defer span.End()
+
+	var id int64
+
+	r := p.db.QueryRow(ctx, `
+	INSERT INTO webhooks (guild_id, url, created_by)
+	VALUES ($1, $2, $3)
+	RETURNING webhook_id`, guildID, url, createdBy)
+
+	if err := r.Scan(&id); err != nil {
+		return 0, errors.Wrap(err, "could not insert webhook")
+	}
+
+	return id, nil
+}
+
+func (p *pgWebhookAPI) ListWebhooks(ctx context.Context, guildID string) ([]Webhook, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgWebhookAPI.ListWebhooks")
+	defer span.End()
+
+	rs, err := p.db.Query(ctx, `
+	SELECT webhook_id, guild_id, url, created_by, created_at
+	FROM webhooks
+	WHERE guild_id = $1
+	ORDER BY webhook_id`, guildID)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve webhooks")
+	}
+	defer rs.Close()
+
+	var hooks []Webhook
+	for rs.Next() {
+		var h Webhook
+		if err := rs.Scan(&h.ID, &h.GuildID, &h.URL, &h.CreatedBy, &h.CreatedAt); err != nil {
+			return nil, errors.Wrap(err, "could not scan webhook")
+		}
+
+		h.GuildID = strings.TrimSpace(h.GuildID)
+		hooks = append(hooks, h)
+	}
+
+	return hooks, errors.Wrap(rs.Err(), "could not retrieve webhooks")
+}
+
+func (p *pgWebhookAPI) RemoveWebhook(ctx context.Context, guildID string, id int64) error {
+	ctx, span := p.census.StartSpan(ctx, "pgWebhookAPI.RemoveWebhook")
+	defer span.End()
+
+	res, err := p.db.Exec(ctx, `
+	DELETE FROM webhooks
+	WHERE guild_id = $1 AND webhook_id = $2`, guildID, id)
+	if err != nil {
+		return errors.Wrap(err, "could not remove webhook", "webhook_id", id)
+	}
+
+	if res.RowsAffected() == 0 {
+		return ErrWebhookNotExist
+	}
+
+	return nil
+}
+
+func (p *pgWebhookAPI) WebhookSecret(ctx context.Context, guildID string) (string, error) {
+	ctx, span := p.census.StartSpan(ctx, "pgWebhookAPI.WebhookSecret")
+	defer span.End()
+
+	var secret string
+
+	r := p.db.QueryRow(ctx, `
+	SELECT secret
+	FROM webhook_secrets
+	WHERE guild_id = $1`, guildID)
+
+	if err := r.Scan(&secret); err != nil {
+		if err == pgx.ErrNoRows {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "could not retrieve webhook secret")
+	}
+
+	return strings.TrimSpace(secret), nil
+}
+
+func (p *pgWebhookAPI) SetWebhookSecret(ctx context.Context, guildID, secret string) error {
+	ctx, span := p.census.StartSpan(ctx, "pgWebhookAPI.SetWebhookSecret")
+	defer span.End()
+
+	_, err := p.db.Exec(ctx, `
+	INSERT INTO webhook_secrets (guild_id, secret)
+	VALUES ($1, $2)
+	ON CONFLICT (guild_id) DO UPDATE SET secret = EXCLUDED.secret`, guildID, secret)
+
+	return errors.Wrap(err, "could not save webhook secret")
+}
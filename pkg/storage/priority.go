@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// ErrBadPriorityTier is the error returned for a priority tier that is not a list of discord roles
+var ErrBadPriorityTier = errors.New("priority tiers must look like @ROLE|@ROLE, @ROLE")
+
+// ParsePriorityTiers parses a comma-separated list of priority tiers, highest first, where each
+// tier is a `ROLE|ROLE` list of discord roles (as mentions or ids), returning the role ids of each tier
+func ParsePriorityTiers(s string) ([][]string, error) {
+	var tiers [][]string
+
+	for _, tierStr := range strings.Split(s, ",") {
+		tierStr = strings.TrimSpace(tierStr)
+		if tierStr == "" {
+			continue
+		}
+
+		var tier []string
+		for _, rid := range strings.Split(tierStr, "|") {
+			rid = strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(rid), "<@&"), ">")
+			if rid == "" {
+				continue
+			}
+
+			if _, err := strconv.ParseUint(rid, 10, 64); err != nil {
+				return nil, errors.WithDetails(ErrBadPriorityTier, "role", rid)
+			}
+
+			tier = append(tier, rid)
+		}
+
+		if len(tier) == 0 {
+			return nil, errors.WithDetails(ErrBadPriorityTier, "tier", tierStr)
+		}
+
+		tiers = append(tiers, tier)
+	}
+
+	return tiers, nil
+}
+
+// PriorityRole picks the discord role that puts a member in the highest priority tier they
+// qualify for, or "" if they are in none of them
+func PriorityRole(tiers [][]string, roleIDs []string) string {
+	for _, tier := range tiers {
+		for _, rid := range tier {
+			for _, memberRid := range roleIDs {
+				if memberRid == rid {
+					return rid
+				}
+			}
+		}
+	}
+
+	return ""
+}
+
+// prioritySignups orders signups by priority tier (highest first), keeping signup order within a tier;
+// signups without a priority role (or whose role is no longer in a tier) come last
+func prioritySignups(ctx context.Context, signups []TrialSignup, tiers [][]string) []TrialSignup {
+	if len(tiers) == 0 {
+		return signups
+	}
+
+	tierOf := map[string]int{}
+	for i := len(tiers) - 1; i >= 0; i-- {
+		for _, rid := range tiers[i] {
+			tierOf[rid] = i
+		}
+	}
+
+	rank := func(su TrialSignup) int {
+		if tier, ok := tierOf[su.GetPriorityRole(ctx)]; ok {
+			return tier
+		}
+		return len(tiers)
+	}
+
+	ordered := append([]TrialSignup(nil), signups...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) < rank(ordered[j])
+	})
+
+	return ordered
+}
+
+// TrialRoster is the effective roster of an event, with priority tiers, alternate roles and role
+// groups taken into account
+func TrialRoster(ctx context.Context, trial Trial) Roster {
+	tiers, _ := ParsePriorityTiers(trial.GetPriorityTiers(ctx)) // validated when it was set
+	signups := prioritySignups(ctx, trial.GetSignups(ctx), tiers)
+
+	return BuildRoster(ctx, signups, trial.GetRoleCounts(ctx), trial.GetRoleGroups(ctx))
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParsePriorityTiers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    [][]string
+		wantErr bool
+	}{
+		{name: "empty", s: "", want: nil},
+		{name: "tiers", s: "<@&1>|<@&2>, 3", want: [][]string{{"1", "2"}, {"3"}}},
+		{name: "trailing separators", s: " <@&1>| ,", want: [][]string{{"1"}}},
+		{name: "not a role", s: "@Core", wantErr: true},
+		{name: "empty tier", s: "1, |", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParsePriorityTiers(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePriorityTiers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePriorityTiers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPriorityRole(t *testing.T) {
+	t.Parallel()
+
+	tiers := [][]string{{"1", "2"}, {"3"}}
+
+	for _, tt := range []struct {
+		roleIDs []string
+		want    string
+	}{
+		{nil, ""},
+		{[]string{"9"}, ""},
+		{[]string{"3"}, "3"},
+		{[]string{"3", "2"}, "2"},
+	} {
+		if got := PriorityRole(tiers, tt.roleIDs); got != tt.want {
+			t.Errorf("PriorityRole(%v) = %q, want %q", tt.roleIDs, got, tt.want)
+		}
+	}
+}
+
+func TestBuildRoster_priority(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	tiers := [][]string{{"1"}, {"2"}}
+
+	signups := []TrialSignup{
+		testSignup{name: "a", role: "tank"},
+		testSignup{name: "b", role: "tank", priorityRole: "2"},
+		testSignup{name: "c", role: "tank", priorityRole: "1"},
+		testSignup{name: "d", role: "tank", priorityRole: "9"},
+		testSignup{name: "e", role: "tank", priorityRole: "2"},
+	}
+	roleCounts := []RoleCount{testRoleCount{role: "Tank", count: 2}}
+
+	r := BuildRoster(ctx, prioritySignups(ctx, signups, tiers), roleCounts, nil)
+
+	if got, want := rosterNames(ctx, r.Main("tank")), []string{"c", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Main() = %v, want %v", got, want)
+	}
+
+	if got, want := rosterNames(ctx, r.Overflow("tank")), []string{"e", "a", "d"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Overflow() = %v, want %v", got, want)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/gsmcwhirter/go-util/v8/errors"
 	"github.com/gsmcwhirter/go-util/v8/telemetry"
@@ -35,6 +36,12 @@ func (b *protoTrial) GetTime(ctx context.Context) string {
 	return b.protoTrial.Time
 }
 
+func (b *protoTrial) GetCategory(ctx context.Context) string {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetCategory")
+	defer span.End()
+	return b.protoTrial.Category
+}
+
 func (b *protoTrial) GetDescription(ctx context.Context) string {
 	_, span := b.census.StartSpan(ctx, "protoTrial.GetDescription")
 	defer span.End()
@@ -81,9 +88,13 @@ func (b *protoTrial) getSignups(ctx context.Context, raw bool) []TrialSignup {
 		}
 
 		s = append(s, &protoTrialSignup{
-			name:   name,
-			role:   ps.Role,
-			census: b.census,
+			name:         name,
+			role:         ps.Role,
+			signedUpAt:   unixOrZero(ps.SignedUpAt),
+			note:         ps.Note,
+			alternates:   ps.Alternates,
+			priorityRole: ps.PriorityRole,
+			census:       b.census,
 		})
 	}
 
@@ -125,11 +136,14 @@ func (b *protoTrial) GetRoleCounts(ctx context.Context) []RoleCount {
 
 		r := b.protoTrial.RoleCountMap[rName]
 		s = append(s, &protoRoleCount{
-			role:   r.Name,
-			count:  r.Count,
-			emoji:  r.Emoji,
-			census: b.census,
-			index:  idx,
+			role:          r.Name,
+			count:         r.Count,
+			emoji:         r.Emoji,
+			requiredRoles: r.RequiredRoles,
+			aliases:       r.Aliases,
+			group:         r.Group,
+			census:        b.census,
+			index:         idx,
 		})
 	}
 
@@ -138,6 +152,29 @@ func (b *protoTrial) GetRoleCounts(ctx context.Context) []RoleCount {
 	return []RoleCount(s)
 }
 
+func (b *protoTrial) GetRoleGroups(ctx context.Context) []RoleGroup {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetRoleGroups")
+	defer span.End()
+
+	names := make([]string, 0, len(b.protoTrial.RoleGroups))
+	for name := range b.protoTrial.RoleGroups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	groups := make([]RoleGroup, 0, len(names))
+	for _, name := range names {
+		g := b.protoTrial.RoleGroups[name]
+		groups = append(groups, &protoRoleGroup{
+			name:   g.Name,
+			count:  g.Count,
+			census: b.census,
+		})
+	}
+
+	return groups
+}
+
 func (b *protoTrial) GetRoleOrder(ctx context.Context) []string {
 	_, span := b.census.StartSpan(ctx, "protoTrial.GetRoleOrder")
 	defer span.End()
@@ -159,6 +196,100 @@ func (b *protoTrial) HideReactionsShow(ctx context.Context) bool {
 	return b.protoTrial.HideReactionsShow
 }
 
+func unixOrZero(ts int64) time.Time {
+	if ts == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(ts, 0).UTC()
+}
+
+func zeroOrUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+func (b *protoTrial) GetSignupsOpenAt(ctx context.Context) time.Time {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetSignupsOpenAt")
+	defer span.End()
+
+	return unixOrZero(b.protoTrial.SignupsOpenAt)
+}
+
+func (b *protoTrial) GetSignupsCloseAt(ctx context.Context) time.Time {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetSignupsCloseAt")
+	defer span.End()
+
+	return unixOrZero(b.protoTrial.SignupsCloseAt)
+}
+
+func (b *protoTrial) GetCloseHoursBefore(ctx context.Context) uint64 {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetCloseHoursBefore")
+	defer span.End()
+
+	return b.protoTrial.CloseHoursBefore
+}
+
+func (b *protoTrial) AnnounceStateChanges(ctx context.Context) bool {
+	_, span := b.census.StartSpan(ctx, "protoTrial.AnnounceStateChanges")
+	defer span.End()
+
+	return b.protoTrial.AnnounceStateChanges
+}
+
+func (b *protoTrial) GetReminderOffsets(ctx context.Context) string {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetReminderOffsets")
+	defer span.End()
+
+	return b.protoTrial.ReminderOffsets
+}
+
+func (b *protoTrial) GetLeader(ctx context.Context) string {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetLeader")
+	defer span.End()
+
+	return b.protoTrial.Leader
+}
+
+// GetTemplate returns the event's own message template of a kind, or "" if it has none
+func (b *protoTrial) GetPriorityTiers(ctx context.Context) string {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetPriorityTiers")
+	defer span.End()
+
+	return b.protoTrial.PriorityTiers
+}
+
+func (b *protoTrial) GetPriorityHoursBefore(ctx context.Context) uint64 {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetPriorityHoursBefore")
+	defer span.End()
+
+	return b.protoTrial.PriorityHoursBefore
+}
+
+func (b *protoTrial) GetTemplate(ctx context.Context, kind string) string {
+	_, span := b.census.StartSpan(ctx, "protoTrial.GetTemplate")
+	defer span.End()
+
+	return b.protoTrial.Templates[kind]
+}
+
+// TemplateKinds lists the kinds of message the event has its own templates for
+func (b *protoTrial) TemplateKinds(ctx context.Context) []string {
+	_, span := b.census.StartSpan(ctx, "protoTrial.TemplateKinds")
+	defer span.End()
+
+	kinds := make([]string, 0, len(b.protoTrial.Templates))
+	for k := range b.protoTrial.Templates {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
+
 func (b *protoTrial) PrettyRoleOrder(ctx context.Context) string {
 	ctx, span := b.census.StartSpan(ctx, "protoTrial.PrettyRoleOrder")
 	defer span.End()
@@ -175,12 +306,39 @@ func (b *protoTrial) PrettyRoles(ctx context.Context, indent string) string {
 	lines := make([]string, 0, len(rcs))
 
 	for _, rc := range rcs {
-		lines = append(lines, fmt.Sprintf("%s%s: %d", rc.GetEmoji(ctx), rc.GetRole(ctx), rc.GetCount(ctx)))
+		line := fmt.Sprintf("%s%s: %s", rc.GetEmoji(ctx), rc.GetRole(ctx), RoleCountString(ctx, rc))
+		if group := rc.GetGroup(ctx); group != "" {
+			line += fmt.Sprintf(" (group: %s)", group)
+		}
+		if reqs := rc.GetRequiredRoles(ctx); len(reqs) > 0 {
+			mentions := make([]string, 0, len(reqs))
+			for _, rid := range reqs {
+				mentions = append(mentions, fmt.Sprintf("<@&%s>", rid))
+			}
+			line += fmt.Sprintf(" (requires: %s)", strings.Join(mentions, " or "))
+		}
+		if aliases := rc.GetAliases(ctx); len(aliases) > 0 {
+			line += fmt.Sprintf(" (aliases: %s)", strings.Join(aliases, ", "))
+		}
+		lines = append(lines, line)
 	}
 
 	return strings.Join(lines, "\n"+indent)
 }
 
+func (b *protoTrial) PrettyRoleGroups(ctx context.Context) string {
+	ctx, span := b.census.StartSpan(ctx, "protoTrial.PrettyRoleGroups")
+	defer span.End()
+
+	groups := b.GetRoleGroups(ctx)
+	parts := make([]string, 0, len(groups))
+	for _, g := range groups {
+		parts = append(parts, fmt.Sprintf("%s: %d", g.GetName(ctx), g.GetCount(ctx)))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
 func (b *protoTrial) PrettySettings(ctx context.Context) string {
 	ctx, span := b.census.StartSpan(ctx, "protoTrial.PrettySettings")
 	defer span.End()
@@ -190,12 +348,23 @@ Event settings:
 %[1]s
 	- State: '%[5]s',
 	- Time: '%[11]s',
+	- Category: '%[12]s',
 	- AnnounceChannel: '#%[2]s',
 	- SignupChannel: '#%[3]s',
 	- AnnounceTo: '%[4]s', 
 	- HideReactionsAnnounce: %[9]v,
 	- HideReactionsShow: %[10]v,
+	- SignupsOpenAt: '%[13]s',
+	- SignupsCloseAt: '%[14]s',
+	- CloseHoursBefore: %[15]d,
+	- AnnounceStateChanges: %[16]v,
+	- ReminderOffsets: '%[17]s',
+	- Leader: '%[18]s',
+	- PriorityTiers: '%[21]s',
+	- PriorityHoursBefore: %[22]d,
+	- Templates: '%[19]s',
 	- RoleOrder: '%[8]s',
+	- RoleGroups: '%[20]s',
 	- Roles:
 		%[6]s
 %[1]s
@@ -204,7 +373,7 @@ Description:
 %[1]s
 %[7]s
 
-%[1]s`, "", b.GetAnnounceChannel(ctx), b.GetSignupChannel(ctx), b.GetAnnounceTo(ctx), b.GetState(ctx), b.PrettyRoles(ctx, "		"), b.GetDescription(ctx), b.PrettyRoleOrder(ctx), b.HideReactionsAnnounce(ctx), b.HideReactionsShow(ctx), b.GetTime(ctx))
+%[1]s`, "", b.GetAnnounceChannel(ctx), b.GetSignupChannel(ctx), b.GetAnnounceTo(ctx), b.GetState(ctx), b.PrettyRoles(ctx, "		"), b.GetDescription(ctx), b.PrettyRoleOrder(ctx), b.HideReactionsAnnounce(ctx), b.HideReactionsShow(ctx), b.GetTime(ctx), b.GetCategory(ctx), FormatEventTime(b.GetSignupsOpenAt(ctx)), FormatEventTime(b.GetSignupsCloseAt(ctx)), b.GetCloseHoursBefore(ctx), b.AnnounceStateChanges(ctx), b.GetReminderOffsets(ctx), b.GetLeader(ctx), strings.Join(b.TemplateKinds(ctx), ", "), b.PrettyRoleGroups(ctx), b.GetPriorityTiers(ctx), b.GetPriorityHoursBefore(ctx))
 }
 
 func (b *protoTrial) SetName(ctx context.Context, name string) {
@@ -219,6 +388,12 @@ func (b *protoTrial) SetTime(ctx context.Context, t string) {
 	b.protoTrial.Time = t
 }
 
+func (b *protoTrial) SetCategory(ctx context.Context, cat string) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetCategory")
+	defer span.End()
+	b.protoTrial.Category = cat
+}
+
 func (b *protoTrial) SetDescription(ctx context.Context, d string) {
 	_, span := b.census.StartSpan(ctx, "protoTrial.SetDescription")
 	defer span.End()
@@ -273,12 +448,49 @@ func (b *protoTrial) AddSignup(ctx context.Context, name, role string) {
 	}
 
 	b.protoTrial.Signups = append(b.protoTrial.Signups, &ProtoTrialSignup{
-		Name:  name,
-		Role:  role,
-		State: signupOk,
+		Name:       name,
+		Role:       role,
+		State:      signupOk,
+		SignedUpAt: time.Now().Unix(),
 	})
 }
 
+func (b *protoTrial) SetSignupNote(ctx context.Context, name, note string) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetSignupNote")
+	defer span.End()
+
+	for i := 0; i < len(b.protoTrial.Signups); i++ {
+		if b.protoTrial.Signups[i].State != signupCanceled && isSameUser(b.protoTrial.Signups[i].Name, name) {
+			b.protoTrial.Signups[i].Note = note
+		}
+	}
+}
+
+// SetSignupAlternates records the roles, in order of preference, that the user would also take
+// if the role they signed up for is full
+func (b *protoTrial) SetSignupAlternates(ctx context.Context, name string, roles []string) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetSignupAlternates")
+	defer span.End()
+
+	for i := 0; i < len(b.protoTrial.Signups); i++ {
+		if b.protoTrial.Signups[i].State != signupCanceled && isSameUser(b.protoTrial.Signups[i].Name, name) {
+			b.protoTrial.Signups[i].Alternates = roles
+		}
+	}
+}
+
+// SetSignupPriorityRole records the discord role that put the user's signup in a priority tier
+func (b *protoTrial) SetSignupPriorityRole(ctx context.Context, name, roleID string) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetSignupPriorityRole")
+	defer span.End()
+
+	for i := 0; i < len(b.protoTrial.Signups); i++ {
+		if b.protoTrial.Signups[i].State != signupCanceled && isSameUser(b.protoTrial.Signups[i].Name, name) {
+			b.protoTrial.Signups[i].PriorityRole = roleID
+		}
+	}
+}
+
 func (b *protoTrial) RemoveSignup(ctx context.Context, name string) {
 	_, span := b.census.StartSpan(ctx, "protoTrial.RemoveSignup")
 	defer span.End()
@@ -320,6 +532,57 @@ func (b *protoTrial) SetRoleCount(ctx context.Context, name, emoji string, ct ui
 	b.protoTrial.RoleCountMap[lowerName] = prc
 }
 
+func (b *protoTrial) SetRoleRequirements(ctx context.Context, name string, roleIDs []string) {
+	ctx, span := b.census.StartSpan(ctx, "protoTrial.SetRoleRequirements")
+	defer span.End()
+
+	b.migrateRoleCounts(ctx)
+
+	prc, ok := b.protoTrial.RoleCountMap[strings.ToLower(name)]
+	if !ok {
+		return
+	}
+
+	prc.RequiredRoles = unique(roleIDs)
+}
+
+// SetRoleAliases sets the other names a role can be signed up for by
+func (b *protoTrial) SetRoleAliases(ctx context.Context, name string, aliases []string) {
+	ctx, span := b.census.StartSpan(ctx, "protoTrial.SetRoleAliases")
+	defer span.End()
+
+	b.migrateRoleCounts(ctx)
+
+	prc, ok := b.protoTrial.RoleCountMap[strings.ToLower(name)]
+	if !ok {
+		return
+	}
+
+	lowerAliases := make([]string, 0, len(aliases))
+	for _, a := range aliases {
+		if a = strings.ToLower(strings.TrimSpace(a)); a != "" {
+			lowerAliases = append(lowerAliases, a)
+		}
+	}
+
+	prc.Aliases = unique(lowerAliases)
+}
+
+// SetRoleParentGroup puts a role in a role group, or takes it out of its group if group is empty
+func (b *protoTrial) SetRoleParentGroup(ctx context.Context, name, group string) {
+	ctx, span := b.census.StartSpan(ctx, "protoTrial.SetRoleParentGroup")
+	defer span.End()
+
+	b.migrateRoleCounts(ctx)
+
+	prc, ok := b.protoTrial.RoleCountMap[strings.ToLower(name)]
+	if !ok {
+		return
+	}
+
+	prc.Group = strings.TrimSpace(group)
+}
+
 func (b *protoTrial) RemoveRole(ctx context.Context, name string) {
 	ctx, span := b.census.StartSpan(ctx, "protoTrial.RemoveRole")
 	defer span.End()
@@ -338,6 +601,33 @@ func (b *protoTrial) RemoveRole(ctx context.Context, name string) {
 	delete(b.protoTrial.RoleCountMap, lowerName)
 }
 
+func (b *protoTrial) SetRoleGroup(ctx context.Context, name string, ct uint64) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetRoleGroup")
+	defer span.End()
+
+	if b.protoTrial.RoleGroups == nil {
+		b.protoTrial.RoleGroups = map[string]*ProtoRoleGroup{}
+	}
+
+	lowerName := strings.ToLower(name)
+	prg, ok := b.protoTrial.RoleGroups[lowerName]
+	if !ok {
+		prg = new(ProtoRoleGroup)
+	}
+
+	prg.Name = name
+	prg.Count = ct
+
+	b.protoTrial.RoleGroups[lowerName] = prg
+}
+
+func (b *protoTrial) RemoveRoleGroup(ctx context.Context, name string) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.RemoveRoleGroup")
+	defer span.End()
+
+	delete(b.protoTrial.RoleGroups, strings.ToLower(name))
+}
+
 func (b *protoTrial) SetRoleOrder(ctx context.Context, ord []string) {
 	_, span := b.census.StartSpan(ctx, "protoTrial.SetRoleOrder")
 	defer span.End()
@@ -376,6 +666,97 @@ func (b *protoTrial) SetHideReactionsShow(ctx context.Context, val string) error
 	return nil
 }
 
+func (b *protoTrial) SetSignupsOpenAt(ctx context.Context, t time.Time) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetSignupsOpenAt")
+	defer span.End()
+
+	b.protoTrial.SignupsOpenAt = zeroOrUnix(t)
+}
+
+func (b *protoTrial) SetSignupsCloseAt(ctx context.Context, t time.Time) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetSignupsCloseAt")
+	defer span.End()
+
+	b.protoTrial.SignupsCloseAt = zeroOrUnix(t)
+}
+
+func (b *protoTrial) SetCloseHoursBefore(ctx context.Context, hours uint64) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetCloseHoursBefore")
+	defer span.End()
+
+	b.protoTrial.CloseHoursBefore = hours
+}
+
+func (b *protoTrial) SetAnnounceStateChanges(ctx context.Context, val string) error {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetAnnounceStateChanges")
+	defer span.End()
+
+	var err error
+	val, err = normalizeTrueFalseString(val)
+	if err != nil {
+		return errors.Wrap(err, "could not normalize true/false value", "val", val)
+	}
+
+	b.protoTrial.AnnounceStateChanges = val == "true"
+	return nil
+}
+
+func (b *protoTrial) SetReminderOffsets(ctx context.Context, val string) error {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetReminderOffsets")
+	defer span.End()
+
+	val = strings.TrimSpace(val)
+	if _, err := ParseReminderOffsets(val); err != nil {
+		return err
+	}
+
+	b.protoTrial.ReminderOffsets = val
+	return nil
+}
+
+func (b *protoTrial) SetLeader(ctx context.Context, val string) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetLeader")
+	defer span.End()
+
+	b.protoTrial.Leader = strings.TrimSpace(val)
+}
+
+// SetTemplate sets the event's own message template of a kind; an empty template removes it
+func (b *protoTrial) SetPriorityTiers(ctx context.Context, val string) error {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetPriorityTiers")
+	defer span.End()
+
+	val = strings.TrimSpace(val)
+	if _, err := ParsePriorityTiers(val); err != nil {
+		return err
+	}
+
+	b.protoTrial.PriorityTiers = val
+	return nil
+}
+
+func (b *protoTrial) SetPriorityHoursBefore(ctx context.Context, hours uint64) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetPriorityHoursBefore")
+	defer span.End()
+
+	b.protoTrial.PriorityHoursBefore = hours
+}
+
+func (b *protoTrial) SetTemplate(ctx context.Context, kind, text string) {
+	_, span := b.census.StartSpan(ctx, "protoTrial.SetTemplate")
+	defer span.End()
+
+	if text == "" {
+		delete(b.protoTrial.Templates, kind)
+		return
+	}
+
+	if b.protoTrial.Templates == nil {
+		b.protoTrial.Templates = map[string]string{}
+	}
+	b.protoTrial.Templates[kind] = text
+}
+
 func (b *protoTrial) Serialize(ctx context.Context) (out []byte, err error) {
 	_, span := b.census.StartSpan(ctx, "protoTrial.Serialize")
 	defer span.End()
@@ -403,9 +784,13 @@ func (b *protoTrial) migrateRoleCounts(ctx context.Context) {
 }
 
 type protoTrialSignup struct {
-	name   string
-	role   string
-	census *telemetry.Census
+	name         string
+	role         string
+	signedUpAt   time.Time
+	note         string
+	alternates   []string
+	priorityRole string
+	census       *telemetry.Census
 }
 
 var _ TrialSignup = (*protoTrialSignup)(nil)
@@ -424,6 +809,36 @@ func (b *protoTrialSignup) GetRole(ctx context.Context) string {
 	return b.role
 }
 
+// GetSignedUpAt is zero for signups made before signup times were recorded
+func (b *protoTrialSignup) GetSignedUpAt(ctx context.Context) time.Time {
+	_, span := b.census.StartSpan(ctx, "protoTrialSignup.GetSignedUpAt")
+	defer span.End()
+
+	return b.signedUpAt
+}
+
+func (b *protoTrialSignup) GetNote(ctx context.Context) string {
+	_, span := b.census.StartSpan(ctx, "protoTrialSignup.GetNote")
+	defer span.End()
+
+	return b.note
+}
+
+// GetAlternates is the other roles the user would take, in order of preference
+func (b *protoTrialSignup) GetAlternates(ctx context.Context) []string {
+	_, span := b.census.StartSpan(ctx, "protoTrialSignup.GetAlternates")
+	defer span.End()
+
+	return b.alternates
+}
+
+func (b *protoTrialSignup) GetPriorityRole(ctx context.Context) string {
+	_, span := b.census.StartSpan(ctx, "protoTrialSignup.GetPriorityRole")
+	defer span.End()
+
+	return b.priorityRole
+}
+
 type RoleCountSlice []RoleCount
 
 func (s RoleCountSlice) Len() int {
@@ -439,11 +854,14 @@ func (s RoleCountSlice) Swap(i, j int) {
 }
 
 type protoRoleCount struct {
-	role   string
-	count  uint64
-	emoji  string
-	census *telemetry.Census
-	index  int
+	role          string
+	count         uint64
+	emoji         string
+	requiredRoles []string
+	aliases       []string
+	group         string
+	census        *telemetry.Census
+	index         int
 }
 
 var _ RoleCount = (*protoRoleCount)(nil)
@@ -469,6 +887,49 @@ func (b *protoRoleCount) GetEmoji(ctx context.Context) string {
 	return b.emoji
 }
 
+func (b *protoRoleCount) GetRequiredRoles(ctx context.Context) []string {
+	_, span := b.census.StartSpan(ctx, "protoRoleCount.GetRequiredRoles")
+	defer span.End()
+
+	return b.requiredRoles
+}
+
+func (b *protoRoleCount) GetAliases(ctx context.Context) []string {
+	_, span := b.census.StartSpan(ctx, "protoRoleCount.GetAliases")
+	defer span.End()
+
+	return b.aliases
+}
+
+func (b *protoRoleCount) GetGroup(ctx context.Context) string {
+	_, span := b.census.StartSpan(ctx, "protoRoleCount.GetGroup")
+	defer span.End()
+
+	return b.group
+}
+
 func (b *protoRoleCount) Index() int {
 	return b.index
 }
+
+type protoRoleGroup struct {
+	name   string
+	count  uint64
+	census *telemetry.Census
+}
+
+var _ RoleGroup = (*protoRoleGroup)(nil)
+
+func (b *protoRoleGroup) GetName(ctx context.Context) string {
+	_, span := b.census.StartSpan(ctx, "protoRoleGroup.GetName")
+	defer span.End()
+
+	return b.name
+}
+
+func (b *protoRoleGroup) GetCount(ctx context.Context) uint64 {
+	_, span := b.census.StartSpan(ctx, "protoRoleGroup.GetCount")
+	defer span.End()
+
+	return b.count
+}
@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"strings"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// ErrBadRoleAlias is the error returned for a role alias that is not of the form ALIAS=ROLE
+var ErrBadRoleAlias = errors.New("role aliases must look like ALIAS=ROLE")
+
+// ParseRoleAliases parses a comma-separated list of ALIAS=ROLE pairs (e.g., "dd=dps, damage=dps"),
+// returning a map from the lower-cased alias to the lower-cased role
+func ParseRoleAliases(s string) (map[string]string, error) {
+	aliases := map[string]string{}
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		pair := strings.SplitN(part, "=", 2)
+		if len(pair) != 2 {
+			return nil, errors.WithDetails(ErrBadRoleAlias, "alias", part)
+		}
+
+		alias := strings.ToLower(strings.TrimSpace(pair[0]))
+		role := strings.ToLower(strings.TrimSpace(pair[1]))
+		if alias == "" || role == "" {
+			return nil, errors.WithDetails(ErrBadRoleAlias, "alias", part)
+		}
+
+		aliases[alias] = role
+	}
+
+	return aliases, nil
+}
@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRoleAliases(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseRoleAliases(" DD=dps, damage = DPS,,heals=healer ")
+	if err != nil {
+		t.Fatalf("ParseRoleAliases() error = %v", err)
+	}
+
+	want := map[string]string{"dd": "dps", "damage": "dps", "heals": "healer"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseRoleAliases() = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"dd", "=dps", "dd="} {
+		if _, err := ParseRoleAliases(bad); err == nil {
+			t.Errorf("ParseRoleAliases(%q) accepted a bad alias", bad)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// RoleGroupsByName maps the lower-cased names of role groups to the groups
+func RoleGroupsByName(ctx context.Context, groups []RoleGroup) map[string]RoleGroup {
+	byName := make(map[string]RoleGroup, len(groups))
+	for _, g := range groups {
+		byName[strings.ToLower(g.GetName(ctx))] = g
+	}
+
+	return byName
+}
+
+// RoleCapacity is the most signups a role can hold on its own. A role in a group may have a
+// count of 0, meaning only the group's count limits it. Roles in a group are also limited by
+// the group's count as a whole (see BuildRoster).
+func RoleCapacity(ctx context.Context, rc RoleCount, groups map[string]RoleGroup) uint64 {
+	ct := rc.GetCount(ctx)
+
+	g, ok := groups[strings.ToLower(rc.GetGroup(ctx))]
+	if !ok {
+		return ct
+	}
+
+	if ct == 0 || ct > g.GetCount(ctx) {
+		return g.GetCount(ctx)
+	}
+
+	return ct
+}
+
+// RoleCountString is the count of a role for display, with "*" for a role limited only by its group
+func RoleCountString(ctx context.Context, rc RoleCount) string {
+	if rc.GetCount(ctx) == 0 && rc.GetGroup(ctx) != "" {
+		return "*"
+	}
+
+	return strconv.FormatUint(rc.GetCount(ctx), 10)
+}
+
+// TotalSlots is the number of signups the main roster of an event can hold
+func TotalSlots(ctx context.Context, roleCounts []RoleCount, groups []RoleGroup) uint64 {
+	byName := RoleGroupsByName(ctx, groups)
+	groupSlots := make(map[string]uint64, len(byName))
+
+	var total uint64
+	for _, rc := range roleCounts {
+		ct := RoleCapacity(ctx, rc, byName)
+
+		group := strings.ToLower(rc.GetGroup(ctx))
+		if _, ok := byName[group]; !ok {
+			total += ct
+			continue
+		}
+
+		groupSlots[group] += ct
+	}
+
+	for group, ct := range groupSlots {
+		if gct := byName[group].GetCount(ctx); ct > gct {
+			ct = gct
+		}
+		total += ct
+	}
+
+	return total
+}
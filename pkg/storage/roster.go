@@ -0,0 +1,278 @@
+package storage
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Roster is the effective roster of an event: which signups fill the slots of each role, and
+// which are overflow. Signups that offered alternate roles may be placed in one of those.
+type Roster struct {
+	main     map[string][]TrialSignup
+	overflow map[string][]TrialSignup
+	assigned map[string]string
+}
+
+// Main is the signups filling the slots of a role, in signup order
+func (r Roster) Main(role string) []TrialSignup {
+	return r.main[strings.ToLower(role)]
+}
+
+// Overflow is the signups waiting for a slot, listed under the role they signed up for
+func (r Roster) Overflow(role string) []TrialSignup {
+	return r.overflow[strings.ToLower(role)]
+}
+
+// AssignedRole is the role the named signup fills, or "" if they are overflow (or not signed up)
+func (r Roster) AssignedRole(name string) string {
+	return r.assigned[name]
+}
+
+// rosterSolver assigns signups (by index, in signup order) to role slots
+type rosterSolver struct {
+	caps     map[string]int
+	prefs    [][]string
+	assigned map[string][]int
+	roleOf   []string
+
+	groupOf   map[string]string
+	groupCaps map[string]int
+	members   map[string][]string
+}
+
+// BuildRoster works out the effective roster of an event. Signups are considered in the order
+// given (signup order, or priority order as in TrialRoster), and each one is placed in the first
+// role of their preferences with a free slot. When none is free, earlier signups that offered
+// alternates are moved to make room, as long as everyone already placed keeps a slot. So nobody
+// loses a slot to a later signup, and as many slots are filled as possible. Roles in a role group
+// also share the group's count.
+func BuildRoster(ctx context.Context, signups []TrialSignup, roleCounts []RoleCount, groups []RoleGroup) Roster {
+	s := rosterSolver{
+		caps:      make(map[string]int, len(roleCounts)),
+		prefs:     make([][]string, len(signups)),
+		assigned:  make(map[string][]int, len(roleCounts)),
+		roleOf:    make([]string, len(signups)),
+		groupOf:   make(map[string]string, len(roleCounts)),
+		groupCaps: make(map[string]int, len(groups)),
+		members:   make(map[string][]string, len(groups)),
+	}
+
+	byName := RoleGroupsByName(ctx, groups)
+	for name, g := range byName {
+		s.groupCaps[name] = int(g.GetCount(ctx))
+	}
+
+	names := make(map[string]string, len(roleCounts))
+	for _, rc := range roleCounts {
+		role := strings.ToLower(rc.GetRole(ctx))
+		s.caps[role] = int(RoleCapacity(ctx, rc, byName))
+		names[role] = rc.GetRole(ctx)
+
+		if group := strings.ToLower(rc.GetGroup(ctx)); byName[group] != nil {
+			s.groupOf[role] = group
+			s.members[group] = append(s.members[group], role)
+		}
+	}
+
+	for i, su := range signups {
+		s.prefs[i] = signupPreferences(ctx, su, s.caps)
+		s.place(i)
+	}
+
+	r := Roster{
+		main:     make(map[string][]TrialSignup, len(roleCounts)),
+		overflow: make(map[string][]TrialSignup, len(roleCounts)),
+		assigned: make(map[string]string, len(signups)),
+	}
+
+	for i, su := range signups {
+		switch {
+		case s.roleOf[i] != "":
+			r.main[s.roleOf[i]] = append(r.main[s.roleOf[i]], su)
+			r.assigned[su.GetName(ctx)] = names[s.roleOf[i]]
+		case len(s.prefs[i]) > 0:
+			r.overflow[s.prefs[i][0]] = append(r.overflow[s.prefs[i][0]], su)
+		}
+	}
+
+	return r
+}
+
+// signupPreferences is the role signed up for followed by the alternates, lower-cased and
+// without duplicates or roles the event does not have
+func signupPreferences(ctx context.Context, su TrialSignup, caps map[string]int) []string {
+	roles := append([]string{su.GetRole(ctx)}, su.GetAlternates(ctx)...)
+
+	prefs := make([]string, 0, len(roles))
+	seen := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		role = strings.ToLower(role)
+		if _, ok := caps[role]; !ok || seen[role] {
+			continue
+		}
+
+		seen[role] = true
+		prefs = append(prefs, role)
+	}
+
+	return prefs
+}
+
+// rosterMove is one step of a chain of moves: a signup taking a slot in a role
+type rosterMove struct {
+	signup int
+	role   string
+}
+
+// rosterVisit tracks the signups, roles and groups already on the current search, so that each
+// is looked at once
+type rosterVisit struct {
+	signups map[int]bool
+	roles   map[string]bool
+	groups  map[string]bool
+}
+
+// place tries to give signup i a slot. This is a search for an augmenting path in a flow network
+// of signups -> roles -> role groups -> slots: signups may move to another of their preferences
+// to make room, a role with room may pass the need on to its group, and a full group may have
+// someone leave one of its roles. Either a chain of moves is found and applied, or nothing changes.
+func (s *rosterSolver) place(i int) bool {
+	v := rosterVisit{
+		signups: map[int]bool{},
+		roles:   map[string]bool{},
+		groups:  map[string]bool{},
+	}
+
+	moves, ok := s.fromSignup(i, v)
+	if !ok {
+		return false
+	}
+
+	for _, m := range moves {
+		if s.roleOf[m.signup] != "" {
+			s.unassign(m.signup)
+		}
+	}
+
+	for _, m := range moves {
+		s.assign(m.signup, m.role)
+	}
+
+	return true
+}
+
+// fromSignup finds moves to give signup j a slot in one of their preferences (other than the role
+// they hold now): first a role with a free slot, and then a role that can be made room in
+func (s *rosterSolver) fromSignup(j int, v rosterVisit) ([]rosterMove, bool) {
+	v.signups[j] = true
+
+	for _, role := range s.prefs[j] {
+		if role != s.roleOf[j] && !v.roles[role] && s.free(role, v) {
+			return []rosterMove{{signup: j, role: role}}, true
+		}
+	}
+
+	for _, role := range s.prefs[j] {
+		if role == s.roleOf[j] || v.roles[role] {
+			continue
+		}
+
+		if moves, ok := s.fromRole(role, v); ok {
+			return append([]rosterMove{{signup: j, role: role}}, moves...), true
+		}
+	}
+
+	return nil, false
+}
+
+// fromRole finds moves to make room for one more signup in a role: through the role's own free
+// slots (and its group's), or by moving someone out of it (most recent signup first)
+func (s *rosterSolver) fromRole(role string, v rosterVisit) ([]rosterMove, bool) {
+	v.roles[role] = true
+
+	if len(s.assigned[role]) < s.caps[role] {
+		group, grouped := s.groupOf[role]
+		if !grouped {
+			return nil, true
+		}
+
+		if !v.groups[group] {
+			if moves, ok := s.fromGroup(group, v); ok {
+				return moves, true
+			}
+		}
+	}
+
+	occupants := append([]int(nil), s.assigned[role]...)
+	sort.Sort(sort.Reverse(sort.IntSlice(occupants)))
+
+	for _, j := range occupants {
+		if v.signups[j] {
+			continue
+		}
+
+		if moves, ok := s.fromSignup(j, v); ok {
+			return moves, true
+		}
+	}
+
+	return nil, false
+}
+
+// fromGroup finds moves to free a slot in a role group: one of its own, or by moving someone out
+// of one of its roles (and so out of the group, since the group is already on the search)
+func (s *rosterSolver) fromGroup(group string, v rosterVisit) ([]rosterMove, bool) {
+	v.groups[group] = true
+
+	if s.groupSize(group) < s.groupCaps[group] {
+		return nil, true
+	}
+
+	for _, role := range s.members[group] {
+		if v.roles[role] || len(s.assigned[role]) == 0 {
+			continue
+		}
+
+		if moves, ok := s.fromRole(role, v); ok {
+			return moves, true
+		}
+	}
+
+	return nil, false
+}
+
+// free reports whether a role, and its group if it has one, have an open slot
+func (s *rosterSolver) free(role string, v rosterVisit) bool {
+	if len(s.assigned[role]) >= s.caps[role] {
+		return false
+	}
+
+	group, ok := s.groupOf[role]
+	return !ok || (!v.groups[group] && s.groupSize(group) < s.groupCaps[group])
+}
+
+func (s *rosterSolver) groupSize(group string) int {
+	size := 0
+	for _, role := range s.members[group] {
+		size += len(s.assigned[role])
+	}
+	return size
+}
+
+func (s *rosterSolver) assign(i int, role string) {
+	s.assigned[role] = append(s.assigned[role], i)
+	s.roleOf[i] = role
+}
+
+func (s *rosterSolver) unassign(i int) {
+	role := s.roleOf[i]
+	occupants := s.assigned[role]
+	for k, j := range occupants {
+		if j == i {
+			s.assigned[role] = append(occupants[:k:k], occupants[k+1:]...)
+			break
+		}
+	}
+	s.roleOf[i] = ""
+}
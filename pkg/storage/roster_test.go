@@ -0,0 +1,359 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testSignup struct {
+	name         string
+	role         string
+	alternates   []string
+	priorityRole string
+}
+
+func (s testSignup) GetName(context.Context) string          { return s.name }
+func (s testSignup) GetRole(context.Context) string          { return s.role }
+func (s testSignup) GetSignedUpAt(context.Context) time.Time { return time.Time{} }
+func (s testSignup) GetNote(context.Context) string          { return "" }
+func (s testSignup) GetAlternates(context.Context) []string  { return s.alternates }
+func (s testSignup) GetPriorityRole(context.Context) string  { return s.priorityRole }
+
+type testRoleCount struct {
+	role  string
+	count uint64
+	group string
+}
+
+func (rc testRoleCount) GetRole(context.Context) string            { return rc.role }
+func (rc testRoleCount) GetCount(context.Context) uint64           { return rc.count }
+func (rc testRoleCount) GetEmoji(context.Context) string           { return "" }
+func (rc testRoleCount) GetRequiredRoles(context.Context) []string { return nil }
+func (rc testRoleCount) GetAliases(context.Context) []string       { return nil }
+func (rc testRoleCount) GetGroup(context.Context) string           { return rc.group }
+func (rc testRoleCount) Index() int                                { return 0 }
+
+type testRoleGroup struct {
+	name  string
+	count uint64
+}
+
+func (g testRoleGroup) GetName(context.Context) string  { return g.name }
+func (g testRoleGroup) GetCount(context.Context) uint64 { return g.count }
+
+// su parses "name:role>alt>alt"
+func su(spec string) TrialSignup {
+	parts := strings.SplitN(spec, ":", 2)
+	roles := strings.Split(parts[1], ">")
+	return testSignup{name: parts[0], role: roles[0], alternates: roles[1:]}
+}
+
+func rosterNames(ctx context.Context, signups []TrialSignup) []string {
+	names := make([]string, 0, len(signups))
+	for _, s := range signups {
+		names = append(names, s.GetName(ctx))
+	}
+	return names
+}
+
+func TestBuildRoster(t *testing.T) {
+	t.Parallel()
+
+	roleCounts := []RoleCount{
+		testRoleCount{role: "DPS", count: 2},
+		testRoleCount{role: "Healer", count: 1},
+		testRoleCount{role: "Tank", count: 1},
+	}
+
+	tests := []struct {
+		name         string
+		signups      []string
+		wantMain     map[string][]string
+		wantOverflow map[string][]string
+	}{
+		{
+			name:         "no alternates",
+			signups:      []string{"a:dps", "b:dps", "c:dps", "d:tank"},
+			wantMain:     map[string][]string{"DPS": {"a", "b"}, "Healer": {}, "Tank": {"d"}},
+			wantOverflow: map[string][]string{"DPS": {"c"}, "Healer": {}, "Tank": {}},
+		},
+		{
+			name:         "alternate when full",
+			signups:      []string{"a:tank", "b:tank>healer"},
+			wantMain:     map[string][]string{"DPS": {}, "Healer": {"b"}, "Tank": {"a"}},
+			wantOverflow: map[string][]string{"DPS": {}, "Healer": {}, "Tank": {}},
+		},
+		{
+			name:         "earlier flex signup moves over",
+			signups:      []string{"a:healer>tank", "b:healer"},
+			wantMain:     map[string][]string{"DPS": {}, "Healer": {"b"}, "Tank": {"a"}},
+			wantOverflow: map[string][]string{"DPS": {}, "Healer": {}, "Tank": {}},
+		},
+		{
+			name:         "chain of moves",
+			signups:      []string{"a:healer>tank", "b:tank>dps", "c:healer", "d:dps", "e:dps"},
+			wantMain:     map[string][]string{"DPS": {"b", "d"}, "Healer": {"c"}, "Tank": {"a"}},
+			wantOverflow: map[string][]string{"DPS": {"e"}, "Healer": {}, "Tank": {}},
+		},
+		{
+			name:         "earlier signups keep their slot",
+			signups:      []string{"a:healer", "b:healer>tank", "c:tank", "d:healer"},
+			wantMain:     map[string][]string{"DPS": {}, "Healer": {"a"}, "Tank": {"b"}},
+			wantOverflow: map[string][]string{"DPS": {}, "Healer": {"d"}, "Tank": {"c"}},
+		},
+		{
+			name:         "unknown roles are ignored",
+			signups:      []string{"a:bard>dps", "b:bard"},
+			wantMain:     map[string][]string{"DPS": {"a"}, "Healer": {}, "Tank": {}},
+			wantOverflow: map[string][]string{"DPS": {}, "Healer": {}, "Tank": {}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			signups := make([]TrialSignup, 0, len(tt.signups))
+			for _, spec := range tt.signups {
+				signups = append(signups, su(spec))
+			}
+
+			r := BuildRoster(ctx, signups, roleCounts, nil)
+			for _, rc := range roleCounts {
+				role := rc.GetRole(ctx)
+				if got := rosterNames(ctx, r.Main(role)); !reflect.DeepEqual(got, tt.wantMain[role]) {
+					t.Errorf("Main(%q) = %v, want %v", role, got, tt.wantMain[role])
+				}
+
+				if got := rosterNames(ctx, r.Overflow(role)); !reflect.DeepEqual(got, tt.wantOverflow[role]) {
+					t.Errorf("Overflow(%q) = %v, want %v", role, got, tt.wantOverflow[role])
+				}
+			}
+		})
+	}
+}
+
+func TestRoster_AssignedRole(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	r := BuildRoster(ctx, []TrialSignup{su("a:healer>tank"), su("b:healer"), su("c:healer")}, []RoleCount{
+		testRoleCount{role: "Healer", count: 1},
+		testRoleCount{role: "Tank", count: 1},
+	}, nil)
+
+	for name, want := range map[string]string{"a": "Tank", "b": "Healer", "c": ""} {
+		if got := r.AssignedRole(name); got != want {
+			t.Errorf("AssignedRole(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestBuildRoster_groups(t *testing.T) {
+	t.Parallel()
+
+	roleCounts := []RoleCount{
+		testRoleCount{role: "Melee", count: 0, group: "DPS"},
+		testRoleCount{role: "Ranged", count: 2, group: "dps"},
+		testRoleCount{role: "Tank", count: 1},
+	}
+	groups := []RoleGroup{testRoleGroup{name: "DPS", count: 3}}
+
+	tests := []struct {
+		name         string
+		signups      []string
+		wantMain     map[string][]string
+		wantOverflow map[string][]string
+	}{
+		{
+			name:         "role and group caps",
+			signups:      []string{"a:ranged", "b:ranged", "c:ranged", "d:melee", "e:melee"},
+			wantMain:     map[string][]string{"Melee": {"d"}, "Ranged": {"a", "b"}, "Tank": {}},
+			wantOverflow: map[string][]string{"Melee": {"e"}, "Ranged": {"c"}, "Tank": {}},
+		},
+		{
+			name:         "moving out of a full group",
+			signups:      []string{"a:melee>tank", "b:ranged", "c:melee", "d:ranged"},
+			wantMain:     map[string][]string{"Melee": {"c"}, "Ranged": {"b", "d"}, "Tank": {"a"}},
+			wantOverflow: map[string][]string{"Melee": {}, "Ranged": {}, "Tank": {}},
+		},
+		{
+			name:         "moving within a group does not help",
+			signups:      []string{"a:melee>ranged", "b:melee", "c:melee", "d:tank", "e:ranged"},
+			wantMain:     map[string][]string{"Melee": {"a", "b", "c"}, "Ranged": {}, "Tank": {"d"}},
+			wantOverflow: map[string][]string{"Melee": {}, "Ranged": {"e"}, "Tank": {}},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+
+			signups := make([]TrialSignup, 0, len(tt.signups))
+			for _, spec := range tt.signups {
+				signups = append(signups, su(spec))
+			}
+
+			r := BuildRoster(ctx, signups, roleCounts, groups)
+			for _, rc := range roleCounts {
+				role := rc.GetRole(ctx)
+				if got := rosterNames(ctx, r.Main(role)); !reflect.DeepEqual(got, tt.wantMain[role]) {
+					t.Errorf("Main(%q) = %v, want %v", role, got, tt.wantMain[role])
+				}
+
+				if got := rosterNames(ctx, r.Overflow(role)); !reflect.DeepEqual(got, tt.wantOverflow[role]) {
+					t.Errorf("Overflow(%q) = %v, want %v", role, got, tt.wantOverflow[role])
+				}
+			}
+		})
+	}
+}
+
+func TestBuildRoster_groupSiblingMove(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	roleCounts := []RoleCount{
+		testRoleCount{role: "a", count: 2, group: "g"},
+		testRoleCount{role: "b", count: 1, group: "g"},
+		testRoleCount{role: "c", count: 1},
+		testRoleCount{role: "d", count: 1},
+	}
+	groups := []RoleGroup{testRoleGroup{name: "g", count: 3}}
+
+	signups := []TrialSignup{su("0:b"), su("1:d>a>c"), su("2:a"), su("3:d"), su("4:b>a>d")}
+
+	r := BuildRoster(ctx, signups, roleCounts, groups)
+	for name, want := range map[string]string{"0": "b", "1": "c", "2": "a", "3": "d", "4": "a"} {
+		if got := r.AssignedRole(name); got != want {
+			t.Errorf("AssignedRole(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+// bruteForceRoster is the most signups that can be given a slot, trying every assignment
+func bruteForceRoster(prefs [][]string, caps map[string]int, groupOf map[string]string, groupCaps map[string]int) int {
+	used := map[string]int{}
+	groupUsed := map[string]int{}
+
+	var best func(i int) int
+	best = func(i int) int {
+		if i == len(prefs) {
+			return 0
+		}
+
+		most := best(i + 1)
+		for _, role := range prefs[i] {
+			group, grouped := groupOf[role]
+			if used[role] >= caps[role] || (grouped && groupUsed[group] >= groupCaps[group]) {
+				continue
+			}
+
+			used[role]++
+			groupUsed[group]++
+			if n := 1 + best(i+1); n > most {
+				most = n
+			}
+			used[role]--
+			groupUsed[group]--
+		}
+
+		return most
+	}
+
+	return best(0)
+}
+
+func TestBuildRoster_bruteForce(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1))
+	roles := []string{"a", "b", "c", "d"}
+
+	for n := 0; n < 2000; n++ {
+		groupCap := 1 + rng.Intn(3)
+		groups := []RoleGroup{testRoleGroup{name: "g", count: uint64(groupCap)}}
+
+		byName := RoleGroupsByName(ctx, groups)
+		roleCounts := make([]RoleCount, 0, len(roles))
+		caps := map[string]int{}
+		groupOf := map[string]string{}
+		for _, role := range roles {
+			rc := testRoleCount{role: role, count: uint64(rng.Intn(3))}
+			if rng.Intn(2) == 0 {
+				rc.group = "g"
+				rc.count = uint64(1 + rng.Intn(2))
+				groupOf[role] = "g"
+			}
+			roleCounts = append(roleCounts, rc)
+			caps[role] = int(RoleCapacity(ctx, rc, byName))
+		}
+
+		numSignups := 3 + rng.Intn(4)
+		signups := make([]TrialSignup, 0, numSignups)
+		prefs := make([][]string, 0, numSignups)
+		for i := 0; i < numSignups; i++ {
+			p := rng.Perm(len(roles))[:1+rng.Intn(3)]
+			pref := make([]string, 0, len(p))
+			for _, k := range p {
+				pref = append(pref, roles[k])
+			}
+			prefs = append(prefs, pref)
+			signups = append(signups, testSignup{name: fmt.Sprint(i), role: pref[0], alternates: pref[1:]})
+		}
+
+		r := BuildRoster(ctx, signups, roleCounts, groups)
+
+		placed, groupUsed := 0, 0
+		for _, role := range roles {
+			main := r.Main(role)
+			if len(main) > caps[role] {
+				t.Fatalf("case %d: %d signups in %s, over its cap of %d", n, len(main), role, caps[role])
+			}
+			if groupOf[role] != "" {
+				groupUsed += len(main)
+			}
+			placed += len(main)
+		}
+
+		if groupUsed > groupCap {
+			t.Fatalf("case %d: %d signups in the group, over its cap of %d", n, groupUsed, groupCap)
+		}
+
+		if want := bruteForceRoster(prefs, caps, groupOf, map[string]int{"g": groupCap}); placed != want {
+			t.Fatalf("case %d: placed %d signups, but %d is possible (prefs %v, caps %v, groups %v cap %d)", n, placed, want, prefs, caps, groupOf, groupCap)
+		}
+	}
+}
+
+func TestTotalSlots(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	groups := []RoleGroup{testRoleGroup{name: "DPS", count: 8}, testRoleGroup{name: "Support", count: 4}}
+
+	roleCounts := []RoleCount{
+		testRoleCount{role: "Melee", count: 0, group: "DPS"},
+		testRoleCount{role: "Ranged", count: 2, group: "DPS"},
+		testRoleCount{role: "Healer", count: 1, group: "Support"},
+		testRoleCount{role: "Buffer", count: 1, group: "Support"},
+		testRoleCount{role: "Tank", count: 2},
+		testRoleCount{role: "Bard", count: 1, group: "Missing"},
+	}
+
+	if got, want := TotalSlots(ctx, roleCounts, groups), uint64(8+2+2+1); got != want {
+		t.Errorf("TotalSlots() = %d, want %d", got, want)
+	}
+}
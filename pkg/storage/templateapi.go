@@ -0,0 +1,14 @@
+package storage
+
+import (
+	"context"
+)
+
+// TemplateAPI is the api for managing the per-guild message templates (per-event templates are
+// stored with the event)
+type TemplateAPI interface {
+	// GuildTemplates returns the templates of a guild by kind
+	GuildTemplates(ctx context.Context, guildID string) (map[string]string, error)
+	SetGuildTemplate(ctx context.Context, guildID, kind, text string) error
+	RemoveGuildTemplate(ctx context.Context, guildID, kind string) error
+}
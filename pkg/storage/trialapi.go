@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 )
 
 //go:generate protoc --go_out=./proto --proto_path=. ./proto/trialapi.proto
@@ -37,6 +38,7 @@ type TrialAPITx interface {
 type Trial interface {
 	GetName(ctx context.Context) string
 	GetTime(ctx context.Context) string
+	GetCategory(ctx context.Context) string
 	GetDescription(ctx context.Context) string
 	GetAnnounceTo(ctx context.Context) string
 	GetAnnounceChannel(ctx context.Context) string
@@ -44,13 +46,25 @@ type Trial interface {
 	GetState(ctx context.Context) TrialState
 	GetSignups(ctx context.Context) []TrialSignup
 	GetRoleCounts(ctx context.Context) []RoleCount
+	GetRoleGroups(ctx context.Context) []RoleGroup
 	GetRoleOrder(ctx context.Context) []string
 	HideReactionsAnnounce(ctx context.Context) bool
 	HideReactionsShow(ctx context.Context) bool
+	GetSignupsOpenAt(ctx context.Context) time.Time
+	GetSignupsCloseAt(ctx context.Context) time.Time
+	GetCloseHoursBefore(ctx context.Context) uint64
+	AnnounceStateChanges(ctx context.Context) bool
+	GetReminderOffsets(ctx context.Context) string
+	GetLeader(ctx context.Context) string
+	GetPriorityTiers(ctx context.Context) string
+	GetPriorityHoursBefore(ctx context.Context) uint64
+	GetTemplate(ctx context.Context, kind string) string
+	TemplateKinds(ctx context.Context) []string
 	PrettySettings(ctx context.Context) string
 
 	SetName(ctx context.Context, name string)
 	SetTime(ctx context.Context, t string)
+	SetCategory(ctx context.Context, cat string)
 	SetDescription(ctx context.Context, d string)
 	SetAnnounceTo(ctx context.Context, val string)
 	SetAnnounceChannel(ctx context.Context, val string)
@@ -58,11 +72,28 @@ type Trial interface {
 	SetState(ctx context.Context, state TrialState)
 	AddSignup(ctx context.Context, name, role string)
 	RemoveSignup(ctx context.Context, name string)
+	SetSignupNote(ctx context.Context, name, note string)
+	SetSignupAlternates(ctx context.Context, name string, roles []string)
+	SetSignupPriorityRole(ctx context.Context, name, roleID string)
 	SetRoleCount(ctx context.Context, name, emoji string, ct uint64)
+	SetRoleRequirements(ctx context.Context, name string, roleIDs []string)
+	SetRoleAliases(ctx context.Context, name string, aliases []string)
+	SetRoleParentGroup(ctx context.Context, name, group string)
 	RemoveRole(ctx context.Context, name string)
+	SetRoleGroup(ctx context.Context, name string, ct uint64)
+	RemoveRoleGroup(ctx context.Context, name string)
 	SetRoleOrder(ctx context.Context, ord []string)
 	SetHideReactionsAnnounce(ctx context.Context, val string) error
 	SetHideReactionsShow(ctx context.Context, val string) error
+	SetSignupsOpenAt(ctx context.Context, t time.Time)
+	SetSignupsCloseAt(ctx context.Context, t time.Time)
+	SetCloseHoursBefore(ctx context.Context, hours uint64)
+	SetAnnounceStateChanges(ctx context.Context, val string) error
+	SetReminderOffsets(ctx context.Context, val string) error
+	SetLeader(ctx context.Context, val string)
+	SetPriorityTiers(ctx context.Context, val string) error
+	SetPriorityHoursBefore(ctx context.Context, hours uint64)
+	SetTemplate(ctx context.Context, kind, text string)
 
 	ClearSignups(ctx context.Context)
 
@@ -73,6 +104,10 @@ type Trial interface {
 type TrialSignup interface {
 	GetName(ctx context.Context) string
 	GetRole(ctx context.Context) string
+	GetSignedUpAt(ctx context.Context) time.Time
+	GetNote(ctx context.Context) string
+	GetAlternates(ctx context.Context) []string
+	GetPriorityRole(ctx context.Context) string
 }
 
 // RoleCount is the api for managing a role in a trial
@@ -80,5 +115,14 @@ type RoleCount interface {
 	GetRole(ctx context.Context) string
 	GetCount(ctx context.Context) uint64
 	GetEmoji(ctx context.Context) string
+	GetRequiredRoles(ctx context.Context) []string
+	GetAliases(ctx context.Context) []string
+	GetGroup(ctx context.Context) string
 	Index() int
 }
+
+// RoleGroup is the api for a group of roles in a trial that share a combined cap
+type RoleGroup interface {
+	GetName(ctx context.Context) string
+	GetCount(ctx context.Context) uint64
+}
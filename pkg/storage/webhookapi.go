@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// ErrWebhookNotExist is the error returned if a webhook does not exist
+var ErrWebhookNotExist = errors.New("webhook does not exist")
+
+// Webhook is a url that is notified of roster changes in a guild
+type Webhook struct {
+	ID        int64
+	GuildID   string
+	URL       string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// WebhookAPI is the api for managing per-guild webhooks and the secret their payloads are signed with
+type WebhookAPI interface {
+	AddWebhook(ctx context.Context, guildID, url, createdBy string) (int64, error)
+	ListWebhooks(ctx context.Context, guildID string) ([]Webhook, error)
+	RemoveWebhook(ctx context.Context, guildID string, id int64) error
+
+	// WebhookSecret returns the signing secret for a guild, or "" if it has none yet
+	WebhookSecret(ctx context.Context, guildID string) (string, error)
+	SetWebhookSecret(ctx context.Context, guildID, secret string) error
+}
+
+// NewWebhookSecret generates a new random webhook signing secret
+func NewWebhookSecret() (string, error) {
+	return NewAPIToken()
+}
@@ -0,0 +1,158 @@
+// Package templates renders the admin-customizable bot messages (announcements, grouping and
+// signup confirmations) with text/template
+package templates
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// Kinds of message that can be customized
+const (
+	KindAnnounce = "announce"
+	KindGrouping = "grouping"
+	KindSignup   = "signup"
+)
+
+// MaxLength is the longest a template, or the message it renders, may be (discord's limit on
+// message content)
+const MaxLength = 2000
+
+var (
+	ErrUnknownKind = errors.New("unknown template kind")
+	ErrTooLong     = errors.New("template output is too long")
+)
+
+// defaults are used when neither the event nor the guild has a template; an empty default means
+// the bot's built-in (translated) message is used instead
+var defaults = map[string]string{
+	KindAnnounce: "",
+	KindGrouping: "",
+	KindSignup:   "",
+}
+
+// Role is a role of an event, as seen by templates
+type Role struct {
+	Name     string
+	Emoji    string
+	Count    int
+	SignedUp int
+	Overflow int
+}
+
+// Data is everything a template can refer to; it only has plain fields, so templates cannot
+// call into the rest of the bot
+type Data struct {
+	Name          string
+	Time          string
+	Roles         []Role
+	Slots         int
+	SignedUp      int
+	Overflow      int
+	SignupChannel string
+	Leader        string
+
+	// Role and IsOverflow are only set for signup confirmations
+	Role       string
+	IsOverflow bool
+}
+
+// Kinds lists the kinds of message that can be customized
+func Kinds() []string {
+	kinds := make([]string, 0, len(defaults))
+	for k := range defaults {
+		kinds = append(kinds, k)
+	}
+	sort.Strings(kinds)
+
+	return kinds
+}
+
+// Default is the template used for a kind of message when none is configured
+func Default(kind string) (string, error) {
+	tmpl, ok := defaults[kind]
+	if !ok {
+		return "", errors.Wrap(ErrUnknownKind, "no default template", "kind", kind)
+	}
+
+	return tmpl, nil
+}
+
+var funcs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// limitedBuffer refuses writes beyond MaxLength, so that a template cannot build a huge message
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > MaxLength {
+		return 0, ErrTooLong
+	}
+
+	return b.Buffer.Write(p)
+}
+
+// Render executes the template text with the given data
+func Render(text string, data Data) (string, error) {
+	if len(text) > MaxLength {
+		return "", errors.WithDetails(errors.New("template is too long"), "max_length", MaxLength)
+	}
+
+	tmpl, err := template.New("message").Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrap(err, "could not parse template")
+	}
+
+	var buf limitedBuffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		if err == ErrTooLong { // write errors are returned as-is
+			return "", errors.Wrap(ErrTooLong, "could not render template", "max_length", MaxLength)
+		}
+		return "", errors.Wrap(err, "could not render template")
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// Validate checks that the template text parses, and renders with example data for its kind
+func Validate(kind, text string) error {
+	if _, ok := defaults[kind]; !ok {
+		return errors.WithDetails(ErrUnknownKind, "kind", kind)
+	}
+
+	_, err := Render(text, Example(kind))
+	return err
+}
+
+// Example is made-up data for previewing a kind of message without an event
+func Example(kind string) Data {
+	d := Data{
+		Name: "Example Raid",
+		Time: "<t:1700000000:F>",
+		Roles: []Role{
+			{Name: "Tank", Emoji: "🛡️", Count: 2, SignedUp: 2, Overflow: 1},
+			{Name: "Healer", Emoji: "💚", Count: 2, SignedUp: 1},
+			{Name: "DPS", Emoji: "⚔️", Count: 6, SignedUp: 4},
+		},
+		Slots:         10,
+		SignedUp:      7,
+		Overflow:      1,
+		SignupChannel: "#signups",
+		Leader:        "@leader",
+	}
+
+	if kind == KindSignup {
+		d.Role = "Healer"
+	}
+
+	return d
+}
@@ -0,0 +1,87 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "fields",
+			text: "{{.Name}} at {{.Time}} led by {{.Leader}} in {{.SignupChannel}}",
+			want: "Example Raid at <t:1700000000:F> led by @leader in #signups",
+		},
+		{
+			name: "roles",
+			text: "{{range .Roles}}{{.Name}} {{.SignedUp}}/{{.Count}};{{end}} {{.SignedUp}}/{{.Slots}}",
+			want: "Tank 2/2;Healer 1/2;DPS 4/6; 7/10",
+		},
+		{
+			name: "upper",
+			text: "{{upper .Name}}!",
+			want: "EXAMPLE RAID!",
+		},
+		{
+			name:    "unknown field",
+			text:    "{{.Secret}}",
+			wantErr: true,
+		},
+		{
+			name:    "parse error",
+			text:    "{{.Name",
+			wantErr: true,
+		},
+		{
+			name:    "too long output",
+			text:    "{{range .Roles}}" + strings.Repeat("x", MaxLength/2) + "{{end}}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Render(tt.text, Example(KindAnnounce))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Render() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("Render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range Kinds() {
+		def, err := Default(kind)
+		if err != nil {
+			t.Fatalf("Default(%q) error = %v", kind, err)
+		}
+
+		if err := Validate(kind, def); err != nil {
+			t.Errorf("default %s template is invalid: %v", kind, err)
+		}
+	}
+
+	if err := Validate(KindSignup, "{{if .IsOverflow}}overflow {{end}}{{.Role}}"); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if err := Validate("nope", "hi"); err == nil {
+		t.Error("Validate() accepted an unknown kind")
+	}
+}
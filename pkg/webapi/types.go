@@ -0,0 +1,152 @@
+package webapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// EventList is the response for the event list
+type EventList struct {
+	Events []Event `json:"events"`
+}
+
+// Event is the summary of an event
+type Event struct {
+	Name        string     `json:"name"`
+	State       string     `json:"state"`
+	Time        string     `json:"time"`
+	StartsAt    *time.Time `json:"starts_at,omitempty"`
+	Category    string     `json:"category,omitempty"`
+	Description string     `json:"description"`
+	Signups     int        `json:"signups"`
+	Slots       uint64     `json:"slots"`
+}
+
+// EventDetail is an event with its full roster
+type EventDetail struct {
+	Event
+	Groups []RoleGroup `json:"groups,omitempty"`
+	Roles  []Role      `json:"roles"`
+}
+
+// RoleGroup is a group of roles in an event that share a combined cap
+type RoleGroup struct {
+	Name  string `json:"name"`
+	Count uint64 `json:"count"`
+}
+
+// Role is the roster of one role in an event
+type Role struct {
+	Name     string   `json:"name"`
+	Emoji    string   `json:"emoji,omitempty"`
+	Count    uint64   `json:"count"`
+	Group    string   `json:"group,omitempty"`
+	Main     []Signup `json:"main"`
+	Overflow []Signup `json:"overflow"`
+}
+
+// Signup is a single signup; UserID is set when the signup is a discord user mention
+type Signup struct {
+	Name       string   `json:"name"`
+	UserID     string   `json:"user_id,omitempty"`
+	Alternates []string `json:"alternates,omitempty"`
+}
+
+// Settings are the public settings of a guild
+type Settings struct {
+	AnnounceChannel  string `json:"announce_channel"`
+	SignupChannel    string `json:"signup_channel"`
+	MessageColor     string `json:"message_color"`
+	ErrorColor       string `json:"error_color"`
+	SignupLimit      string `json:"signup_limit"`
+	ReminderOffsets  string `json:"reminder_offsets"`
+	ReminderDelivery string `json:"reminder_delivery"`
+	Language         string `json:"language"`
+}
+
+func newSignup(name string) Signup {
+	s := Signup{Name: name}
+
+	if strings.HasPrefix(name, "<@") && strings.HasSuffix(name, ">") {
+		s.UserID = strings.TrimPrefix(name[2:len(name)-1], "!")
+	}
+
+	return s
+}
+
+func eventSummary(ctx context.Context, trial storage.Trial) Event {
+	e := Event{
+		Name:        trial.GetName(ctx),
+		State:       string(trial.GetState(ctx)),
+		Time:        trial.GetTime(ctx),
+		Category:    trial.GetCategory(ctx),
+		Description: trial.GetDescription(ctx),
+		Signups:     len(trial.GetSignups(ctx)),
+	}
+
+	if start, ok := storage.ParseEventTime(e.Time); ok {
+		e.StartsAt = &start
+	}
+
+	e.Slots = storage.TotalSlots(ctx, trial.GetRoleCounts(ctx), trial.GetRoleGroups(ctx))
+
+	return e
+}
+
+// eventDetail splits the signups for each role into the main roster and overflow (the same way the
+// show command does)
+func eventDetail(ctx context.Context, trial storage.Trial) EventDetail {
+	d := EventDetail{
+		Event: eventSummary(ctx, trial),
+	}
+
+	groups := trial.GetRoleGroups(ctx)
+	byName := storage.RoleGroupsByName(ctx, groups)
+	for _, g := range groups {
+		d.Groups = append(d.Groups, RoleGroup{Name: g.GetName(ctx), Count: g.GetCount(ctx)})
+	}
+
+	roster := storage.TrialRoster(ctx, trial)
+	for _, rc := range trial.GetRoleCounts(ctx) {
+		role := Role{
+			Name:     rc.GetRole(ctx),
+			Emoji:    rc.GetEmoji(ctx),
+			Count:    storage.RoleCapacity(ctx, rc, byName),
+			Group:    rc.GetGroup(ctx),
+			Main:     []Signup{},
+			Overflow: []Signup{},
+		}
+
+		for _, su := range roster.Main(role.Name) {
+			s := newSignup(su.GetName(ctx))
+			s.Alternates = su.GetAlternates(ctx)
+			role.Main = append(role.Main, s)
+		}
+
+		for _, su := range roster.Overflow(role.Name) {
+			s := newSignup(su.GetName(ctx))
+			s.Alternates = su.GetAlternates(ctx)
+			role.Overflow = append(role.Overflow, s)
+		}
+
+		d.Roles = append(d.Roles, role)
+	}
+
+	return d
+}
+
+func publicSettings(s storage.GuildSettings) Settings {
+	return Settings{
+		AnnounceChannel:  s.AnnounceChannel,
+		SignupChannel:    s.SignupChannel,
+		MessageColor:     s.MessageColor,
+		ErrorColor:       s.ErrorColor,
+		SignupLimit:      s.SignupLimit,
+		ReminderOffsets:  s.ReminderOffsets,
+		ReminderDelivery: s.ReminderDelivery,
+		Language:         s.Language,
+	}
+}
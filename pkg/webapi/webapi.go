@@ -0,0 +1,193 @@
+package webapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gsmcwhirter/discord-bot-lib/v23/snowflake"
+	"github.com/gsmcwhirter/go-util/v8/deferutil"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+// PathPrefix is where the api is served
+const PathPrefix = "/api/v1/"
+
+type Logger = interface {
+	Log(keyvals ...interface{}) error
+	Message(string, ...interface{})
+	Err(string, error, ...interface{})
+	Printf(string, ...interface{})
+}
+
+type dependencies interface {
+	Logger() Logger
+	TrialAPI() storage.TrialAPI
+	GuildAPI() storage.GuildAPI
+	APITokenAPI() storage.APITokenAPI
+	Census() *telemetry.Census
+}
+
+// Handler serves the read-only json api:
+//
+//	GET /api/v1/guilds/{gid}/events
+//	GET /api/v1/guilds/{gid}/events/{name}
+//	GET /api/v1/guilds/{gid}/settings
+//
+// Requests must carry a guild api token as "Authorization: Bearer TOKEN".
+type Handler struct {
+	deps dependencies
+}
+
+var _ http.Handler = (*Handler)(nil)
+
+// NewHandler creates a new Handler
+func NewHandler(deps dependencies) *Handler {
+	return &Handler{
+		deps: deps,
+	}
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (h *Handler) writeJSON(ctx context.Context, w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		level.Error(h.deps.Logger()).Err("could not write api response", err)
+	}
+}
+
+func (h *Handler) writeError(ctx context.Context, w http.ResponseWriter, status int, msg string) {
+	h.writeJSON(ctx, w, status, errorResponse{Error: msg})
+}
+
+func bearerToken(req *http.Request) string {
+	auth := req.Header.Get("Authorization")
+	if len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		return strings.TrimSpace(auth[7:])
+	}
+
+	return ""
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx, span := h.deps.Census().StartSpan(req.Context(), "webapi.ServeHTTP")
+	defer span.End()
+
+	logger := h.deps.Logger()
+
+	if req.Method != http.MethodGet {
+		h.writeError(ctx, w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	// use the escaped path so that event names containing slashes survive the split
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.EscapedPath(), PathPrefix), "/"), "/")
+	if len(parts) < 3 || parts[0] != "guilds" {
+		h.writeError(ctx, w, http.StatusNotFound, "not found")
+		return
+	}
+
+	gid, err := snowflake.FromString(parts[1])
+	if err != nil {
+		h.writeError(ctx, w, http.StatusNotFound, "not found")
+		return
+	}
+
+	tokenGuild, err := h.deps.APITokenAPI().GuildForToken(ctx, bearerToken(req))
+	if err == storage.ErrTokenNotExist || (err == nil && tokenGuild != gid.ToString()) {
+		h.writeError(ctx, w, http.StatusUnauthorized, "invalid api token")
+		return
+	}
+	if err != nil {
+		level.Error(logger).Err("could not check api token", err)
+		h.writeError(ctx, w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && parts[2] == "events":
+		h.listEvents(ctx, w, gid)
+	case len(parts) == 4 && parts[2] == "events":
+		name, err := url.PathUnescape(parts[3])
+		if err != nil {
+			h.writeError(ctx, w, http.StatusNotFound, "not found")
+			return
+		}
+		h.showEvent(ctx, w, gid, name)
+	case len(parts) == 3 && parts[2] == "settings":
+		h.settings(ctx, w, gid)
+	default:
+		h.writeError(ctx, w, http.StatusNotFound, "not found")
+	}
+}
+
+func (h *Handler) listEvents(ctx context.Context, w http.ResponseWriter, gid snowflake.Snowflake) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "webapi.listEvents", "guild_id", gid.ToString())
+	defer span.End()
+
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		level.Error(h.deps.Logger()).Err("could not start transaction", err)
+		h.writeError(ctx, w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trials := t.GetTrials(ctx)
+	events := make([]Event, 0, len(trials))
+	for _, trial := range trials {
+		events = append(events, eventSummary(ctx, trial))
+	}
+
+	h.writeJSON(ctx, w, http.StatusOK, EventList{Events: events})
+}
+
+func (h *Handler) showEvent(ctx context.Context, w http.ResponseWriter, gid snowflake.Snowflake, name string) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "webapi.showEvent", "guild_id", gid.ToString())
+	defer span.End()
+
+	t, err := h.deps.TrialAPI().NewTransaction(ctx, gid.ToString(), false)
+	if err != nil {
+		level.Error(h.deps.Logger()).Err("could not start transaction", err)
+		h.writeError(ctx, w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	defer deferutil.CheckDefer(func() error { return t.Rollback(ctx) })
+
+	trial, err := t.GetTrial(ctx, name)
+	if err == storage.ErrTrialNotExist {
+		h.writeError(ctx, w, http.StatusNotFound, "event not found")
+		return
+	}
+	if err != nil {
+		level.Error(h.deps.Logger()).Err("could not get event", err, "trial_name", name)
+		h.writeError(ctx, w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.writeJSON(ctx, w, http.StatusOK, eventDetail(ctx, trial))
+}
+
+func (h *Handler) settings(ctx context.Context, w http.ResponseWriter, gid snowflake.Snowflake) {
+	ctx, span := h.deps.Census().StartSpan(ctx, "webapi.settings", "guild_id", gid.ToString())
+	defer span.End()
+
+	gsettings, err := storage.GetSettings(ctx, h.deps.GuildAPI(), gid)
+	if err != nil {
+		level.Error(h.deps.Logger()).Err("could not get guild settings", err)
+		h.writeError(ctx, w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	h.writeJSON(ctx, w, http.StatusOK, publicSettings(gsettings))
+}
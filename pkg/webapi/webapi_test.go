@@ -0,0 +1,262 @@
+package webapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+func TestBearerToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{name: "bearer", header: "Bearer abc123", want: "abc123"},
+		{name: "lowercase", header: "bearer abc123", want: "abc123"},
+		{name: "basic", header: "Basic abc123", want: ""},
+		{name: "empty", header: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req, _ := http.NewRequest(http.MethodGet, "/api/v1/guilds/1/events", nil)
+			req.Header.Set("Authorization", tt.header)
+
+			if got := bearerToken(req); got != tt.want {
+				t.Errorf("bearerToken() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSignup(t *testing.T) {
+	t.Parallel()
+
+	if s := newSignup("<@!123>"); s.UserID != "123" {
+		t.Errorf("newSignup() user id = %q, want %q", s.UserID, "123")
+	}
+
+	if s := newSignup("someone"); s.UserID != "" || s.Name != "someone" {
+		t.Errorf("newSignup() = %+v, want plain name", s)
+	}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Log(...interface{}) error { return nil }
+
+// testTokens is an APITokenAPI that knows which guild each token belongs to
+type testTokens struct {
+	storage.APITokenAPI
+	guilds map[string]string
+	err    error
+}
+
+func (a testTokens) GuildForToken(ctx context.Context, token string) (string, error) {
+	if a.err != nil {
+		return "", a.err
+	}
+
+	gid, ok := a.guilds[token]
+	if !ok {
+		return "", storage.ErrTokenNotExist
+	}
+
+	return gid, nil
+}
+
+type testTrial struct {
+	storage.Trial
+	name string
+}
+
+func (t testTrial) GetName(context.Context) string                    { return t.name }
+func (t testTrial) GetState(context.Context) storage.TrialState       { return storage.TrialStateOpen }
+func (t testTrial) GetTime(context.Context) string                    { return "" }
+func (t testTrial) GetCategory(context.Context) string                { return "" }
+func (t testTrial) GetDescription(context.Context) string             { return "" }
+func (t testTrial) GetPriorityTiers(context.Context) string           { return "" }
+func (t testTrial) GetSignups(context.Context) []storage.TrialSignup  { return nil }
+func (t testTrial) GetRoleCounts(context.Context) []storage.RoleCount { return nil }
+func (t testTrial) GetRoleGroups(context.Context) []storage.RoleGroup { return nil }
+
+// testTrialAPI holds the event names of each guild
+type testTrialAPI struct {
+	events map[string][]string
+}
+
+func (a testTrialAPI) NewTransaction(ctx context.Context, guild string, writable bool) (storage.TrialAPITx, error) {
+	return testTrialTx{events: a.events[guild]}, nil
+}
+
+type testTrialTx struct {
+	storage.TrialAPITx
+	events []string
+}
+
+func (t testTrialTx) Rollback(context.Context) error { return nil }
+
+func (t testTrialTx) GetTrial(ctx context.Context, name string) (storage.Trial, error) {
+	for _, e := range t.events {
+		if strings.EqualFold(e, name) {
+			return testTrial{name: e}, nil
+		}
+	}
+
+	return nil, storage.ErrTrialNotExist
+}
+
+func (t testTrialTx) GetTrials(context.Context) []storage.Trial {
+	trials := make([]storage.Trial, 0, len(t.events))
+	for _, e := range t.events {
+		trials = append(trials, testTrial{name: e})
+	}
+
+	return trials
+}
+
+// testGuildAPI holds the settings of each guild
+type testGuildAPI struct {
+	storage.GuildAPI
+	settings map[string]storage.GuildSettings
+}
+
+func (a testGuildAPI) NewTransaction(ctx context.Context, writable bool) (storage.GuildAPITx, error) {
+	return testGuildTx{settings: a.settings}, nil
+}
+
+type testGuildTx struct {
+	storage.GuildAPITx
+	settings map[string]storage.GuildSettings
+}
+
+func (t testGuildTx) Rollback(context.Context) error { return nil }
+
+func (t testGuildTx) AddGuild(ctx context.Context, name string) (storage.Guild, error) {
+	return testGuild{settings: t.settings[name]}, nil
+}
+
+type testGuild struct {
+	storage.Guild
+	settings storage.GuildSettings
+}
+
+func (g testGuild) GetSettings(context.Context) storage.GuildSettings { return g.settings }
+
+type testDeps struct {
+	tokens testTokens
+}
+
+func (d testDeps) Logger() Logger { return logging.NewFrom(nopLogger{}) }
+
+func (d testDeps) TrialAPI() storage.TrialAPI {
+	return testTrialAPI{events: map[string][]string{"1": {"Raid", "a/b"}, "2": {"Secret"}}}
+}
+
+func (d testDeps) GuildAPI() storage.GuildAPI {
+	return testGuildAPI{settings: map[string]storage.GuildSettings{
+		"1": {SignupChannel: "signups"},
+		"2": {SignupChannel: "secret-signups"},
+	}}
+}
+
+func (d testDeps) APITokenAPI() storage.APITokenAPI { return d.tokens }
+func (d testDeps) Census() *telemetry.Census        { return &telemetry.Census{} }
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	tokens := testTokens{guilds: map[string]string{"tok1": "1", "tok2": "2"}}
+
+	tests := []struct {
+		name       string
+		tokens     testTokens
+		method     string
+		path       string
+		auth       string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "events", path: "/api/v1/guilds/1/events", auth: "Bearer tok1", wantStatus: http.StatusOK, wantBody: `"name":"Raid"`},
+		{name: "event", path: "/api/v1/guilds/1/events/raid", auth: "Bearer tok1", wantStatus: http.StatusOK, wantBody: `"name":"Raid"`},
+		{name: "event with a slash", path: "/api/v1/guilds/1/events/a%2Fb", auth: "Bearer tok1", wantStatus: http.StatusOK, wantBody: `"name":"a/b"`},
+		{name: "unknown event", path: "/api/v1/guilds/1/events/dungeon", auth: "Bearer tok1", wantStatus: http.StatusNotFound},
+		{name: "settings", path: "/api/v1/guilds/1/settings", auth: "Bearer tok1", wantStatus: http.StatusOK, wantBody: `"signup_channel":"signups"`},
+		{name: "unknown route", path: "/api/v1/guilds/1/members", auth: "Bearer tok1", wantStatus: http.StatusNotFound},
+		{name: "not a guild", path: "/api/v1/events", auth: "Bearer tok1", wantStatus: http.StatusNotFound},
+		{name: "bad guild id", path: "/api/v1/guilds/abc/events", auth: "Bearer tok1", wantStatus: http.StatusNotFound},
+		{name: "not a get", method: http.MethodPost, path: "/api/v1/guilds/1/events", auth: "Bearer tok1", wantStatus: http.StatusMethodNotAllowed},
+		{name: "missing token", path: "/api/v1/guilds/1/events", wantStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", path: "/api/v1/guilds/1/events", auth: "Basic tok1", wantStatus: http.StatusUnauthorized},
+		{name: "unknown token", path: "/api/v1/guilds/1/events", auth: "Bearer nope", wantStatus: http.StatusUnauthorized},
+		{name: "token store failing", tokens: testTokens{err: errors.New("db down")}, path: "/api/v1/guilds/1/events", auth: "Bearer tok1", wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if tt.tokens.guilds == nil && tt.tokens.err == nil {
+				tt.tokens = tokens
+			}
+
+			if tt.method == "" {
+				tt.method = http.MethodGet
+			}
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.auth != "" {
+				req.Header.Set("Authorization", tt.auth)
+			}
+
+			w := httptest.NewRecorder()
+			NewHandler(testDeps{tokens: tt.tokens}).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_otherGuild(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(testDeps{tokens: testTokens{guilds: map[string]string{"tok1": "1", "tok2": "2"}}})
+
+	// a token only ever reads its own guild
+	for _, path := range []string{"/api/v1/guilds/2/events", "/api/v1/guilds/2/events/secret", "/api/v1/guilds/2/settings"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Authorization", "Bearer tok1")
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: status = %d, want %d", path, w.Code, http.StatusUnauthorized)
+		}
+
+		if body := w.Body.String(); strings.Contains(body, "Secret") || strings.Contains(body, "secret-signups") {
+			t.Errorf("%s: guild 2 data leaked to a guild 1 token: %s", path, body)
+		}
+	}
+}
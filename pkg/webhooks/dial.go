@@ -0,0 +1,85 @@
+package webhooks
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+)
+
+// ErrForbiddenAddress is the error returned when a webhook would connect to an address inside the
+// bot's own network
+var ErrForbiddenAddress = errors.New("webhook address is not a public address")
+
+// ErrInsecureURL is the error returned for a webhook url that is not https
+var ErrInsecureURL = errors.New("webhook url must be https")
+
+// publicIP determines whether a webhook may connect to ip: loopback, private, link-local,
+// multicast and unspecified addresses are all refused
+func publicIP(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// checkDial is a net.Dialer Control func refusing connections to non-public addresses. It runs after
+// the host is resolved (at send time, for every connection, including redirects), so a webhook host
+// cannot be pointed at an internal address after it was added.
+func checkDial(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(err, "could not parse webhook address", "address", address)
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !publicIP(ip) {
+		return errors.Wrap(ErrForbiddenAddress, "refusing to connect", "address", address)
+	}
+
+	return nil
+}
+
+// checkURL makes sure a webhook url is an https url
+func checkURL(raw string) error {
+	// the url is left out of errors, since it may carry a token
+	u, err := url.Parse(raw)
+	if err != nil {
+		return errors.New("could not parse webhook url")
+	}
+
+	if u.Scheme != "https" || u.Host == "" {
+		return errors.Wrap(ErrInsecureURL, "refusing webhook url", "scheme", u.Scheme, "host", u.Host)
+	}
+
+	return nil
+}
+
+// newClient creates the http client used to deliver webhooks; it never uses a proxy (so the
+// address checks apply to the webhook host itself) and only follows redirects to https urls
+func newClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   checkDial,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   timeout,
+			ExpectContinueTimeout: time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 5 {
+				return errors.New("too many webhook redirects")
+			}
+			return checkURL(req.URL.String())
+		},
+	}
+}
@@ -0,0 +1,313 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gsmcwhirter/go-util/v8/errors"
+	"github.com/gsmcwhirter/go-util/v8/logging/level"
+	"github.com/gsmcwhirter/go-util/v8/telemetry"
+
+	"github.com/gsmcwhirter/discord-signup-bot/pkg/storage"
+)
+
+type Logger = interface {
+	Log(keyvals ...interface{}) error
+	Message(string, ...interface{})
+	Err(string, error, ...interface{})
+	Printf(string, ...interface{})
+}
+
+type dependencies interface {
+	Logger() Logger
+	WebhookAPI() storage.WebhookAPI
+	Census() *telemetry.Census
+}
+
+// Doer performs an http request
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Kinds of roster changes that are sent to webhooks
+const (
+	KindSignup   = "signup"
+	KindWithdraw = "withdraw"
+	KindOpen     = "open"
+	KindClose    = "close"
+	KindCreate   = "create"
+	KindDelete   = "delete"
+)
+
+// SignatureHeader carries the hex hmac-sha256 of the request body, keyed with the guild webhook secret
+const SignatureHeader = "X-Signup-Bot-Signature"
+
+var ErrBadResponse = errors.New("bad response from webhook")
+
+// Event is the json payload sent to webhooks
+type Event struct {
+	Kind      string    `json:"event"`
+	GuildID   string    `json:"guild_id"`
+	EventName string    `json:"event_name"`
+	State     string    `json:"state,omitempty"`
+	Member    string    `json:"member,omitempty"`
+	Role      string    `json:"role,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Options configures the delivery behavior of the queue
+type Options struct {
+	Workers     int
+	QueueSize   int
+	MaxAttempts int
+	RetryDelay  time.Duration
+	Timeout     time.Duration
+}
+
+type delivery struct {
+	id      int64
+	url     string
+	kind    string
+	body    []byte
+	sig     string
+	attempt int
+}
+
+// host is the host of the webhook url; the rest of the url is never logged, since webhook urls
+// often carry a token in the path or query
+func (d delivery) host() string {
+	u, err := url.Parse(d.url)
+	if err != nil {
+		return ""
+	}
+
+	return u.Host
+}
+
+// Queue delivers events to guild webhooks in the background.
+//
+// Notify never blocks: if the queue is full the event is dropped (and logged). Failed deliveries
+// are retried with exponential backoff, but retries are not persisted across restarts.
+type Queue struct {
+	deps    dependencies
+	opts    Options
+	doer    Doer
+	events  chan Event
+	retries chan delivery
+}
+
+// NewQueue creates a new Queue
+func NewQueue(deps dependencies, opts Options) *Queue {
+	if opts.Workers <= 0 {
+		opts.Workers = 4
+	}
+
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 256
+	}
+
+	if opts.MaxAttempts <= 0 {
+		opts.MaxAttempts = 5
+	}
+
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = 5 * time.Second
+	}
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+
+	return &Queue{
+		deps:    deps,
+		opts:    opts,
+		doer:    newClient(opts.Timeout),
+		events:  make(chan Event, opts.QueueSize),
+		retries: make(chan delivery, opts.QueueSize),
+	}
+}
+
+// Sign computes the signature of a payload
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notify queues an event for delivery to the webhooks of its guild
+func (q *Queue) Notify(ev Event) {
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now().UTC()
+	}
+
+	select {
+	case q.events <- ev:
+	default:
+		level.Error(q.deps.Logger()).Message("webhook queue full; dropping event", "guild_id", ev.GuildID, "kind", ev.Kind, "event_name", ev.EventName)
+	}
+}
+
+// Start runs the delivery workers until the context is canceled
+func (q *Queue) Start(ctx context.Context) error {
+	level.Info(q.deps.Logger()).Message("starting webhook queue", "workers", q.opts.Workers)
+
+	done := make(chan struct{})
+	for i := 0; i < q.opts.Workers; i++ {
+		go func() {
+			defer func() { done <- struct{}{} }()
+			q.work(ctx)
+		}()
+	}
+
+	for i := 0; i < q.opts.Workers; i++ {
+		<-done
+	}
+
+	level.Info(q.deps.Logger()).Message("stopping webhook queue")
+	return nil
+}
+
+func (q *Queue) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-q.events:
+			q.dispatch(ctx, ev)
+		case d := <-q.retries:
+			q.deliver(ctx, d)
+		}
+	}
+}
+
+// dispatch looks up the webhooks for the event's guild and delivers to each
+func (q *Queue) dispatch(ctx context.Context, ev Event) {
+	ctx, span := q.deps.Census().StartSpan(ctx, "webhooks.dispatch", "guild_id", ev.GuildID, "kind", ev.Kind)
+	defer span.End()
+
+	logger := q.deps.Logger()
+
+	hooks, err := q.deps.WebhookAPI().ListWebhooks(ctx, ev.GuildID)
+	if err != nil {
+		level.Error(logger).Err("could not list webhooks", err, "guild_id", ev.GuildID)
+		return
+	}
+
+	if len(hooks) == 0 {
+		return
+	}
+
+	secret, err := q.deps.WebhookAPI().WebhookSecret(ctx, ev.GuildID)
+	if err != nil {
+		level.Error(logger).Err("could not get webhook secret", err, "guild_id", ev.GuildID)
+		return
+	}
+
+	if secret == "" {
+		level.Error(logger).Message("guild has webhooks but no webhook secret; not sending", "guild_id", ev.GuildID)
+		return
+	}
+
+	body, err := json.Marshal(ev)
+	if err != nil {
+		level.Error(logger).Err("could not marshal webhook event", err, "guild_id", ev.GuildID)
+		return
+	}
+
+	sig := Sign(secret, body)
+	for _, h := range hooks {
+		q.deliver(ctx, delivery{
+			id:      h.ID,
+			url:     h.URL,
+			kind:    ev.Kind,
+			body:    body,
+			sig:     sig,
+			attempt: 1,
+		})
+	}
+}
+
+func (q *Queue) deliver(ctx context.Context, d delivery) {
+	ctx, span := q.deps.Census().StartSpan(ctx, "webhooks.deliver", "kind", d.kind)
+	defer span.End()
+
+	logger := q.deps.Logger()
+
+	retry, err := q.post(ctx, d)
+	if err == nil {
+		return
+	}
+
+	if !retry || d.attempt >= q.opts.MaxAttempts {
+		level.Error(logger).Err("webhook delivery failed; giving up", err, "webhook_id", d.id, "host", d.host(), "kind", d.kind, "attempt", d.attempt)
+		return
+	}
+
+	delay := q.opts.RetryDelay << uint(d.attempt-1)
+	level.Info(logger).Message("webhook delivery failed; will retry", "webhook_id", d.id, "host", d.host(), "kind", d.kind, "attempt", d.attempt, "delay", delay.String(), "error", err.Error())
+
+	d.attempt++
+	time.AfterFunc(delay, func() {
+		select {
+		case q.retries <- d:
+		case <-ctx.Done():
+		}
+	})
+}
+
+// post sends one delivery attempt; the bool says whether a failure is worth retrying
+func (q *Queue) post(ctx context.Context, d delivery) (bool, error) {
+	// webhooks added before https was required may still be stored with http urls
+	if err := checkURL(d.url); err != nil {
+		return false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(d.body))
+	if err != nil {
+		return false, errors.Wrap(err, "could not create webhook request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "discord-signup-bot")
+	req.Header.Set(SignatureHeader, "sha256="+d.sig)
+	req.Header.Set("X-Signup-Bot-Event", d.kind)
+	req.Header.Set("X-Signup-Bot-Attempt", strconv.Itoa(d.attempt))
+
+	resp, err := q.doer.Do(req)
+	if err != nil {
+		// the client's errors include the full url
+		var uerr *url.Error
+		if stderrors.As(err, &uerr) {
+			err = uerr.Err
+		}
+
+		// a forbidden address will not become allowed on a retry
+		return !stderrors.Is(err, ErrForbiddenAddress), errors.Wrap(err, "could not send webhook")
+	}
+	defer resp.Body.Close() //nolint:errcheck // not needed
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 200 && resp.StatusCode <= 299 {
+		return false, nil
+	}
+
+	return retryable(resp.StatusCode), errors.Wrap(ErrBadResponse, "webhook returned an error", "status", resp.StatusCode)
+}
+
+// retryable determines whether a failed delivery might succeed later
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusRequestTimeout || status >= 500
+}
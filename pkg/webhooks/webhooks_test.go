@@ -0,0 +1,114 @@
+package webhooks
+
+import (
+	"context"
+	stderrors "errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSign(t *testing.T) {
+	t.Parallel()
+
+	// echo -n '{"event":"signup"}' | openssl dgst -sha256 -hmac secret
+	want := "1b732c4c5739f4ed5be23bfe04f9b87a8675ff032aca403a46606e9fcbe177c2"
+	if got := Sign("secret", []byte(`{"event":"signup"}`)); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+
+	if Sign("other", []byte(`{"event":"signup"}`)) == want {
+		t.Error("Sign() ignored the secret")
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{status: http.StatusBadRequest, want: false},
+		{status: http.StatusNotFound, want: false},
+		{status: http.StatusRequestTimeout, want: true},
+		{status: http.StatusTooManyRequests, want: true},
+		{status: http.StatusInternalServerError, want: true},
+		{status: http.StatusBadGateway, want: true},
+	}
+
+	for _, tt := range tests {
+		if got := retryable(tt.status); got != tt.want {
+			t.Errorf("retryable(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "8.8.8.8", want: true},
+		{ip: "2606:4700:4700::1111", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.1.2.3", want: false},
+		{ip: "172.16.0.1", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "fd00::1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "::ffff:127.0.0.1", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := publicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("publicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	t.Parallel()
+
+	for raw, wantErr := range map[string]bool{
+		"https://example.com/hook": false,
+		"http://example.com/hook":  true,
+		"ftp://example.com/hook":   true,
+		"https:///hook":            true,
+	} {
+		if err := checkURL(raw); (err != nil) != wantErr {
+			t.Errorf("checkURL(%q) error = %v, wantErr %v", raw, err, wantErr)
+		}
+	}
+}
+
+func TestPost_forbiddenAddress(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("webhook was delivered to a loopback address")
+	}))
+	defer srv.Close()
+
+	q := &Queue{doer: newClient(time.Second)}
+	retry, err := q.post(context.Background(), delivery{url: srv.URL + "/hooks/secret-token", kind: KindSignup, body: []byte("{}"), attempt: 1})
+	if !stderrors.Is(err, ErrForbiddenAddress) {
+		t.Fatalf("post() error = %v, want %v", err, ErrForbiddenAddress)
+	}
+
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("post() error leaks the webhook url: %v", err)
+	}
+
+	if retry {
+		t.Error("post() wants to retry a forbidden address")
+	}
+}